@@ -5,6 +5,8 @@ import (
 	"io"
 	"log"
 	"os"
+	"runtime"
+	"runtime/debug"
 	"strings"
 
 	"github.com/urfave/cli/v2"
@@ -32,7 +34,9 @@ const (
 	parseInternalFlag        = "parseInternal"
 	requiredByDefaultFlag    = "requiredByDefault"
 	parseDepthFlag           = "parseDepth"
+	maxTypeDepthFlag         = "maxTypeDepth"
 	instanceNameFlag         = "instanceName"
+	outputFileNameFlag       = "outputFileName"
 	overridesFileFlag        = "overridesFile"
 	parseGoListFlag          = "parseGoList"
 	quietFlag                = "quiet"
@@ -44,6 +48,39 @@ const (
 	parseFuncBodyFlag        = "parseFuncBody"
 	parseGoPackagesFlag      = "parseGoPackages"
 	debugFlag                = "debug"
+	emitNullableFlag         = "emitNullable"
+	pointersOptionalFlag     = "pointersOptional"
+	outputWarningsFlag       = "outputWarnings"
+	bundleFlag               = "bundle"
+	splitByTagFlag           = "splitByTag"
+	dryRunFlag               = "dryRun"
+	failOnWarningFlag        = "failOnWarning"
+	inlineEnumsFlag          = "inlineEnums"
+	namingStrategyFlag       = "namingStrategy"
+	namespaceByTagFlag       = "namespaceByTag"
+	keepDefinitionsFlag      = "keepDefinitions"
+	profileFlag              = "profile"
+	hostEnvFlag              = "hostEnv"
+	basePathEnvFlag          = "basePathEnv"
+	incrementalFlag          = "incremental"
+	durationAsIntFlag        = "durationAsInt"
+	emitFieldOrderFlag       = "emitFieldOrder"
+	emitOmitEmptyFlag        = "emitOmitEmpty"
+	uiFlag                   = "ui"
+	uiBundleURLFlag          = "uiBundleURL"
+	buildTagsFlag            = "buildTags"
+	strictEnumsFlag          = "strictEnums"
+	dedupeDefinitionsFlag    = "dedupeDefinitions"
+	inlineSingleUseFlag      = "inlineSingleUse"
+	maxDefinitionsFlag       = "maxDefinitions"
+)
+
+// Build metadata, set at build time with
+//
+//	go build -ldflags "-X main.commit=$(git rev-parse HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	commit    = ""
+	buildDate = ""
 )
 
 var initFlags = []cli.Flag{
@@ -66,7 +103,7 @@ var initFlags = []cli.Flag{
 	},
 	&cli.StringFlag{
 		Name:  excludeFlag,
-		Usage: "Exclude directories and files when searching, comma separated",
+		Usage: "Exclude directories and files when searching, comma separated. Entries are paths relative to the search dir; prefix an entry with glob: to match a pattern, e.g. glob:*_gen.go or glob:api/*/fixtures",
 	},
 	&cli.StringFlag{
 		Name:    propertyStrategyFlag,
@@ -84,7 +121,7 @@ var initFlags = []cli.Flag{
 		Name:    outputTypesFlag,
 		Aliases: []string{"ot"},
 		Value:   "json,yaml",
-		Usage:   "Output types of generated files (swagger.json, swagger.yaml) like json,yaml",
+		Usage:   "Output types of generated files (swagger.json, swagger.yaml, swagger.schema.json) like json,yaml,jsonschema",
 	},
 	&cli.BoolFlag{
 		Name:  parseVendorFlag,
@@ -126,20 +163,30 @@ var initFlags = []cli.Flag{
 		Value: 100,
 		Usage: "Dependency parse depth",
 	},
+	&cli.IntFlag{
+		Name:  maxTypeDepthFlag,
+		Value: 100,
+		Usage: "How deeply nested and embedded types are resolved before failing with a possible cycle",
+	},
 	&cli.BoolFlag{
 		Name:  requiredByDefaultFlag,
 		Value: true,
-		Usage: "Set validation required for all fields by default",
+		Usage: "Also require omitempty fields with a required validate or binding rule; fields without omitempty are always required",
 	},
 	&cli.StringFlag{
 		Name:  instanceNameFlag,
 		Value: "",
 		Usage: "This parameter can be used to name different swagger document instances. It is optional.",
 	},
+	&cli.StringFlag{
+		Name:  outputFileNameFlag,
+		Value: gen.DefaultOutputFileName,
+		Usage: "Base name of the generated spec files, e.g. openapi for openapi.json and openapi.yaml",
+	},
 	&cli.StringFlag{
 		Name:  overridesFileFlag,
 		Value: gen.DefaultOverridesFile,
-		Usage: "File to read global type overrides from.",
+		Usage: "File to read global type overrides from. Files ending in .yaml or .yml use the structured YAML format.",
 	},
 	&cli.BoolFlag{
 		Name:  parseGoListFlag,
@@ -185,6 +232,113 @@ var initFlags = []cli.Flag{
 		Name:  debugFlag,
 		Usage: "Enable debug mode, disabled by default",
 	},
+	&cli.BoolFlag{
+		Name:  emitNullableFlag,
+		Usage: "Mark any/interface{} and pointer-to-interface fields as nullable, disabled by default; pointer-to-slice and pointer slice element fields always are",
+	},
+	&cli.BoolFlag{
+		Name:  pointersOptionalFlag,
+		Usage: "Never mark pointer fields as required, even without omitempty or in @AllRequired structs, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  outputWarningsFlag,
+		Usage: "Write collected warnings to swagger.warnings.json in the output directory, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  bundleFlag,
+		Usage: "Inline external $ref targets into local definitions so the output is self-contained, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  splitByTagFlag,
+		Usage: "Also write a spec per operation tag to <output>/<tag> and the general info to <output>/_shared, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:    dryRunFlag,
+		Aliases: []string{"dry-run"},
+		Usage:   "Build the spec and report a summary without writing any files, exits non-zero when warnings are found with --strict or --failOnWarning",
+	},
+	&cli.BoolFlag{
+		Name:    failOnWarningFlag,
+		Aliases: []string{"fail-on-warning"},
+		Usage:   "Exit non-zero without writing the spec when warnings are found, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  inlineEnumsFlag,
+		Usage: "Inline enum values, varnames and descriptions into properties instead of referencing enum definitions, disabled by default",
+	},
+	&cli.StringFlag{
+		Name:  namingStrategyFlag,
+		Usage: "Definition naming strategy: full (package.Type, full import path on conflicts), package (package.Type) or short (Type, package suffix on conflicts). Defaults to full, or package with --useStructName",
+	},
+	&cli.BoolFlag{
+		Name:  namespaceByTagFlag,
+		Usage: "Prefix definitions only used by operations of a single tag with that tag (e.g. billing.Invoice), disabled by default",
+	},
+	&cli.StringFlag{
+		Name:  keepDefinitionsFlag,
+		Usage: "Comma-separated definitions that may be unreferenced without being reported as orphans",
+	},
+	&cli.StringFlag{
+		Name:  profileFlag,
+		Usage: "Write a pprof profile of the build to the output directory: cpu or mem",
+	},
+	&cli.StringFlag{
+		Name:  hostEnvFlag,
+		Usage: "Environment variable to resolve the host from at runtime, written as a ${NAME} placeholder instead of the literal host",
+	},
+	&cli.StringFlag{
+		Name:  basePathEnvFlag,
+		Usage: "Environment variable to resolve the basePath from at runtime, written as a ${NAME} placeholder instead of the literal basePath",
+	},
+	&cli.BoolFlag{
+		Name:  incrementalFlag,
+		Usage: "Reuse the schema definitions of the previous run for packages whose type declarations are unchanged, keeping state in swagger.state.json in the output directory",
+	},
+	&cli.BoolFlag{
+		Name:  durationAsIntFlag,
+		Usage: "Document time.Duration as integer nanoseconds instead of a string like \"5s\", disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:    emitFieldOrderFlag,
+		Aliases: []string{"emit-field-order"},
+		Usage:   "Add an x-order extension with the struct field declaration order to every property, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:    emitOmitEmptyFlag,
+		Aliases: []string{"emit-omitempty"},
+		Usage:   "Add an x-omitempty extension to properties whose json tag has omitempty, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  uiFlag,
+		Usage: "Write an index.html to the output directory that renders the generated spec with Swagger UI",
+	},
+	&cli.StringFlag{
+		Name:  uiBundleURLFlag,
+		Value: gen.DefaultUIBundleURL,
+		Usage: "swagger-ui-dist base URL the index.html loads Swagger UI from",
+	},
+	&cli.StringFlag{
+		Name:  buildTagsFlag,
+		Usage: "Build tags to load files with, so handlers and types behind build constraints are parsed, comma separated",
+	},
+	&cli.BoolFlag{
+		Name:    strictEnumsFlag,
+		Aliases: []string{"strict-enums"},
+		Usage:   "Fail when a field declared as an enum type has no enum values, e.g. because its constants were not parsed",
+	},
+	&cli.BoolFlag{
+		Name:  dedupeDefinitionsFlag,
+		Usage: "Merge definitions of the same type from different packages whose schemas are identical, e.g. a.Pagination and b.Pagination, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  inlineSingleUseFlag,
+		Usage: "Inline object definitions referenced exactly once at their use site, except enums and self-referential types, disabled by default",
+	},
+	&cli.IntFlag{
+		Name:    maxDefinitionsFlag,
+		Aliases: []string{"max-definitions"},
+		Usage:   "Fail when the spec has more definitions, listing the packages with the most, 0 is unlimited",
+	},
 }
 
 func initAction(ctx *cli.Context) error {
@@ -219,39 +373,188 @@ func initAction(ctx *cli.Context) error {
 		)
 	}
 
+	return gen.New().Build(&gen.Config{
+		SearchDir:                  ctx.String(searchDirFlag),
+		Excludes:                   ctx.String(excludeFlag),
+		ParseExtension:             ctx.String(parseExtensionFlag),
+		MainAPIFile:                ctx.String(generalInfoFlag),
+		PropNamingStrategy:         strategy,
+		OutputDir:                  ctx.String(outputFlag),
+		OutputTypes:                outputTypes,
+		ParseVendor:                ctx.Bool(parseVendorFlag),
+		ParseDependency:            parseDependencyLevel(ctx),
+		MarkdownFilesDir:           ctx.String(markdownFilesFlag),
+		ParseInternal:              ctx.Bool(parseInternalFlag),
+		UseStructNames:             ctx.Bool(useStructNameFlag),
+		RequiredByDefault:          ctx.Bool(requiredByDefaultFlag),
+		CodeExampleFilesDir:        ctx.String(codeExampleFilesFlag),
+		ParseDepth:                 ctx.Int(parseDepthFlag),
+		MaxTypeDepth:               ctx.Int(maxTypeDepthFlag),
+		InstanceName:               ctx.String(instanceNameFlag),
+		OutputFileName:             ctx.String(outputFileNameFlag),
+		OverridesFile:              ctx.String(overridesFileFlag),
+		ParseGoList:                ctx.Bool(parseGoListFlag),
+		Tags:                       ctx.String(tagsFlag),
+		Debugger:                   logger,
+		CollectionFormat:           collectionFormat,
+		PackagePrefix:              ctx.String(packagePrefixFlag),
+		State:                      ctx.String(stateFlag),
+		ParseFuncBody:              ctx.Bool(parseFuncBodyFlag),
+		ParseGoPackages:            ctx.Bool(parseGoPackagesFlag),
+		EmitNullable:               ctx.Bool(emitNullableFlag),
+		PointersOptional:           ctx.Bool(pointersOptionalFlag),
+		OutputWarnings:             ctx.Bool(outputWarningsFlag),
+		Bundle:                     ctx.Bool(bundleFlag),
+		SplitByTag:                 ctx.Bool(splitByTagFlag),
+		DryRun:                     ctx.Bool(dryRunFlag),
+		FailOnWarning:              ctx.Bool(failOnWarningFlag),
+		InlineEnums:                ctx.Bool(inlineEnumsFlag),
+		NamingStrategy:             ctx.String(namingStrategyFlag),
+		NamespaceByTag:             ctx.Bool(namespaceByTagFlag),
+		KeepDefinitions:            ctx.String(keepDefinitionsFlag),
+		Profile:                    ctx.String(profileFlag),
+		HostEnv:                    ctx.String(hostEnvFlag),
+		BasePathEnv:                ctx.String(basePathEnvFlag),
+		Incremental:                ctx.Bool(incrementalFlag),
+		DurationAsInt:              ctx.Bool(durationAsIntFlag),
+		EmitFieldOrder:             ctx.Bool(emitFieldOrderFlag),
+		EmitOmitEmpty:              ctx.Bool(emitOmitEmptyFlag),
+		EmitUI:                     ctx.Bool(uiFlag),
+		UIBundleURL:                ctx.String(uiBundleURLFlag),
+		BuildTags:                  ctx.String(buildTagsFlag),
+		StrictEnums:                ctx.Bool(strictEnumsFlag),
+		DedupeIdenticalDefinitions: ctx.Bool(dedupeDefinitionsFlag),
+		InlineSingleUseDefinitions: ctx.Bool(inlineSingleUseFlag),
+		MaxDefinitions:             ctx.Int(maxDefinitionsFlag),
+	})
+}
+
+// parseDependencyLevel returns the dependency level to parse, where the bool
+// parseDependency flag stands for level 1.
+func parseDependencyLevel(ctx *cli.Context) int {
 	pdv := ctx.Int(parseDependencyLevelFlag)
 	if pdv == 0 {
 		if ctx.Bool(parseDependencyFlag) {
 			pdv = 1
 		}
 	}
-	return gen.New().Build(&gen.Config{
-		SearchDir:           ctx.String(searchDirFlag),
-		Excludes:            ctx.String(excludeFlag),
-		ParseExtension:      ctx.String(parseExtensionFlag),
-		MainAPIFile:         ctx.String(generalInfoFlag),
-		PropNamingStrategy:  strategy,
-		OutputDir:           ctx.String(outputFlag),
-		OutputTypes:         outputTypes,
-		ParseVendor:         ctx.Bool(parseVendorFlag),
-		ParseDependency:     pdv,
-		MarkdownFilesDir:    ctx.String(markdownFilesFlag),
-		ParseInternal:       ctx.Bool(parseInternalFlag),
-		UseStructNames:      ctx.Bool(useStructNameFlag),
-		RequiredByDefault:   ctx.Bool(requiredByDefaultFlag),
-		CodeExampleFilesDir: ctx.String(codeExampleFilesFlag),
-		ParseDepth:          ctx.Int(parseDepthFlag),
-		InstanceName:        ctx.String(instanceNameFlag),
-		OverridesFile:       ctx.String(overridesFileFlag),
-		ParseGoList:         ctx.Bool(parseGoListFlag),
-		Tags:                ctx.String(tagsFlag),
-		Debugger:            logger,
-		CollectionFormat:    collectionFormat,
-		PackagePrefix:       ctx.String(packagePrefixFlag),
-		State:               ctx.String(stateFlag),
-		ParseFuncBody:       ctx.Bool(parseFuncBodyFlag),
-		ParseGoPackages:     ctx.Bool(parseGoPackagesFlag),
+	return pdv
+}
+
+// lintFlags are the init flags that select and load the files lint checks.
+var lintFlags = selectFlags(initFlags,
+	searchDirFlag, excludeFlag, generalInfoFlag, parseExtensionFlag, parseVendorFlag,
+	parseDependencyFlag, parseDependencyLevelFlag, parseInternalFlag, parseDepthFlag,
+	parseGoListFlag, parseGoPackagesFlag, packagePrefixFlag, markdownFilesFlag, buildTagsFlag,
+)
+
+// selectFlags returns the flags with the given names, in the order of flags.
+func selectFlags(flags []cli.Flag, names ...string) []cli.Flag {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var selected []cli.Flag
+	for _, flag := range flags {
+		if wanted[flag.Names()[0]] {
+			selected = append(selected, flag)
+		}
+	}
+	return selected
+}
+
+func lintAction(ctx *cli.Context) error {
+	warnings, err := gen.New().Lint(&gen.Config{
+		SearchDir:        ctx.String(searchDirFlag),
+		Excludes:         ctx.String(excludeFlag),
+		ParseExtension:   ctx.String(parseExtensionFlag),
+		MainAPIFile:      ctx.String(generalInfoFlag),
+		ParseVendor:      ctx.Bool(parseVendorFlag),
+		ParseDependency:  parseDependencyLevel(ctx),
+		ParseInternal:    ctx.Bool(parseInternalFlag),
+		ParseDepth:       ctx.Int(parseDepthFlag),
+		ParseGoList:      ctx.Bool(parseGoListFlag),
+		ParseGoPackages:  ctx.Bool(parseGoPackagesFlag),
+		PackagePrefix:    ctx.String(packagePrefixFlag),
+		MarkdownFilesDir: ctx.String(markdownFilesFlag),
+		BuildTags:        ctx.String(buildTagsFlag),
+		Debugger:         log.New(io.Discard, "", log.LstdFlags),
 	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		if warning.File == "" {
+			fmt.Fprintln(ctx.App.Writer, warning.Message)
+			continue
+		}
+		fmt.Fprintf(ctx.App.Writer, "%s:%d: %s\n", warning.File, warning.Line, warning.Message)
+	}
+
+	if len(warnings) > 0 {
+		return cli.Exit(fmt.Sprintf("%d problems found", len(warnings)), 1)
+	}
+	return nil
+}
+
+// buildInfo returns the commit and date core-swag was built from. Values not set
+// through ldflags fall back to the VCS stamp of the Go toolchain, e.g. for go install.
+func buildInfo() (string, string) {
+	revision, date := commit, buildDate
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && revision == "":
+				revision = setting.Value
+			case setting.Key == "vcs.time" && date == "":
+				date = setting.Value
+			}
+		}
+	}
+
+	if revision == "" {
+		revision = "unknown"
+	}
+	if date == "" {
+		date = "unknown"
+	}
+	return revision, date
+}
+
+// flagDefault returns the default of flag, or "" when it has none.
+func flagDefault(flag cli.Flag) string {
+	if boolFlag, ok := flag.(*cli.BoolFlag); ok {
+		if boolFlag.Value {
+			return "true"
+		}
+		return ""
+	}
+
+	if docFlag, ok := flag.(cli.DocGenerationFlag); ok && docFlag.TakesValue() {
+		return docFlag.GetValue()
+	}
+	return ""
+}
+
+func aboutAction(ctx *cli.Context) error {
+	revision, date := buildInfo()
+
+	w := ctx.App.Writer
+	fmt.Fprintf(w, "core-swag %s\n", gen.Version)
+	fmt.Fprintf(w, "commit:     %s\n", revision)
+	fmt.Fprintf(w, "build date: %s\n", date)
+	fmt.Fprintf(w, "go version: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "init defaults:")
+	for _, flag := range initFlags {
+		if value := flagDefault(flag); value != "" {
+			fmt.Fprintf(w, "  --%s=%s\n", flag.Names()[0], value)
+		}
+	}
+
+	return nil
 }
 
 func main() {
@@ -266,6 +569,17 @@ func main() {
 			Action:  initAction,
 			Flags:   initFlags,
 		},
+		{
+			Name:   "about",
+			Usage:  "Print build metadata and the init defaults, for bug reports",
+			Action: aboutAction,
+		},
+		{
+			Name:   "lint",
+			Usage:  "Report annotation problems without generating documentation",
+			Action: lintAction,
+			Flags:  lintFlags,
+		},
 		{
 			Name:    "fmt",
 			Aliases: []string{"f"},
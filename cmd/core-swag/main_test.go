@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// runInit parses args with the init flags and returns the resulting context.
+func runInit(t *testing.T, args ...string) *cli.Context {
+	t.Helper()
+
+	var parsed *cli.Context
+	app := &cli.App{
+		Flags: initFlags,
+		Action: func(ctx *cli.Context) error {
+			parsed = ctx
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(append([]string{"core-swag"}, args...)))
+	require.NotNil(t, parsed)
+	return parsed
+}
+
+func TestInitFlags_KebabCaseAliases(t *testing.T) {
+	tests := []struct {
+		alias string
+		flag  string
+	}{
+		{alias: "dry-run", flag: dryRunFlag},
+		{alias: "fail-on-warning", flag: failOnWarningFlag},
+		{alias: "emit-omitempty", flag: emitOmitEmptyFlag},
+		{alias: "emit-field-order", flag: emitFieldOrderFlag},
+		{alias: "strict-enums", flag: strictEnumsFlag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			assert.False(t, runInit(t).Bool(tt.flag))
+			assert.True(t, runInit(t, "--"+tt.alias).Bool(tt.flag))
+			assert.True(t, runInit(t, "--"+tt.flag).Bool(tt.flag))
+		})
+	}
+
+	t.Run("max-definitions", func(t *testing.T) {
+		assert.Equal(t, 40, runInit(t, "--max-definitions", "40").Int(maxDefinitionsFlag))
+	})
+}
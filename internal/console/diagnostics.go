@@ -0,0 +1,115 @@
+package console
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Diagnostic is a warning recorded while generating the spec, such as a type
+// that could not be resolved. File and Line point at the source location that
+// caused it, when known.
+type Diagnostic struct {
+	Category string `json:"category"`
+	Message  string `json:"message"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// String formats the diagnostic for human output, e.g. "warning: file.go:12: message".
+func (d Diagnostic) String() string {
+	if d.File != "" {
+		return fmt.Sprintf("warning: %s:%d: %s", d.File, d.Line, d.Message)
+	}
+	return fmt.Sprintf("warning: %s", d.Message)
+}
+
+// Diagnostics collects the warnings of every stage of generation and is safe for
+// concurrent use. Every recorded diagnostic is also printed through Logger.Debug
+// for human output. A nil *Diagnostics only prints.
+type Diagnostics struct {
+	mu    sync.Mutex
+	items []Diagnostic
+}
+
+// NewDiagnostics creates an empty collector.
+func NewDiagnostics() *Diagnostics {
+	return &Diagnostics{}
+}
+
+// Add records a diagnostic.
+func (d *Diagnostics) Add(diagnostic Diagnostic) {
+	Logger.Debug("%s", diagnostic.String())
+
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	d.items = append(d.items, diagnostic)
+	d.mu.Unlock()
+}
+
+// List returns a copy of the recorded diagnostics, in the order they were added.
+func (d *Diagnostics) List() []Diagnostic {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.items) == 0 {
+		return nil
+	}
+
+	items := make([]Diagnostic, len(d.items))
+	copy(items, d.items)
+	return items
+}
+
+// Len returns the number of recorded diagnostics.
+func (d *Diagnostics) Len() int {
+	if d == nil {
+		return 0
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.items)
+}
+
+// SortFrom sorts the diagnostics recorded after the first from by file and line,
+// so diagnostics added by concurrent workers keep a deterministic order.
+func (d *Diagnostics) SortFrom(from int) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if from >= len(d.items) {
+		return
+	}
+
+	added := d.items[from:]
+	sort.SliceStable(added, func(i, j int) bool {
+		if added[i].File != added[j].File {
+			return added[i].File < added[j].File
+		}
+		return added[i].Line < added[j].Line
+	})
+}
+
+// Reset discards all recorded diagnostics.
+func (d *Diagnostics) Reset() {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	d.items = nil
+	d.mu.Unlock()
+}
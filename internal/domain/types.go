@@ -51,6 +51,16 @@ func (t *TypeSpecDef) Name() string {
 	return ""
 }
 
+// ScopedName the name of the typeSpec, qualified by its enclosing function
+// when it is declared inside one, e.g. GetFoo.response.
+func (t *TypeSpecDef) ScopedName() string {
+	if parentFun, ok := (t.ParentSpec).(*ast.FuncDecl); ok && parentFun != nil {
+		return parentFun.Name.Name + "." + t.Name()
+	}
+
+	return t.Name()
+}
+
 // TypeName the type name of the typeSpec.
 func (t *TypeSpecDef) TypeName() string {
 	if ignoreNameOverride(t.TypeSpec.Name.Name) {
@@ -94,6 +104,11 @@ func (t *TypeSpecDef) SimpleTypeName() string {
 	return fullTypeName(names...)
 }
 
+// IsAlias reports whether the typeSpec declares a type alias, e.g. type AccountID = uuid.UUID.
+func (t *TypeSpecDef) IsAlias() bool {
+	return t.TypeSpec != nil && t.TypeSpec.Assign.IsValid()
+}
+
 // FullPath return the full path of the typeSpec.
 func (t *TypeSpecDef) FullPath() string {
 	return t.PkgPath + "." + t.Name()
@@ -196,6 +211,11 @@ func (pkg *PackageDefinitions) AddTypeSpec(name string, typeSpec *TypeSpecDef) *
 // AddConst add a const variable.
 func (pkg *PackageDefinitions) AddConst(astFile *ast.File, valueSpec *ast.ValueSpec) *PackageDefinitions {
 	for i := 0; i < len(valueSpec.Names) && i < len(valueSpec.Values); i++ {
+		// Blank constants can't be referenced and are no enum values. They still
+		// advance iota, which the parser records on every other name.
+		if valueSpec.Names[i].Name == "_" {
+			continue
+		}
 		variable := &ConstVariable{
 			Name:  valueSpec.Names[i],
 			Type:  valueSpec.Type,
@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"fmt"
 	"go/ast"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -140,3 +143,23 @@ func TransToValidPrimitiveSchema(typeName string) *spec.Schema {
 
 	return &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{typeName}}}
 }
+
+// ReadMarkdownFile reads a markdown file from dir. The .md extension is added
+// to name when missing, so both "users" and "users.md" resolve to users.md.
+func ReadMarkdownFile(dir, name string) ([]byte, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("markdown file directory not set")
+	}
+
+	if !strings.HasSuffix(name, ".md") {
+		name += ".md"
+	}
+
+	fullPath := filepath.Join(dir, name)
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read markdown file %s: %w", fullPath, err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,324 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// bundleHTTPTimeout limits how long fetching a single external document may take.
+const bundleHTTPTimeout = 30 * time.Second
+
+// refBundler inlines external $ref targets into the spec's local definitions.
+type refBundler struct {
+	swagger *spec.Swagger
+	client  *http.Client
+	// docs caches fetched documents by URL without fragment
+	docs map[string]interface{}
+	// names maps an absolute external ref to the definition it was bundled as
+	names map[string]string
+	// specDir is the directory URL relative refs of the spec itself resolve against:
+	// the directory of the referencing file
+	specDir *url.URL
+}
+
+// bundleExternalRefs replaces every external $ref in the spec (file paths,
+// file:// and http(s) URLs) with a local "#/definitions/..." ref, copying the
+// referenced schemas into the spec's definitions so the output is self-contained.
+// Relative refs of an operation resolve against the directory of its source
+// file (x-path), other relative refs against mainDir, the directory of the main
+// API file. Refs inside fetched schemas are bundled recursively, relative to
+// their document.
+func bundleExternalRefs(swagger *spec.Swagger, mainDir string) error {
+	if swagger == nil {
+		return nil
+	}
+
+	mainDirURL, err := directoryURL(mainDir)
+	if err != nil {
+		return err
+	}
+
+	b := &refBundler{
+		swagger: swagger,
+		client:  &http.Client{Timeout: bundleHTTPTimeout},
+		docs:    make(map[string]interface{}),
+		names:   make(map[string]string),
+		specDir: mainDirURL,
+	}
+
+	if swagger.Definitions == nil {
+		swagger.Definitions = make(spec.Definitions)
+	}
+
+	// Snapshot the keys: bundling adds new definitions, which are walked when created
+	names := make([]string, 0, len(swagger.Definitions))
+	for name := range swagger.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema := swagger.Definitions[name]
+		if err := b.bundleSchema(&schema, nil); err != nil {
+			return err
+		}
+		swagger.Definitions[name] = schema
+	}
+
+	for name, param := range swagger.Parameters {
+		if err := b.walk(nil, func(w schema.Walker) { w.WalkParameter(&param) }); err != nil {
+			return err
+		}
+		swagger.Parameters[name] = param
+	}
+
+	for name, response := range swagger.Responses {
+		if err := b.walk(nil, func(w schema.Walker) { w.WalkResponse(&response) }); err != nil {
+			return err
+		}
+		swagger.Responses[name] = response
+	}
+
+	if swagger.Paths == nil {
+		return nil
+	}
+
+	for pathKey, pathItem := range swagger.Paths.Paths {
+		for _, op := range schema.PathItemOperations(pathItem) {
+			b.specDir = mainDirURL
+			if file, ok := op.Extensions.GetString("x-path"); ok && file != "" {
+				if b.specDir, err = directoryURL(filepath.Dir(file)); err != nil {
+					return err
+				}
+			}
+			if err := b.walk(nil, func(w schema.Walker) { w.WalkOperation(op) }); err != nil {
+				return fmt.Errorf("path %s: %w", pathKey, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// bundleSchema rewrites the refs of a schema and all of its sub-schemas.
+// base is the URL of the document the schema came from, nil for the spec itself.
+func (b *refBundler) bundleSchema(s *spec.Schema, base *url.URL) error {
+	return b.walk(base, func(w schema.Walker) { w.WalkSchema(s) })
+}
+
+// walk runs fn with a walker rewriting the refs of the schemas it visits,
+// relative to base, and returns the first error. Schemas after an error are
+// left untouched.
+func (b *refBundler) walk(base *url.URL, fn func(w schema.Walker)) error {
+	var err error
+	fn(schema.Walker{Schema: func(s *spec.Schema) {
+		ref := s.Ref.String()
+		if err != nil || ref == "" || (base == nil && strings.HasPrefix(ref, "#")) {
+			return
+		}
+		var name string
+		if name, err = b.define(ref, base); err == nil {
+			s.Ref = spec.MustCreateRef("#/definitions/" + name)
+		}
+	}})
+	return err
+}
+
+// define makes sure the schema behind ref is present in the definitions and
+// returns its definition name.
+func (b *refBundler) define(ref string, base *url.URL) (string, error) {
+	if base == nil {
+		base = b.specDir
+	}
+	target, err := resolveBundleRef(ref, base)
+	if err != nil {
+		return "", err
+	}
+
+	key := target.String()
+	if name, ok := b.names[key]; ok {
+		return name, nil
+	}
+
+	fragment := target.Fragment
+	docURL := *target
+	docURL.Fragment = ""
+
+	doc, err := b.fetch(&docURL)
+	if err != nil {
+		return "", fmt.Errorf("bundling %s: %w", ref, err)
+	}
+
+	node, err := lookupJSONPointer(doc, fragment)
+	if err != nil {
+		return "", fmt.Errorf("bundling %s: %w", ref, err)
+	}
+
+	raw, err := json.Marshal(node)
+	if err != nil {
+		return "", fmt.Errorf("bundling %s: %w", ref, err)
+	}
+
+	var schema spec.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return "", fmt.Errorf("bundling %s: %w", ref, err)
+	}
+
+	name := b.uniqueName(bundleDefinitionName(&docURL, fragment))
+
+	// Register before recursing so self-referencing schemas terminate
+	b.names[key] = name
+	b.swagger.Definitions[name] = schema
+
+	if err := b.bundleSchema(&schema, &docURL); err != nil {
+		return "", err
+	}
+	b.swagger.Definitions[name] = schema
+
+	return name, nil
+}
+
+// fetch loads and parses a JSON or YAML document, caching it by URL.
+func (b *refBundler) fetch(docURL *url.URL) (interface{}, error) {
+	key := docURL.String()
+	if doc, ok := b.docs[key]; ok {
+		return doc, nil
+	}
+
+	var data []byte
+	switch docURL.Scheme {
+	case "file":
+		content, err := os.ReadFile(docURL.Path)
+		if err != nil {
+			return nil, err
+		}
+		data = content
+	case "http", "https":
+		resp, err := b.client.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %s", resp.Status)
+		}
+
+		content, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		data = content
+	default:
+		return nil, fmt.Errorf("unsupported ref scheme %q", docURL.Scheme)
+	}
+
+	// YAMLToJSON accepts JSON documents as well
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, err
+	}
+
+	b.docs[key] = doc
+
+	return doc, nil
+}
+
+// uniqueName returns name, or name with a numeric suffix when it's already taken.
+func (b *refBundler) uniqueName(name string) string {
+	candidate := name
+	for i := 2; ; i++ {
+		if _, exists := b.swagger.Definitions[candidate]; !exists {
+			return candidate
+		}
+		candidate = name + strconv.Itoa(i)
+	}
+}
+
+// resolveBundleRef resolves ref against base into an absolute URL.
+// Scheme-less refs are file paths relative to base.
+func resolveBundleRef(ref string, base *url.URL) (*url.URL, error) {
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid $ref %q: %w", ref, err)
+	}
+
+	return base.ResolveReference(refURL), nil
+}
+
+// directoryURL returns the file URL of a directory, with a trailing slash so
+// relative refs resolve inside it.
+func directoryURL(dir string) (*url.URL, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &url.URL{Scheme: "file", Path: strings.TrimSuffix(filepath.ToSlash(absDir), "/") + "/"}, nil
+}
+
+// bundleDefinitionName derives a definition name from the last JSON pointer
+// segment, falling back to the document's file name without extension.
+func bundleDefinitionName(docURL *url.URL, fragment string) string {
+	if idx := strings.LastIndex(fragment, "/"); idx >= 0 && idx < len(fragment)-1 {
+		return unescapeJSONPointer(fragment[idx+1:])
+	}
+
+	base := path.Base(docURL.Path)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// lookupJSONPointer returns the node addressed by a JSON pointer fragment (e.g. "/definitions/Pet").
+func lookupJSONPointer(doc interface{}, pointer string) (interface{}, error) {
+	if pointer == "" || pointer == "/" {
+		return doc, nil
+	}
+
+	node := doc
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = unescapeJSONPointer(token)
+
+		switch typed := node.(type) {
+		case map[string]interface{}:
+			next, ok := typed[token]
+			if !ok {
+				return nil, fmt.Errorf("pointer %q not found", pointer)
+			}
+			node = next
+		case []interface{}:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(typed) {
+				return nil, fmt.Errorf("pointer %q not found", pointer)
+			}
+			node = typed[idx]
+		default:
+			return nil, fmt.Errorf("pointer %q not found", pointer)
+		}
+	}
+
+	return node, nil
+}
+
+// unescapeJSONPointer decodes the ~1 and ~0 escapes of a JSON pointer token.
+func unescapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
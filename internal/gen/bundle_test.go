@@ -0,0 +1,161 @@
+package gen
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleExternalRefs(t *testing.T) {
+	dir := t.TempDir()
+
+	petsDoc := `{
+  "definitions": {
+    "Pet": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "category": {"$ref": "#/definitions/Category"},
+        "tag": {"$ref": "tag.yaml"}
+      }
+    },
+    "Category": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "integer"}
+      }
+    }
+  }
+}`
+	tagDoc := "type: object\nproperties:\n  label:\n    type: string\n"
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.json"), []byte(petsDoc), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tag.yaml"), []byte(tagDoc), 0o600))
+
+	petsURL := (&url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(dir, "pets.json"))}).String()
+
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Definitions: spec.Definitions{
+				"Owner": {
+					SchemaProps: spec.SchemaProps{
+						Type: []string{"object"},
+						Properties: spec.SchemaProperties{
+							"pet": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef(petsURL + "#/definitions/Pet")}},
+						},
+					},
+				},
+			},
+			Paths: &spec.Paths{
+				Paths: map[string]spec.PathItem{
+					"/pets": {
+						PathItemProps: spec.PathItemProps{
+							Get: &spec.Operation{
+								OperationProps: spec.OperationProps{
+									Responses: &spec.Responses{
+										ResponsesProps: spec.ResponsesProps{
+											StatusCodeResponses: map[int]spec.Response{
+												200: {ResponseProps: spec.ResponseProps{
+													Schema: spec.RefSchema(petsURL + "#/definitions/Pet"),
+												}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, bundleExternalRefs(swagger, dir))
+
+	t.Run("should inline external schemas into definitions", func(t *testing.T) {
+		require.Contains(t, swagger.Definitions, "Pet")
+		require.Contains(t, swagger.Definitions, "Category")
+		require.Contains(t, swagger.Definitions, "tag")
+
+		petProp := swagger.Definitions["Owner"].Properties["pet"]
+		assert.Equal(t, "#/definitions/Pet", petProp.Ref.String())
+		assert.Contains(t, swagger.Definitions["tag"].Properties, "label")
+	})
+
+	t.Run("should rewrite refs inside bundled schemas", func(t *testing.T) {
+		pet := swagger.Definitions["Pet"]
+		category := pet.Properties["category"]
+		tag := pet.Properties["tag"]
+		assert.Equal(t, "#/definitions/Category", category.Ref.String())
+		assert.Equal(t, "#/definitions/tag", tag.Ref.String())
+	})
+
+	t.Run("should rewrite refs in operations", func(t *testing.T) {
+		response := swagger.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200]
+		assert.Equal(t, "#/definitions/Pet", response.Schema.Ref.String())
+	})
+
+	t.Run("should error on missing pointer", func(t *testing.T) {
+		broken := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Definitions: spec.Definitions{
+					"Owner": *spec.RefSchema(petsURL + "#/definitions/Missing"),
+				},
+			},
+		}
+		assert.Error(t, bundleExternalRefs(broken, dir))
+	})
+
+	t.Run("should resolve relative refs against the referencing file", func(t *testing.T) {
+		handlers := filepath.Join(dir, "handlers")
+		require.NoError(t, os.MkdirAll(handlers, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(handlers, "tag.yaml"), []byte("type: object\nproperties:\n  handler:\n    type: string\n"), 0o600))
+
+		// The working directory has neither file
+		t.Chdir(t.TempDir())
+
+		relative := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Definitions: spec.Definitions{
+					"Owner": *spec.RefSchema("tag.yaml"),
+				},
+				Paths: &spec.Paths{
+					Paths: map[string]spec.PathItem{
+						"/tags": {
+							PathItemProps: spec.PathItemProps{
+								Get: &spec.Operation{
+									VendorExtensible: spec.VendorExtensible{
+										Extensions: spec.Extensions{"x-path": filepath.Join(handlers, "tags.go")},
+									},
+									OperationProps: spec.OperationProps{
+										Responses: &spec.Responses{
+											ResponsesProps: spec.ResponsesProps{
+												StatusCodeResponses: map[int]spec.Response{
+													200: {ResponseProps: spec.ResponseProps{Schema: spec.RefSchema("tag.yaml")}},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		require.NoError(t, bundleExternalRefs(relative, dir))
+
+		owner := relative.Definitions["Owner"]
+		assert.Equal(t, "#/definitions/tag", owner.Ref.String())
+		assert.Contains(t, relative.Definitions["tag"].Properties, "label")
+
+		response := relative.Paths.Paths["/tags"].Get.Responses.StatusCodeResponses[200]
+		assert.Equal(t, "#/definitions/tag2", response.Schema.Ref.String())
+		assert.Contains(t, relative.Definitions["tag2"].Properties, "handler")
+	})
+}
@@ -0,0 +1,19 @@
+package gen
+
+import (
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/pkg/swagenv"
+)
+
+// applyEnvPlaceholders replaces the host and basePath with placeholders for
+// config.HostEnv and config.BasePathEnv, so one generated spec can be served
+// from several environments with swagenv.Expand. Literal values are kept when
+// the options are empty.
+func applyEnvPlaceholders(config *Config, swagger *spec.Swagger) {
+	if config.HostEnv != "" {
+		swagger.Host = swagenv.Placeholder(config.HostEnv)
+	}
+	if config.BasePathEnv != "" {
+		swagger.BasePath = swagenv.Placeholder(config.BasePathEnv)
+	}
+}
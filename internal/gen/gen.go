@@ -2,6 +2,7 @@ package gen
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,12 +10,15 @@ import (
 	"math"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-openapi/spec"
 	"github.com/griffnb/core-swag/internal/console"
 	"github.com/griffnb/core-swag/internal/loader"
 	"github.com/griffnb/core-swag/internal/orchestrator"
+	"github.com/griffnb/core-swag/internal/schema"
+	"github.com/griffnb/core-swag/internal/typeregistry"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/yaml"
 )
@@ -27,18 +31,28 @@ const Version = "v1.16.7"
 // DefaultInstanceName is the default name for a swagger instance.
 const DefaultInstanceName = "swagger"
 
+// DefaultOutputFileName is the default base name of the generated spec files.
+const DefaultOutputFileName = "swagger"
+
 // DefaultOverridesFile is the location swagger will look for type overrides.
 const DefaultOverridesFile = ".swaggo"
 
-type genTypeWriter func(*Config, *spec.Swagger) error
+// outputFormat serializes the spec for one output type. Build writes it to the output
+// file name plus extension in the output directory, WriteTo to any writer.
+type outputFormat struct {
+	extension string
+	encode    func(*spec.Swagger) ([]byte, error)
+}
 
 // Gen presents a generate tool for swag.
 type Gen struct {
 	json          func(data interface{}) ([]byte, error)
 	jsonIndent    func(data interface{}) ([]byte, error)
 	jsonToYAML    func(data []byte) ([]byte, error)
-	outputTypeMap map[string]genTypeWriter
+	outputTypeMap map[string]outputFormat
 	debug         Debugger
+	definitions   map[string]spec.Schema
+	state         *orchestrator.BuildState
 }
 
 // Debugger is the interface that wraps the basic Printf method.
@@ -57,15 +71,32 @@ func New() *Gen {
 		debug:      log.New(os.Stdout, "", log.LstdFlags),
 	}
 
-	gen.outputTypeMap = map[string]genTypeWriter{
-		"json": gen.writeJSONSwagger,
-		"yaml": gen.writeYAMLSwagger,
-		"yml":  gen.writeYAMLSwagger,
+	gen.outputTypeMap = map[string]outputFormat{
+		"json":       {extension: ".json", encode: gen.encodeJSON},
+		"yaml":       {extension: ".yaml", encode: gen.encodeYAML},
+		"yml":        {extension: ".yaml", encode: gen.encodeYAML},
+		"jsonschema": {extension: ".schema.json", encode: gen.encodeJSONSchema},
 	}
 
 	return &gen
 }
 
+// AddDefinition registers an extra schema definition to merge into the generated spec,
+// e.g. for a type defined outside the parsed tree. Registering the same name twice is an
+// error; a clash with a generated definition fails in strict mode and warns otherwise.
+func (g *Gen) AddDefinition(name string, schema spec.Schema) error {
+	if err := orchestrator.ValidateDefinition(g.definitions, name); err != nil {
+		return err
+	}
+
+	if g.definitions == nil {
+		g.definitions = make(map[string]spec.Schema)
+	}
+	g.definitions[name] = schema
+
+	return nil
+}
+
 // Config presents Gen configurations.
 type Config struct {
 	Debugger Debugger
@@ -73,7 +104,7 @@ type Config struct {
 	// SearchDir the swag would parse,comma separated if multiple
 	SearchDir string
 
-	// excludes dirs and files in SearchDir,comma separated
+	// excludes dirs and files in SearchDir, comma separated; entries prefixed with glob: are patterns such as glob:*_gen.go
 	Excludes string
 
 	// outputs only specific extension
@@ -82,7 +113,8 @@ type Config struct {
 	// OutputDir represents the output directory for all the generated files
 	OutputDir string
 
-	// OutputTypes define types of files which should be generated
+	// OutputTypes define types of files which should be generated: json, yaml (or yml) and
+	// jsonschema, a draft-07 JSON Schema bundle of the definitions
 	OutputTypes []string
 
 	// MainAPIFile the Go file path in which 'swagger general API Info' is written
@@ -101,9 +133,16 @@ type Config struct {
 	// same project. The default value is "swagger".
 	InstanceName string
 
+	// OutputFileName the base name of the generated spec files, e.g. openapi for openapi.json.
+	// The default value is "swagger".
+	OutputFileName string
+
 	// ParseDepth dependency parse depth
 	ParseDepth int
 
+	// MaxTypeDepth how deeply nested and embedded types are resolved before failing, zero uses the default of 100
+	MaxTypeDepth int
+
 	// ParseVendor whether swag should be parse vendor folder
 	ParseVendor bool
 
@@ -113,13 +152,23 @@ type Config struct {
 	// UseStructNames stick to the struct name instead of those ugly full-path names
 	UseStructNames bool
 
+	// NamingStrategy how definitions are named: full, package or short. Empty means full,
+	// or package when UseStructNames is set
+	NamingStrategy string
+
+	// NamespaceByTag whether swag should prefix definitions used by operations of a single tag with that tag
+	NamespaceByTag bool
+
+	// KeepDefinitions definitions that may be unreferenced without being reported as orphans, comma separated
+	KeepDefinitions string
+
 	// ParseInternal whether swag should parse internal packages
 	ParseInternal bool
 
 	// Strict whether swag should error or warn when it detects cases which are most likely user errors
 	Strict bool
 
-	// RequiredByDefault set validation required for all fields by default
+	// RequiredByDefault also requires omitempty fields with a required validate or binding rule, @AllRequired structs require every field
 	RequiredByDefault bool
 
 	// OverridesFile defines global type overrides.
@@ -145,103 +194,321 @@ type Config struct {
 
 	// ParseGoPackages whether swag use golang.org/x/tools/go/packages to parse source.
 	ParseGoPackages bool
+
+	// BuildTags build tags files are loaded with, so files behind build constraints are parsed, comma separated
+	BuildTags string
+
+	// EmitNullable whether swag should mark any/interface{} and pointer-to-interface fields as nullable; pointer-to-slice and pointer slice element fields always are
+	EmitNullable bool
+
+	// PointersOptional whether swag should never mark pointer fields as required, since nil is a valid value
+	PointersOptional bool
+
+	// InlineEnums whether swag should inline enum values, varnames and descriptions into properties instead of referencing enum definitions
+	InlineEnums bool
+
+	// DurationAsInt whether swag should document time.Duration as integer nanoseconds instead of a string like "5s"
+	DurationAsInt bool
+
+	// StrictEnums whether swag should fail when a field declared as an enum type has no enum values
+	StrictEnums bool
+
+	// DedupeIdenticalDefinitions whether swag should merge definitions of the same type from different packages whose schemas are equal
+	DedupeIdenticalDefinitions bool
+
+	// InlineSingleUseDefinitions whether swag should inline object definitions referenced exactly once at their use site
+	InlineSingleUseDefinitions bool
+
+	// EmitFieldOrder whether swag should add an x-order extension with the struct field declaration index to every property
+	EmitFieldOrder bool
+
+	// EmitOmitEmpty whether swag should add an x-omitempty extension to properties whose json tag has omitempty
+	EmitOmitEmpty bool
+
+	// OutputWarnings whether swag should write collected warnings to swagger.warnings.json
+	OutputWarnings bool
+
+	// Bundle whether swag should inline external $ref targets into local definitions
+	Bundle bool
+
+	// MaxDefinitions fails the build when the spec has more definitions, 0 is unlimited
+	MaxDefinitions int
+
+	// SplitByTag whether swag should also write a spec per operation tag to <OutputDir>/<tag>, with the
+	// operations carrying the tag and their reachable definitions, and the general info to <OutputDir>/_shared
+	SplitByTag bool
+
+	// DryRun whether swag should only report a summary of the generated spec without writing any files
+	DryRun bool
+
+	// FailOnWarning whether swag should fail instead of writing the spec when warnings were collected
+	FailOnWarning bool
+
+	// HostEnv when set, writes the host as a ${HostEnv} placeholder to be substituted at runtime with swagenv.Expand
+	HostEnv string
+
+	// BasePathEnv when set, writes the basePath as a ${BasePathEnv} placeholder to be substituted at runtime with swagenv.Expand
+	BasePathEnv string
+
+	// DefinitionHook when set, is called with every generated definition before naming, e.g. to add common properties
+	DefinitionHook func(name string, schema *spec.Schema)
+
+	// Incremental reuses the schema definitions of each package of the previous build whose type
+	// declarations, dependencies and referenced types are unchanged, keeping its state in
+	// swagger.state.json in the output directory
+	Incremental bool
+
+	// Profile writes a pprof profile of the build to the output directory: cpu or mem. Ignored in dry runs
+	Profile string
+
+	// EmitUI whether swag should write an index.html that renders the generated spec with Swagger UI
+	EmitUI bool
+
+	// UIBundleURL the swagger-ui-dist base URL the index.html loads Swagger UI from, DefaultUIBundleURL when empty
+	UIBundleURL string
 }
 
 // Build builds swagger json file  for given searchDir and mainAPIFile. Returns json.
-func (g *Gen) Build(config *Config) error {
+func (g *Gen) Build(config *Config) (err error) {
+	if !config.DryRun {
+		stopProfile, profileErr := startProfile(config)
+		if profileErr != nil {
+			return profileErr
+		}
+		defer func() {
+			if stopErr := stopProfile(); err == nil {
+				err = stopErr
+			}
+		}()
+	}
+
+	swagger, warnings, err := g.BuildSpec(config)
+	if err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		return g.reportDryRun(swagger, warnings, config.Strict || config.FailOnWarning)
+	}
+
+	if config.FailOnWarning && len(warnings) > 0 {
+		return fmt.Errorf("found %d warning(s)", len(warnings))
+	}
+
+	// nolint:gosec // This is not executing user-provided code, just writing files
+	if err := os.MkdirAll(config.OutputDir, os.ModePerm); err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, outputType := range config.OutputTypes {
+		outputType = strings.ToLower(strings.TrimSpace(outputType))
+		if format, ok := g.outputTypeMap[outputType]; ok {
+			if err := g.writeOutput(config, swagger, format); err != nil {
+				return err
+			}
+		} else {
+			log.Printf("output type '%s' not supported", outputType)
+		}
+	}
+
+	if config.SplitByTag {
+		if err := g.writeSplitSpecs(config, swagger); err != nil {
+			return err
+		}
+	}
+
+	if config.EmitUI {
+		if err := g.writeUI(config, swagger); err != nil {
+			return err
+		}
+	}
+
+	if config.OutputWarnings {
+		if err := g.writeWarnings(config, warnings); err != nil {
+			return err
+		}
+	}
+
+	if config.Incremental {
+		if err := g.writeBuildState(config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BuildSpec runs the full generation pipeline for given searchDir and mainAPIFile
+// without writing any files. Returns the spec and the warnings collected while building it.
+func (g *Gen) BuildSpec(config *Config) (*spec.Swagger, []orchestrator.Warning, error) {
 	if config.Debugger != nil {
 		g.debug = config.Debugger
 	}
 	if config.InstanceName == "" {
 		config.InstanceName = DefaultInstanceName
 	}
+	if config.OutputFileName == "" {
+		config.OutputFileName = DefaultOutputFileName
+	}
 
 	searchDirs := strings.Split(config.SearchDir, ",")
 	if !config.ParseGoPackages { // packages.Load support pattern like ./...
 		for _, searchDir := range searchDirs {
 			if _, err := os.Stat(searchDir); os.IsNotExist(err) {
-				return fmt.Errorf("dir: %s does not exist", searchDir)
+				return nil, nil, fmt.Errorf("dir: %s does not exist", searchDir)
 			}
 		}
 	}
 
 	var overrides map[string]string
+	var primitives map[string]typeregistry.TypeEntry
+	var stateInputs []string
 
 	if config.OverridesFile != "" {
-		overridesFile, err := open(config.OverridesFile)
+		overridesPath := config.OverridesFile
+
+		// Auto-detect the YAML format when only the default YAML file exists
+		if overridesPath == DefaultOverridesFile {
+			if _, err := os.Stat(DefaultOverridesFile); os.IsNotExist(err) {
+				if _, err := os.Stat(DefaultYAMLOverridesFile); err == nil {
+					overridesPath = DefaultYAMLOverridesFile
+				}
+			}
+		}
+
+		overridesFile, err := open(overridesPath)
 		if err != nil {
 			// Don't bother reporting if the default file is missing; assume there are no overrides
 			if !(config.OverridesFile == DefaultOverridesFile && os.IsNotExist(err)) {
-				return errors.WithMessagef(err, "could not open overrides file: %s", config.OverridesFile)
+				return nil, nil, errors.WithMessagef(err, "could not open overrides file: %s", overridesPath)
 			}
 		} else {
-			console.Logger.Debug("Using overrides from %s", config.OverridesFile)
+			console.Logger.Debug("Using overrides from %s", overridesPath)
+			stateInputs = append(stateInputs, overridesPath)
 
-			overrides, err = parseOverrides(overridesFile)
+			overrides, primitives, err = parseOverridesFile(overridesPath, overridesFile)
 			if err != nil {
-				return err
+				return nil, nil, err
 			}
 		}
 	}
 
+	var previousState *orchestrator.BuildState
+	if config.Incremental {
+		previousState = g.readBuildState(config)
+	}
+
 	console.Logger.Debug("Generate swagger docs....")
 
 	// Create orchestrator with configuration
 	orc := orchestrator.New(&orchestrator.Config{
-		ParseVendor:             config.ParseVendor,
-		ParseInternal:           config.ParseInternal,
-		ParseDependency:         loader.ParseFlag(config.ParseDependency),
-		PropNamingStrategy:      config.PropNamingStrategy,
-		RequiredByDefault:       config.RequiredByDefault,
-		Strict:                  config.Strict,
-		MarkdownFileDir:         config.MarkdownFilesDir,
-		CodeExampleFilesDir:     config.CodeExampleFilesDir,
-		CollectionFormatInQuery: config.CollectionFormat,
-		Excludes:                parseExcludes(config.Excludes),
-		PackagePrefix:           parsePackagePrefix(config.PackagePrefix),
-		ParseExtension:          config.ParseExtension,
-		ParseGoList:             config.ParseGoList,
-		ParseGoPackages:         config.ParseGoPackages,
-		HostState:               config.State,
-		ParseFuncBody:           config.ParseFuncBody,
-		UseStructName:           config.UseStructNames,
-		Overrides:               overrides,
-		Tags:                    parseTags(config.Tags),
-		Debug:                   g.debug,
+		ParseVendor:                config.ParseVendor,
+		ParseInternal:              config.ParseInternal,
+		ParseDependency:            loader.ParseFlag(config.ParseDependency),
+		PropNamingStrategy:         config.PropNamingStrategy,
+		RequiredByDefault:          config.RequiredByDefault,
+		Strict:                     config.Strict,
+		MarkdownFileDir:            config.MarkdownFilesDir,
+		CodeExampleFilesDir:        config.CodeExampleFilesDir,
+		CollectionFormatInQuery:    config.CollectionFormat,
+		Excludes:                   parseExcludes(config.Excludes),
+		PackagePrefix:              parsePackagePrefix(config.PackagePrefix),
+		ParseExtension:             config.ParseExtension,
+		ParseGoList:                config.ParseGoList,
+		ParseGoPackages:            config.ParseGoPackages,
+		HostState:                  config.State,
+		ParseFuncBody:              config.ParseFuncBody,
+		UseStructName:              config.UseStructNames,
+		Overrides:                  overrides,
+		PrimitiveTypes:             primitives,
+		Tags:                       parseTags(config.Tags),
+		EmitNullable:               config.EmitNullable,
+		PointersOptional:           config.PointersOptional,
+		InlineEnums:                config.InlineEnums,
+		DurationAsInt:              config.DurationAsInt,
+		EmitFieldOrder:             config.EmitFieldOrder,
+		EmitOmitEmpty:              config.EmitOmitEmpty,
+		BuildTags:                  parseBuildTags(config.BuildTags),
+		StrictEnums:                config.StrictEnums,
+		MaxTypeDepth:               config.MaxTypeDepth,
+		NamingStrategy:             orchestrator.NamingStrategy(config.NamingStrategy),
+		NamespaceByTag:             config.NamespaceByTag,
+		DedupeIdenticalDefinitions: config.DedupeIdenticalDefinitions,
+		InlineSingleUseDefinitions: config.InlineSingleUseDefinitions,
+		KeepDefinitions:            parseTags(config.KeepDefinitions),
+		Debug:                      g.debug,
+		Incremental:                config.Incremental,
+		PreviousState:              previousState,
+		StateInputs:                stateInputs,
+		DefinitionHook:             config.DefinitionHook,
 	})
 
+	for name, schema := range g.definitions {
+		if err := orc.AddDefinition(name, schema); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Parse using orchestrator
-	swagger, err := orc.Parse(searchDirs, config.MainAPIFile, config.ParseDepth)
+	swagger, err := orc.ParseContext(context.Background(), searchDirs, config.MainAPIFile, config.ParseDepth)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	g.state = orc.State()
 
 	// Sanitize swagger spec to remove infinity/NaN values before any output
 	// These values are not valid in JSON and will cause marshaling errors
 	g.debug.Printf("Sanitizing swagger spec to remove invalid numeric values...")
 	sanitizeSwaggerSpec(swagger)
 
-	// nolint:gosec // This is not executing user-provided code, just writing files
-	if err := os.MkdirAll(config.OutputDir, os.ModePerm); err != nil {
-		return errors.WithStack(err)
+	applyEnvPlaceholders(config, swagger)
+
+	if config.Bundle {
+		g.debug.Printf("Bundling external $refs into definitions...")
+		// A bare main file name is relative to the first search dir, like in the orchestrator
+		mainDir := filepath.Dir(config.MainAPIFile)
+		if !filepath.IsAbs(config.MainAPIFile) && mainDir == "." {
+			mainDir = searchDirs[0]
+		}
+		if err := bundleExternalRefs(swagger, mainDir); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	for _, outputType := range config.OutputTypes {
-		outputType = strings.ToLower(strings.TrimSpace(outputType))
-		if typeWriter, ok := g.outputTypeMap[outputType]; ok {
-			if err := typeWriter(config, swagger); err != nil {
-				return err
-			}
-		} else {
-			log.Printf("output type '%s' not supported", outputType)
+	if err := checkMaxDefinitions(swagger, config.MaxDefinitions); err != nil {
+		return nil, nil, err
+	}
+
+	return swagger, orc.Warnings(), nil
+}
+
+// reportDryRun logs a summary of the generated spec instead of writing it.
+// With failOnWarning, returns an error when warnings were collected so dry runs can gate CI.
+func (g *Gen) reportDryRun(swagger *spec.Swagger, warnings []orchestrator.Warning, failOnWarning bool) error {
+	pathCount := 0
+	if swagger.Paths != nil {
+		pathCount = len(swagger.Paths.Paths)
+	}
+
+	g.debug.Printf("Dry run: %d definitions, %d paths, %d warnings", len(swagger.Definitions), pathCount, len(warnings))
+
+	for _, warning := range warnings {
+		if warning.File != "" {
+			g.debug.Printf("Dry run warning [%s] %s:%d: %s", warning.Category, warning.File, warning.Line, warning.Message)
+			continue
 		}
+		g.debug.Printf("Dry run warning [%s] %s", warning.Category, warning.Message)
+	}
+
+	if failOnWarning && len(warnings) > 0 {
+		return fmt.Errorf("dry run found %d warning(s)", len(warnings))
 	}
 
 	return nil
 }
 
-func (g *Gen) writeJSONSwagger(config *Config, swagger *spec.Swagger) error {
-	filename := "swagger.json"
+func (g *Gen) writeWarnings(config *Config, warnings []orchestrator.Warning) error {
+	filename := "swagger.warnings.json"
 
 	if config.State != "" {
 		filename = config.State + "_" + filename
@@ -251,25 +518,55 @@ func (g *Gen) writeJSONSwagger(config *Config, swagger *spec.Swagger) error {
 		filename = config.InstanceName + "_" + filename
 	}
 
-	jsonFileName := path.Join(config.OutputDir, filename)
+	warningsFileName := path.Join(config.OutputDir, filename)
+
+	if warnings == nil {
+		warnings = []orchestrator.Warning{}
+	}
 
-	b, err := g.jsonIndent(swagger)
+	b, err := g.jsonIndent(warnings)
 	if err != nil {
 		return err
 	}
 
-	err = g.writeFile(b, jsonFileName)
+	err = g.writeFile(b, warningsFileName)
 	if err != nil {
 		return err
 	}
 
-	console.Logger.Debug("create swagger.json at %+v", jsonFileName)
+	console.Logger.Debug("create swagger.warnings.json at %+v", warningsFileName)
 
 	return nil
 }
 
-func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
-	filename := "swagger.yaml"
+// WriteTo builds the spec like BuildSpec and writes it to w in a single output
+// type (json, yaml, yml or jsonschema) without touching disk, e.g. to serve the
+// docs from an HTTP handler.
+func (g *Gen) WriteTo(config *Config, w io.Writer, outputType string) error {
+	format, ok := g.outputTypeMap[strings.ToLower(strings.TrimSpace(outputType))]
+	if !ok {
+		return fmt.Errorf("output type '%s' not supported", outputType)
+	}
+
+	swagger, _, err := g.BuildSpec(config)
+	if err != nil {
+		return err
+	}
+
+	b, err := format.encode(swagger)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+
+	return err
+}
+
+// writeOutput writes the spec in the given format to the output directory,
+// prefixing the file name with the state and instance name when set.
+func (g *Gen) writeOutput(config *Config, swagger *spec.Swagger, format outputFormat) error {
+	filename := config.OutputFileName + format.extension
 
 	if config.State != "" {
 		filename = config.State + "_" + filename
@@ -279,26 +576,41 @@ func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
 		filename = config.InstanceName + "_" + filename
 	}
 
-	yamlFileName := path.Join(config.OutputDir, filename)
+	outputFileName := path.Join(config.OutputDir, filename)
 
-	b, err := g.json(swagger)
+	b, err := format.encode(swagger)
 	if err != nil {
 		return err
 	}
 
-	y, err := g.jsonToYAML(b)
+	err = g.writeFile(b, outputFileName)
 	if err != nil {
-		return fmt.Errorf("cannot covert json to yaml error: %s", err)
+		return err
 	}
 
-	err = g.writeFile(y, yamlFileName)
+	console.Logger.Debug("create %s at %+v", filename, outputFileName)
+
+	return nil
+}
+
+func (g *Gen) encodeJSON(swagger *spec.Swagger) ([]byte, error) {
+	markNullableSchemas(swagger, swagger20)
+	return g.jsonIndent(swagger)
+}
+
+func (g *Gen) encodeYAML(swagger *spec.Swagger) ([]byte, error) {
+	markNullableSchemas(swagger, swagger20)
+	b, err := g.json(swagger)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	console.Logger.Debug("create swagger.yaml at %+v", yamlFileName)
+	y, err := g.jsonToYAML(b)
+	if err != nil {
+		return nil, fmt.Errorf("cannot covert json to yaml error: %s", err)
+	}
 
-	return nil
+	return y, nil
 }
 
 func (g *Gen) writeFile(b []byte, file string) error {
@@ -391,6 +703,14 @@ func parsePackagePrefix(packagePrefix string) []string {
 	return result
 }
 
+// parseBuildTags converts a comma or space separated build tags string to a slice,
+// as accepted by go build -tags.
+func parseBuildTags(buildTags string) []string {
+	return strings.FieldsFunc(buildTags, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
 // parseTags converts comma-separated tags string to map.
 func parseTags(tags string) map[string]struct{} {
 	result := make(map[string]struct{})
@@ -416,13 +736,9 @@ func sanitizeSwaggerSpec(swagger *spec.Swagger) {
 
 	// Walk through all paths and operations
 	for pathKey, pathItem := range swagger.Paths.Paths {
-		sanitizeOperation(pathKey, pathItem.Get)
-		sanitizeOperation(pathKey, pathItem.Put)
-		sanitizeOperation(pathKey, pathItem.Post)
-		sanitizeOperation(pathKey, pathItem.Delete)
-		sanitizeOperation(pathKey, pathItem.Options)
-		sanitizeOperation(pathKey, pathItem.Head)
-		sanitizeOperation(pathKey, pathItem.Patch)
+		for _, op := range schema.PathItemOperations(pathItem) {
+			sanitizeOperation(pathKey, op)
+		}
 	}
 }
 
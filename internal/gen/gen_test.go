@@ -2,6 +2,7 @@ package gen
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -11,6 +12,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/orchestrator"
+	"github.com/griffnb/core-swag/internal/schemautil"
+	"github.com/griffnb/core-swag/pkg/swagenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -112,6 +117,34 @@ func TestGen_BuildInstanceName(t *testing.T) {
 	}
 }
 
+func TestGen_BuildOutputFileName(t *testing.T) {
+	config := &Config{
+		SearchDir:      searchDir,
+		MainAPIFile:    "./main.go",
+		OutputDir:      t.TempDir(),
+		OutputTypes:    []string{"json", "yaml", "jsonschema"},
+		OutputFileName: "openapi",
+	}
+	require.NoError(t, New().Build(config))
+
+	for _, name := range []string{"openapi.json", "openapi.yaml", "openapi.schema.json"} {
+		_, err := os.Stat(filepath.Join(config.OutputDir, name))
+		require.NoError(t, err)
+	}
+	_, err := os.Stat(filepath.Join(config.OutputDir, "swagger.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Composes with the state and instance name prefixes
+	config.OutputDir = t.TempDir()
+	config.OutputTypes = []string{"json"}
+	config.State = "admin"
+	config.InstanceName = "Custom"
+	require.NoError(t, New().Build(config))
+
+	_, err = os.Stat(filepath.Join(config.OutputDir, "Custom_admin_openapi.json"))
+	require.NoError(t, err)
+}
+
 func TestGen_BuildSnakeCase(t *testing.T) {
 	config := &Config{
 		SearchDir:          "../../testing/testdata/simple2",
@@ -309,6 +342,290 @@ func TestGen_configWithOutputDir(t *testing.T) {
 	}
 }
 
+func TestGen_OutputWarnings(t *testing.T) {
+	config := &Config{
+		SearchDir:      "../../testing/testdata/duplicated",
+		MainAPIFile:    "./main.go",
+		OutputDir:      t.TempDir(),
+		OutputTypes:    []string{"json"},
+		OutputWarnings: true,
+	}
+
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.warnings.json"))
+	require.NoError(t, err)
+
+	var warnings []orchestrator.Warning
+	require.NoError(t, json.Unmarshal(b, &warnings))
+	require.Len(t, warnings, 1)
+	assert.Equal(t, orchestrator.WarningDuplicateOperationID, warnings[0].Category)
+	assert.Contains(t, warnings[0].Message, `"get-foo"`)
+	assert.True(t, strings.HasSuffix(warnings[0].File, filepath.Join("api", "api.go")))
+	assert.Positive(t, warnings[0].Line)
+}
+
+func TestGen_DryRun(t *testing.T) {
+	t.Run("should report without writing files", func(t *testing.T) {
+		var buf bytes.Buffer
+		config := &Config{
+			SearchDir:   searchDir,
+			MainAPIFile: "./main.go",
+			OutputDir:   filepath.Join(t.TempDir(), "docs"),
+			OutputTypes: outputTypes,
+			DryRun:      true,
+			Debugger:    log.New(&buf, "", 0),
+		}
+
+		require.NoError(t, New().Build(config))
+		assert.Contains(t, buf.String(), "Dry run:")
+
+		_, err := os.Stat(config.OutputDir)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("should report warnings without failing by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		config := &Config{
+			SearchDir:   "../../testing/testdata/duplicated",
+			MainAPIFile: "./main.go",
+			OutputDir:   filepath.Join(t.TempDir(), "docs"),
+			OutputTypes: []string{"json"},
+			DryRun:      true,
+			Debugger:    log.New(&buf, "", 0),
+		}
+
+		require.NoError(t, New().Build(config))
+		assert.Contains(t, buf.String(), orchestrator.WarningDuplicateOperationID)
+	})
+
+	t.Run("should fail when warnings are found with FailOnWarning", func(t *testing.T) {
+		var buf bytes.Buffer
+		config := &Config{
+			SearchDir:     "../../testing/testdata/duplicated",
+			MainAPIFile:   "./main.go",
+			OutputDir:     filepath.Join(t.TempDir(), "docs"),
+			OutputTypes:   []string{"json"},
+			DryRun:        true,
+			FailOnWarning: true,
+			Debugger:      log.New(&buf, "", 0),
+		}
+
+		assert.Error(t, New().Build(config))
+		assert.Contains(t, buf.String(), orchestrator.WarningDuplicateOperationID)
+
+		_, err := os.Stat(config.OutputDir)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestGen_Profile(t *testing.T) {
+	for _, profile := range []string{ProfileCPU, ProfileMem} {
+		t.Run("should write "+profile+" profile", func(t *testing.T) {
+			config := &Config{
+				SearchDir:   "../../testing/testdata/pet",
+				MainAPIFile: "./main.go",
+				OutputDir:   filepath.Join(t.TempDir(), "docs"),
+				OutputTypes: []string{"json"},
+				Profile:     profile,
+			}
+
+			require.NoError(t, New().Build(config))
+
+			info, err := os.Stat(filepath.Join(config.OutputDir, "swagger."+profile+".pprof"))
+			require.NoError(t, err)
+			assert.NotZero(t, info.Size())
+		})
+	}
+
+	t.Run("should reject unknown profile", func(t *testing.T) {
+		config := &Config{
+			SearchDir:   "../../testing/testdata/pet",
+			MainAPIFile: "./main.go",
+			OutputDir:   filepath.Join(t.TempDir(), "docs"),
+			OutputTypes: []string{"json"},
+			Profile:     "block",
+		}
+
+		assert.Error(t, New().Build(config))
+	})
+}
+
+func TestGen_UI(t *testing.T) {
+	t.Run("should write index.html loading the json spec", func(t *testing.T) {
+		config := &Config{
+			SearchDir:   "../../testing/testdata/pet",
+			MainAPIFile: "./main.go",
+			OutputDir:   filepath.Join(t.TempDir(), "docs"),
+			OutputTypes: []string{"json", "yaml"},
+			EmitUI:      true,
+		}
+
+		require.NoError(t, New().Build(config))
+
+		b, err := os.ReadFile(filepath.Join(config.OutputDir, "index.html"))
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `url: "./swagger.json"`)
+		assert.Contains(t, string(b), DefaultUIBundleURL+"/swagger-ui-bundle.js")
+	})
+
+	t.Run("should use the bundle url, state and yaml output", func(t *testing.T) {
+		config := &Config{
+			SearchDir:   "../../testing/testdata/pet",
+			MainAPIFile: "./main.go",
+			OutputDir:   filepath.Join(t.TempDir(), "docs"),
+			OutputTypes: []string{"yaml"},
+			State:       "admin",
+			EmitUI:      true,
+			UIBundleURL: "https://cdn.example.com/swagger-ui/",
+		}
+
+		require.NoError(t, New().Build(config))
+
+		b, err := os.ReadFile(filepath.Join(config.OutputDir, "admin_index.html"))
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `url: "./admin_swagger.yaml"`)
+		assert.Contains(t, string(b), `href="https://cdn.example.com/swagger-ui/swagger-ui.css"`)
+	})
+
+	t.Run("should require a spec output type", func(t *testing.T) {
+		config := &Config{
+			SearchDir:   "../../testing/testdata/pet",
+			MainAPIFile: "./main.go",
+			OutputDir:   filepath.Join(t.TempDir(), "docs"),
+			EmitUI:      true,
+		}
+
+		assert.Error(t, New().Build(config))
+	})
+}
+
+func TestGen_Incremental(t *testing.T) {
+	config := &Config{
+		SearchDir:   "../../testing/testdata/pet",
+		MainAPIFile: "./main.go",
+		OutputDir:   filepath.Join(t.TempDir(), "docs"),
+		OutputTypes: []string{"json"},
+		Incremental: true,
+	}
+
+	require.NoError(t, New().Build(config))
+	first, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+
+	state, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.state.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(state), `"packages"`)
+
+	require.NoError(t, New().Build(config))
+	second, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(second))
+}
+
+func TestGen_EnvPlaceholders(t *testing.T) {
+	t.Run("should write placeholders for host and basePath", func(t *testing.T) {
+		config := &Config{
+			SearchDir:   "../../testing/testdata/pet",
+			MainAPIFile: "./main.go",
+			HostEnv:     "API_HOST",
+			BasePathEnv: "API_BASE_PATH",
+		}
+
+		swagger, _, err := New().BuildSpec(config)
+		require.NoError(t, err)
+		assert.Equal(t, "${API_HOST}", swagger.Host)
+		assert.Equal(t, "${API_BASE_PATH}", swagger.BasePath)
+	})
+
+	t.Run("should keep literal values by default", func(t *testing.T) {
+		config := &Config{
+			SearchDir:   "../../testing/testdata/pet",
+			MainAPIFile: "./main.go",
+		}
+
+		swagger, _, err := New().BuildSpec(config)
+		require.NoError(t, err)
+		assert.NotContains(t, swagger.Host, "${")
+		assert.NotContains(t, swagger.BasePath, "${")
+	})
+
+	t.Run("should expand the placeholders with swagenv", func(t *testing.T) {
+		t.Setenv("API_HOST", "api.example.com")
+		config := &Config{
+			SearchDir:   "../../testing/testdata/pet",
+			MainAPIFile: "./main.go",
+			HostEnv:     "API_HOST",
+		}
+
+		swagger, _, err := New().BuildSpec(config)
+		require.NoError(t, err)
+		doc, err := json.Marshal(swagger)
+		require.NoError(t, err)
+		assert.Contains(t, string(swagenv.Expand(doc, "API_HOST")), `"host":"api.example.com"`)
+	})
+}
+
+func TestGen_AddDefinition(t *testing.T) {
+	money := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: spec.SchemaProperties{
+				"amount":   *spec.StringProperty(),
+				"currency": *spec.StringProperty(),
+			},
+		},
+	}
+
+	t.Run("should merge registered definitions into the spec", func(t *testing.T) {
+		g := New()
+		require.NoError(t, g.AddDefinition("external.Money", money))
+
+		swagger, _, err := g.BuildSpec(&Config{
+			SearchDir:   searchDir,
+			MainAPIFile: "./main.go",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, money, swagger.Definitions["external.Money"])
+	})
+
+	t.Run("should reject registering the same name twice", func(t *testing.T) {
+		g := New()
+		require.NoError(t, g.AddDefinition("external.Money", money))
+
+		err := g.AddDefinition("external.Money", money)
+		assert.True(t, errors.Is(err, orchestrator.ErrDefinitionConflict))
+	})
+
+	t.Run("should report conflicts with generated definitions", func(t *testing.T) {
+		g := New()
+		require.NoError(t, g.AddDefinition("api.Foo", money))
+
+		swagger, warnings, err := g.BuildSpec(&Config{
+			SearchDir:   "../../testing/testdata/nested",
+			MainAPIFile: "./main.go",
+		})
+		require.NoError(t, err)
+		assert.NotEqual(t, money, swagger.Definitions["api.Foo"], "generated definition should be kept")
+
+		var conflicts []orchestrator.Warning
+		for _, warning := range warnings {
+			if warning.Category == orchestrator.WarningDefinitionConflict {
+				conflicts = append(conflicts, warning)
+			}
+		}
+		require.Len(t, conflicts, 1)
+		assert.Contains(t, conflicts[0].Message, `"api.Foo"`)
+
+		_, _, err = g.BuildSpec(&Config{
+			SearchDir:   "../../testing/testdata/nested",
+			MainAPIFile: "./main.go",
+			Strict:      true,
+		})
+		assert.True(t, errors.Is(err, orchestrator.ErrDefinitionConflict))
+	})
+}
+
 func TestGen_configWithOutputTypesAll(t *testing.T) {
 	searchDir := "../../testing/testdata/simple"
 	outputTypes := []string{"json", "yaml"}
@@ -681,3 +998,322 @@ func TestGen_StateUser(t *testing.T) {
 
 	assert.JSONEq(t, string(expectedJSON), string(jsonOutput))
 }
+
+func TestGen_NamingStrategy(t *testing.T) {
+	swagger, warnings, err := New().BuildSpec(&Config{
+		SearchDir:      "../../testing/testdata/nested",
+		MainAPIFile:    "./main.go",
+		NamingStrategy: "short",
+	})
+	require.NoError(t, err)
+
+	for _, warning := range warnings {
+		assert.NotEqual(t, orchestrator.WarningDanglingRef, warning.Category, "refs should follow renamed definitions: %s", warning.Message)
+	}
+
+	assert.Contains(t, swagger.Definitions, "Foo")
+	assert.NotContains(t, swagger.Definitions, "api.Foo")
+	for name := range swagger.Definitions {
+		assert.NotContains(t, name, ".", "definition %s should not be package-qualified", name)
+	}
+
+	_, _, err = New().BuildSpec(&Config{
+		SearchDir:      "../../testing/testdata/nested",
+		MainAPIFile:    "./main.go",
+		NamingStrategy: "unknown",
+	})
+	assert.Error(t, err)
+}
+
+func TestGen_JSONSchema(t *testing.T) {
+	pet := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:     []string{"object"},
+			Required: []string{"kind"},
+			Properties: spec.SchemaProperties{
+				"kind":    {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Enum: []interface{}{"cat", "dog"}}},
+				"born":    {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "date-time"}},
+				"owner":   *spec.RefSchema("#/definitions/external.Owner"),
+				"example": *spec.StringProperty(),
+			},
+		},
+		SwaggerSchemaProps: spec.SwaggerSchemaProps{Discriminator: "kind", Example: map[string]interface{}{"kind": "cat"}},
+	}
+	owner := *spec.StringProperty()
+	owner.AddExtension("x-nullable", true)
+
+	g := New()
+	require.NoError(t, g.AddDefinition("external.Pet", pet))
+	require.NoError(t, g.AddDefinition("external.Owner", owner))
+
+	config := &Config{
+		SearchDir:   searchDir,
+		MainAPIFile: "./main.go",
+		OutputDir:   filepath.Join(t.TempDir(), "docs"),
+		OutputTypes: []string{"jsonschema"},
+	}
+	require.NoError(t, g.Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.schema.json"))
+	require.NoError(t, err)
+
+	var bundle map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &bundle))
+	assert.Equal(t, jsonSchemaDraft, bundle["$schema"])
+
+	defs := bundle["definitions"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"string", "null"}, defs["external.Owner"].(map[string]interface{})["type"])
+
+	petSchema := defs["external.Pet"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"kind"}, petSchema["required"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"kind": "cat"}}, petSchema["examples"])
+	assert.NotContains(t, petSchema, "discriminator")
+	assert.NotContains(t, petSchema, "example")
+
+	properties := petSchema["properties"].(map[string]interface{})
+	assert.Equal(t, "#/definitions/external.Owner", properties["owner"].(map[string]interface{})["$ref"])
+	assert.Equal(t, []interface{}{"cat", "dog"}, properties["kind"].(map[string]interface{})["enum"])
+	assert.Equal(t, "date-time", properties["born"].(map[string]interface{})["format"])
+	assert.Contains(t, properties, "example", "properties named like a keyword are kept")
+}
+
+func TestGen_WriteTo(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			SearchDir:   searchDir,
+			MainAPIFile: "./main.go",
+			OutputDir:   filepath.Join(t.TempDir(), "docs"),
+		}
+	}
+
+	t.Run("writes the chosen format without touching disk", func(t *testing.T) {
+		config := newConfig()
+		var jsonOut, yamlOut bytes.Buffer
+		require.NoError(t, New().WriteTo(config, &jsonOut, "json"))
+		require.NoError(t, New().WriteTo(config, &yamlOut, " YAML "))
+
+		var swagger spec.Swagger
+		require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &swagger))
+		assert.Equal(t, "2.0", swagger.Swagger)
+		assert.Contains(t, yamlOut.String(), "swagger: \"2.0\"")
+
+		_, err := os.Stat(config.OutputDir)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("matches the file Build writes", func(t *testing.T) {
+		config := newConfig()
+		config.OutputTypes = []string{"jsonschema"}
+		require.NoError(t, New().Build(config))
+		written, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.schema.json"))
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		require.NoError(t, New().WriteTo(config, &out, "jsonschema"))
+		assert.Equal(t, string(written), out.String())
+	})
+
+	t.Run("rejects unknown output types", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.EqualError(t, New().WriteTo(newConfig(), &out, "toml"), "output type 'toml' not supported")
+		assert.Zero(t, out.Len())
+	})
+}
+
+func TestGen_FunctionScopedTypes(t *testing.T) {
+	// Struct fields are resolved with go/packages, which needs the fixture's module.
+	t.Chdir("../../testing/testdata/function_scoped")
+	config := &Config{
+		SearchDir:   "./",
+		MainAPIFile: "./main.go",
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, New().WriteTo(config, &out, "json"))
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal(out.Bytes(), &swagger))
+
+	response := swagger.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200]
+	assert.Equal(t, "#/definitions/api.GetPet.response", response.Schema.Ref.String())
+
+	definition, ok := swagger.Definitions["api.GetPet.response"]
+	require.True(t, ok, "function-scoped type should be defined under its scoped name")
+	assert.Equal(t, "ApiGetPetResponse", definition.Title)
+	assert.Equal(t, "response is the body returned by GetPet", definition.Description)
+	assert.Contains(t, definition.Properties, "id")
+	assert.Contains(t, definition.Properties, "name")
+	assert.Equal(t, "#/definitions/api.Tag", definition.Properties["tags"].Items.Schema.Ref.String())
+	assert.Contains(t, swagger.Definitions, "api.Tag")
+	assert.NotContains(t, swagger.Definitions, "api.response")
+
+	// A local type referenced from another local type keeps its scoped name.
+	ownerProperty := definition.Properties["owner"]
+	assert.Equal(t, "#/definitions/api.GetPet.owner", ownerProperty.Ref.String())
+	assert.Equal(t, "#/definitions/api.GetPet.owner", definition.Properties["owners"].Items.Schema.Ref.String())
+	owner, ok := swagger.Definitions["api.GetPet.owner"]
+	require.True(t, ok, "local type referenced from a local type should be defined under its scoped name")
+	assert.Contains(t, owner.Properties, "name")
+	assert.NotContains(t, swagger.Definitions, "api.owner")
+}
+
+func TestGen_SplitByTag(t *testing.T) {
+	// Struct fields are resolved with go/packages, which needs the fixture's module.
+	t.Chdir("../../testing/testdata/split_by_tag")
+	config := &Config{
+		SearchDir:   "./",
+		MainAPIFile: "./main.go",
+		OutputDir:   t.TempDir(),
+		OutputTypes: []string{"json"},
+		SplitByTag:  true,
+	}
+	require.NoError(t, New().Build(config))
+
+	readSpec := func(dir string) spec.Swagger {
+		b, err := os.ReadFile(filepath.Join(config.OutputDir, dir, "swagger.json"))
+		require.NoError(t, err)
+		var swagger spec.Swagger
+		require.NoError(t, json.Unmarshal(b, &swagger))
+		return swagger
+	}
+
+	full := readSpec("")
+	assert.Len(t, full.Paths.Paths, 2)
+
+	pets := readSpec("pets")
+	assert.Equal(t, []string{"/pets"}, schemautil.SortedKeys(pets.Paths.Paths))
+	assert.Equal(t, []string{"api.Address", "api.Owner", "api.Pet"}, schemautil.SortedKeys(pets.Definitions), "only definitions reachable from the pets operations")
+	assert.Equal(t, full.Info, pets.Info)
+
+	stores := readSpec("stores")
+	assert.Equal(t, []string{"/stores"}, schemautil.SortedKeys(stores.Paths.Paths))
+	assert.Equal(t, []string{"api.Address", "api.Store"}, schemautil.SortedKeys(stores.Definitions))
+
+	shared := readSpec("_shared")
+	assert.Equal(t, full.Info, shared.Info)
+	assert.Empty(t, shared.Paths.Paths)
+	assert.Empty(t, shared.Definitions)
+}
+
+func TestGen_TypeAliases(t *testing.T) {
+	// Struct fields are resolved with go/packages, which needs the fixture's module.
+	t.Chdir("../../testing/testdata/alias_assign")
+	config := &Config{
+		SearchDir:   "./",
+		MainAPIFile: "./main.go",
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, New().WriteTo(config, &out, "json"))
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal(out.Bytes(), &swagger))
+
+	t.Run("aliases of primitives are inlined", func(t *testing.T) {
+		// AccountID aliases ids.UUID, a [16]byte documented as a uuid string, not an integer array
+		holder := swagger.Definitions["model.Holder"]
+		assert.Equal(t, spec.StringOrArray{"string"}, holder.Properties["id"].Type)
+		assert.Equal(t, "uuid", holder.Properties["id"].Format)
+		assert.Nil(t, holder.Properties["id"].Items)
+		assert.Equal(t, spec.StringOrArray{"string"}, holder.Properties["name"].Type)
+
+		response := swagger.Paths.Paths["/accounts/{id}"].Get.Responses.StatusCodeResponses[202]
+		assert.Equal(t, spec.StringOrArray{"string"}, response.Schema.Type)
+	})
+
+	t.Run("aliases of structs reference the aliased type", func(t *testing.T) {
+		holder := swagger.Definitions["model.Holder"]
+		account, accounts := holder.Properties["account"], holder.Properties["accounts"]
+		assert.Equal(t, "#/definitions/model.Profile", account.Ref.String())
+		assert.Equal(t, "#/definitions/model.Profile", accounts.Items.Schema.Ref.String())
+
+		response := swagger.Paths.Paths["/accounts/{id}"].Get.Responses.StatusCodeResponses[200]
+		assert.Equal(t, "#/definitions/model.Profile", response.Schema.Ref.String())
+	})
+
+	for _, alias := range []string{"model.Account", "model.AccountID", "model.Name", "ids.UUID"} {
+		assert.NotContains(t, swagger.Definitions, alias, "aliases get no definition of their own")
+	}
+	assert.Contains(t, swagger.Definitions, "model.Profile")
+}
+
+func TestGen_EnumGaps(t *testing.T) {
+	t.Chdir("../../testing/testdata/enum_gaps")
+	config := &Config{
+		SearchDir:   "./",
+		MainAPIFile: "./main.go",
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, New().WriteTo(config, &out, "json"))
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal(out.Bytes(), &swagger))
+
+	t.Run("blank constants advance iota without becoming values", func(t *testing.T) {
+		grade := swagger.Definitions["model.Grade"]
+		assert.Equal(t, []interface{}{float64(0), float64(2)}, grade.Enum)
+		assert.Equal(t, []interface{}{"A", "C"}, grade.Extensions["x-enum-varnames"])
+	})
+
+	t.Run("non-contiguous values keep their declaration order", func(t *testing.T) {
+		code := swagger.Definitions["model.Code"]
+		assert.Equal(t, []interface{}{float64(200), float64(404), float64(418)}, code.Enum)
+		assert.Equal(t, []interface{}{"CodeOK", "CodeNotFound", "CodeTeapot"}, code.Extensions["x-enum-varnames"])
+	})
+}
+
+func TestGen_EnumAcrossFiles(t *testing.T) {
+	t.Chdir("../../testing/testdata/enum_split")
+	config := &Config{
+		SearchDir:   "./",
+		MainAPIFile: "./main.go",
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, New().WriteTo(config, &out, "json"))
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal(out.Bytes(), &swagger))
+
+	status := swagger.Definitions["model.Status"]
+	assert.ElementsMatch(t, []interface{}{"pending", "paid", "shipped", "cancelled", "refunded"}, status.Enum)
+	assert.ElementsMatch(t,
+		[]interface{}{"StatusPending", "StatusPaid", "StatusShipped", "StatusCancelled", "StatusRefunded"},
+		status.Extensions["x-enum-varnames"],
+	)
+}
+
+func TestGen_MaxDefinitions(t *testing.T) {
+	config := &Config{
+		SearchDir:   "../../testing/testdata/simple",
+		MainAPIFile: "./main.go",
+		OutputDir:   filepath.Join(t.TempDir(), "docs"),
+		OutputTypes: []string{"json"},
+	}
+
+	swagger, _, err := New().BuildSpec(config)
+	require.NoError(t, err)
+	count := len(swagger.Definitions)
+	require.Greater(t, count, 2)
+
+	t.Run("should fail above the limit without writing files", func(t *testing.T) {
+		limited := *config
+		limited.MaxDefinitions = 2
+
+		err := New().Build(&limited)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("%d definitions exceed the limit of 2", count))
+		assert.Contains(t, err.Error(), "top packages: ")
+
+		_, statErr := os.Stat(filepath.Join(limited.OutputDir, "swagger.json"))
+		assert.True(t, os.IsNotExist(statErr))
+	})
+
+	t.Run("should pass at the limit", func(t *testing.T) {
+		limited := *config
+		limited.MaxDefinitions = count
+
+		require.NoError(t, New().Build(&limited))
+	})
+}
@@ -0,0 +1,67 @@
+package gen
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+
+	"github.com/griffnb/core-swag/internal/console"
+	"github.com/griffnb/core-swag/internal/orchestrator"
+	"github.com/pkg/errors"
+)
+
+// stateFileName returns the path of the incremental build state in the output directory.
+func stateFileName(config *Config) string {
+	filename := "swagger.state.json"
+
+	if config.State != "" {
+		filename = config.State + "_" + filename
+	}
+
+	if config.InstanceName != "" && config.InstanceName != DefaultInstanceName {
+		filename = config.InstanceName + "_" + filename
+	}
+
+	return path.Join(config.OutputDir, filename)
+}
+
+// readBuildState reads the state of the previous incremental build. A missing
+// or unreadable state file is not an error, the build is just not incremental.
+func (g *Gen) readBuildState(config *Config) *orchestrator.BuildState {
+	data, err := os.ReadFile(stateFileName(config))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			g.debug.Printf("Ignoring incremental build state: %v", err)
+		}
+		return nil
+	}
+
+	var state orchestrator.BuildState
+	if err := json.Unmarshal(data, &state); err != nil {
+		g.debug.Printf("Ignoring incremental build state: %v", err)
+		return nil
+	}
+
+	return &state
+}
+
+// writeBuildState writes the state of the last build for the next incremental one.
+func (g *Gen) writeBuildState(config *Config) error {
+	if g.state == nil {
+		return nil
+	}
+
+	b, err := g.json(g.state)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	stateFileName := stateFileName(config)
+	if err := g.writeFile(b, stateFileName); err != nil {
+		return err
+	}
+
+	console.Logger.Debug("create swagger.state.json at %+v", stateFileName)
+
+	return nil
+}
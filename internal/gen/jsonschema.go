@@ -0,0 +1,135 @@
+package gen
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/pkg/errors"
+)
+
+// jsonSchemaDraft is the $schema of the jsonschema output type.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// swaggerOnlyKeywords are schema keywords of Swagger 2.0 that JSON Schema does not define.
+var swaggerOnlyKeywords = []string{"discriminator", "xml", "externalDocs", "example"}
+
+// encodeJSONSchema encodes the definitions of the spec as a draft-07 JSON Schema
+// bundle, written as swagger.schema.json, with every definition under definitions.
+func (g *Gen) encodeJSONSchema(swagger *spec.Swagger) ([]byte, error) {
+	defs, err := toJSONSchemaDefs(swagger.Definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := map[string]interface{}{
+		"$schema":     jsonSchemaDraft,
+		"definitions": defs,
+	}
+	if swagger.Info != nil && swagger.Info.Title != "" {
+		bundle["title"] = swagger.Info.Title
+	}
+
+	return g.jsonIndent(bundle)
+}
+
+// toJSONSchemaDefs converts Swagger definitions to JSON Schema ones. The conversion
+// goes through the marshaled form, so the output matches the json output type, and
+// marks nullable schemas on a copy like OpenAPI 3.1 does, leaving the spec untouched.
+func toJSONSchemaDefs(definitions spec.Definitions) (map[string]interface{}, error) {
+	defs := make(map[string]interface{}, len(definitions))
+	for name, def := range definitions {
+		data, err := json.Marshal(def)
+		if err != nil {
+			return nil, errors.Wrapf(err, "definition %s", name)
+		}
+
+		var copied spec.Schema
+		if err := json.Unmarshal(data, &copied); err != nil {
+			return nil, errors.Wrapf(err, "definition %s", name)
+		}
+		markNullableSchema(&copied, openAPI31)
+		if data, err = json.Marshal(copied); err != nil {
+			return nil, errors.Wrapf(err, "definition %s", name)
+		}
+
+		var schema map[string]interface{}
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, errors.Wrapf(err, "definition %s", name)
+		}
+
+		defs[name] = toJSONSchema(schema)
+	}
+	return defs, nil
+}
+
+// toJSONSchema rewrites a marshaled Swagger schema in place: boolean exclusive
+// bounds become the numeric ones of draft-07, example becomes examples and the
+// other Swagger-only keywords and vendor extensions are dropped. Local refs keep
+// pointing to #/definitions, where the bundle holds the definitions. Sub-schemas
+// are only looked up under the keywords holding them, so properties named like a
+// keyword are left alone.
+func toJSONSchema(schema map[string]interface{}) map[string]interface{} {
+	toExclusiveBound(schema, "minimum", "exclusiveMinimum")
+	toExclusiveBound(schema, "maximum", "exclusiveMaximum")
+
+	if example, ok := schema["example"]; ok {
+		schema["examples"] = []interface{}{example}
+	}
+
+	for _, keyword := range swaggerOnlyKeywords {
+		delete(schema, keyword)
+	}
+	for key := range schema {
+		if strings.HasPrefix(key, "x-") {
+			delete(schema, key)
+		}
+	}
+
+	for _, keyword := range []string{"properties", "patternProperties", "definitions"} {
+		if schemas, ok := schema[keyword].(map[string]interface{}); ok {
+			for name, sub := range schemas {
+				schemas[name] = toJSONSchemaValue(sub)
+			}
+		}
+	}
+
+	for _, keyword := range []string{"items", "additionalProperties", "additionalItems", "not", "allOf", "anyOf", "oneOf"} {
+		if sub, ok := schema[keyword]; ok {
+			schema[keyword] = toJSONSchemaValue(sub)
+		}
+	}
+
+	return schema
+}
+
+// toExclusiveBound replaces Swagger's boolean exclusive bound with the numeric
+// one of draft-07, e.g. minimum: 1 and exclusiveMinimum: true become
+// exclusiveMinimum: 1.
+func toExclusiveBound(schema map[string]interface{}, bound, exclusive string) {
+	isExclusive, ok := schema[exclusive].(bool)
+	if !ok {
+		return
+	}
+
+	delete(schema, exclusive)
+	if value, ok := schema[bound]; ok && isExclusive {
+		schema[exclusive] = value
+		delete(schema, bound)
+	}
+}
+
+// toJSONSchemaValue converts a schema, a list of schemas or a boolean schema.
+func toJSONSchemaValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return toJSONSchema(val)
+	case []interface{}:
+		for i, item := range val {
+			val[i] = toJSONSchemaValue(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
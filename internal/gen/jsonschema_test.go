@@ -0,0 +1,209 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGen_JSONSchemaExclusiveBounds(t *testing.T) {
+	metaschema, err := os.ReadFile("testdata/json-schema-draft-07.json")
+	require.NoError(t, err)
+	var meta map[string]interface{}
+	require.NoError(t, json.Unmarshal(metaschema, &meta))
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/boundsapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Bounds API
+// @version 1.0
+func main() {}
+`,
+		"api/api.go": `package api
+
+type Range struct {
+	Count int     ` + "`json:\"count\" validate:\"gt=0,lt=10\"`" + `
+	Ratio float64 ` + "`json:\"ratio\" validate:\"gte=0,lt=1\"`" + `
+}
+
+// @Success 200 {object} Range
+// @Router /range [get]
+func GetRange() {}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	// Struct fields are resolved with go/packages, which needs the fixture's module.
+	t.Chdir(dir)
+
+	var out bytes.Buffer
+	require.NoError(t, New().WriteTo(&Config{SearchDir: "./", MainAPIFile: "./main.go"}, &out, "jsonschema"))
+
+	var bundle map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &bundle))
+	assert.NoError(t, validateDraft07(meta, meta, bundle, "#"))
+
+	properties := bundle["definitions"].(map[string]interface{})["api.Range"].(map[string]interface{})["properties"].(map[string]interface{})
+	count := properties["count"].(map[string]interface{})
+	assert.Equal(t, float64(0), count["exclusiveMinimum"])
+	assert.Equal(t, float64(10), count["exclusiveMaximum"])
+	assert.NotContains(t, count, "minimum")
+	assert.NotContains(t, count, "maximum")
+
+	ratio := properties["ratio"].(map[string]interface{})
+	assert.Equal(t, float64(0), ratio["minimum"])
+	assert.NotContains(t, ratio, "exclusiveMinimum")
+	assert.Equal(t, float64(1), ratio["exclusiveMaximum"])
+}
+
+// validateDraft07 validates instance against schema, a part of the draft-07
+// metaschema root. Only the keywords the metaschema uses are implemented.
+func validateDraft07(root, schema interface{}, instance interface{}, path string) error {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		if schema == false {
+			return fmt.Errorf("%s: not allowed", path)
+		}
+		return nil
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		target := root
+		if name := strings.TrimPrefix(ref, "#/definitions/"); name != ref {
+			target = root.(map[string]interface{})["definitions"].(map[string]interface{})[name]
+		}
+		return validateDraft07(root, target, instance, path)
+	}
+
+	if types, ok := s["type"]; ok && !draft07TypeMatches(types, instance) {
+		return fmt.Errorf("%s: %v is not of type %v", path, instance, types)
+	}
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, value := range enum {
+			found = found || reflect.DeepEqual(value, instance)
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, instance, enum)
+		}
+	}
+	if minimum, ok := s["minimum"].(float64); ok {
+		if n, ok := instance.(float64); ok && n < minimum {
+			return fmt.Errorf("%s: %v is less than %v", path, n, minimum)
+		}
+	}
+	if minimum, ok := s["exclusiveMinimum"].(float64); ok {
+		if n, ok := instance.(float64); ok && n <= minimum {
+			return fmt.Errorf("%s: %v is not greater than %v", path, n, minimum)
+		}
+	}
+	for _, keyword := range []string{"allOf", "anyOf"} {
+		schemas, ok := s[keyword].([]interface{})
+		if !ok {
+			continue
+		}
+		matched := 0
+		var lastErr error
+		for _, sub := range schemas {
+			if err := validateDraft07(root, sub, instance, path); err != nil {
+				lastErr = err
+				continue
+			}
+			matched++
+		}
+		if (keyword == "allOf" && matched != len(schemas)) || (keyword == "anyOf" && matched == 0) {
+			return lastErr
+		}
+	}
+
+	switch value := instance.(type) {
+	case map[string]interface{}:
+		properties, _ := s["properties"].(map[string]interface{})
+		for name, item := range value {
+			sub, ok := properties[name]
+			if !ok {
+				sub, ok = s["additionalProperties"]
+			}
+			if !ok {
+				continue
+			}
+			if err := validateDraft07(root, sub, item, path+"/"+name); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if minItems, ok := s["minItems"].(float64); ok && float64(len(value)) < minItems {
+			return fmt.Errorf("%s: fewer than %v items", path, minItems)
+		}
+		if unique, _ := s["uniqueItems"].(bool); unique {
+			for i := range value {
+				for j := i + 1; j < len(value); j++ {
+					if reflect.DeepEqual(value[i], value[j]) {
+						return fmt.Errorf("%s: items are not unique", path)
+					}
+				}
+			}
+		}
+		if items, ok := s["items"]; ok {
+			for i, item := range value {
+				if err := validateDraft07(root, items, item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// draft07TypeMatches reports whether instance is of one of the JSON Schema types.
+func draft07TypeMatches(types interface{}, instance interface{}) bool {
+	names, ok := types.([]interface{})
+	if !ok {
+		names = []interface{}{types}
+	}
+
+	for _, name := range names {
+		switch value := instance.(type) {
+		case map[string]interface{}:
+			if name == "object" {
+				return true
+			}
+		case []interface{}:
+			if name == "array" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case float64:
+			if name == "number" || (name == "integer" && value == math.Trunc(value)) {
+				return true
+			}
+		case nil:
+			if name == "null" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+// topPackagesReported is the number of packages listed when there are too many definitions.
+const topPackagesReported = 5
+
+// checkMaxDefinitions returns an error listing the packages with the most
+// definitions when the spec has more than max of them. max 0 is unlimited.
+func checkMaxDefinitions(swagger *spec.Swagger, max int) error {
+	if max <= 0 || len(swagger.Definitions) <= max {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for name := range swagger.Definitions {
+		counts[definitionPackage(name)]++
+	}
+
+	packages := schemautil.SortedKeys(counts)
+	sort.SliceStable(packages, func(i, j int) bool {
+		return counts[packages[i]] > counts[packages[j]]
+	})
+	if len(packages) > topPackagesReported {
+		packages = packages[:topPackagesReported]
+	}
+
+	top := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		top = append(top, fmt.Sprintf("%s (%d)", pkg, counts[pkg]))
+	}
+
+	return fmt.Errorf("%d definitions exceed the limit of %d, top packages: %s",
+		len(swagger.Definitions), max, strings.Join(top, ", "))
+}
+
+// definitionPackage returns the package qualifier of a definition name, e.g.
+// "model" for model.Pet, or "(none)" for unqualified names.
+func definitionPackage(name string) string {
+	if dot := strings.LastIndex(name, "."); dot > 0 {
+		return name[:dot]
+	}
+	return "(none)"
+}
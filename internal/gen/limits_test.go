@@ -0,0 +1,26 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckMaxDefinitions(t *testing.T) {
+	swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Definitions: spec.Definitions{
+		"model.Pet":          {},
+		"model.PetPublic":    {},
+		"model.Owner":        {},
+		"github.com/x/y.Tag": {},
+		"Error":              {},
+	}}}
+
+	assert.NoError(t, checkMaxDefinitions(swagger, 0))
+	assert.NoError(t, checkMaxDefinitions(swagger, 5))
+
+	err := checkMaxDefinitions(swagger, 4)
+	require.Error(t, err)
+	assert.Equal(t, "5 definitions exceed the limit of 4, top packages: model (3), (none) (1), github.com/x/y (1)", err.Error())
+}
@@ -0,0 +1,45 @@
+package gen
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/griffnb/core-swag/internal/loader"
+	"github.com/griffnb/core-swag/internal/orchestrator"
+)
+
+// Lint checks the annotations of config.SearchDir for common mistakes without
+// building schemas or writing any files, see orchestrator.Service.Lint. Only the
+// options that select and load the source files are used.
+func (g *Gen) Lint(config *Config) ([]orchestrator.Warning, error) {
+	if config.Debugger != nil {
+		g.debug = config.Debugger
+	}
+
+	searchDirs := strings.Split(config.SearchDir, ",")
+	if !config.ParseGoPackages {
+		for _, searchDir := range searchDirs {
+			if _, err := os.Stat(searchDir); os.IsNotExist(err) {
+				return nil, fmt.Errorf("dir: %s does not exist", searchDir)
+			}
+		}
+	}
+
+	orc := orchestrator.New(&orchestrator.Config{
+		ParseVendor:             config.ParseVendor,
+		ParseInternal:           config.ParseInternal,
+		ParseDependency:         loader.ParseFlag(config.ParseDependency),
+		MarkdownFileDir:         config.MarkdownFilesDir,
+		CollectionFormatInQuery: config.CollectionFormat,
+		Excludes:                parseExcludes(config.Excludes),
+		PackagePrefix:           parsePackagePrefix(config.PackagePrefix),
+		ParseExtension:          config.ParseExtension,
+		ParseGoList:             config.ParseGoList,
+		ParseGoPackages:         config.ParseGoPackages,
+		BuildTags:               parseBuildTags(config.BuildTags),
+		Debug:                   g.debug,
+	})
+
+	return orc.Lint(searchDirs, config.MainAPIFile, config.ParseDepth)
+}
@@ -0,0 +1,82 @@
+package gen
+
+import (
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/schema"
+)
+
+// nullableExtension is the Swagger 2.0 vendor extension marking a schema as nullable.
+const nullableExtension = "x-nullable"
+
+// specVersion is the spec version an output type writes nullable markers for.
+type specVersion int
+
+const (
+	// swagger20 marks nullable schemas with the x-nullable extension.
+	swagger20 specVersion = iota
+	// openAPI30 marks nullable schemas with nullable: true.
+	openAPI30
+	// openAPI31 adds "null" to the type of nullable schemas, as JSON Schema does.
+	openAPI31
+)
+
+// isNullable reports whether a schema is marked nullable: by the version-neutral
+// Nullable field set while parsing, or by an x-nullable extension from a struct
+// tag or an earlier Swagger 2.0 transform.
+func isNullable(schema *spec.Schema) bool {
+	if schema.Nullable {
+		return true
+	}
+	nullable, _ := schema.Extensions.GetBool(nullableExtension)
+	return nullable
+}
+
+// markNullable rewrites the nullable marker of a schema the way version expresses
+// it. Keywords next to a $ref are ignored, so a nullable $ref is wrapped in an allOf
+// first. In 3.1, a single type takes "null" and a wrapped $ref becomes an anyOf with
+// a null schema; other schemas without a single type lose the marker. Swagger 2.0
+// keeps x-nullable, so the transform is idempotent and the other versions still
+// find the marker afterwards.
+func markNullable(schema *spec.Schema, version specVersion) {
+	if !isNullable(schema) {
+		return
+	}
+
+	schema.Nullable = false
+	delete(schema.Extensions, nullableExtension)
+
+	if schema.Ref.String() != "" {
+		schema.AllOf = append([]spec.Schema{{SchemaProps: spec.SchemaProps{Ref: schema.Ref}}}, schema.AllOf...)
+		schema.Ref = spec.Ref{}
+	}
+
+	switch version {
+	case swagger20:
+		schema.AddExtension(nullableExtension, true)
+	case openAPI30:
+		schema.Nullable = true
+	case openAPI31:
+		if len(schema.Type) == 1 {
+			schema.Type = spec.StringOrArray{schema.Type[0], "null"}
+		} else if len(schema.Type) == 0 && len(schema.AllOf) == 1 {
+			schema.AnyOf = append(schema.AnyOf, schema.AllOf[0], spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"null"}}})
+			schema.AllOf = nil
+		}
+	}
+}
+
+// markNullableSchemas rewrites the nullable markers of every schema of the spec in
+// place: definitions, shared parameters and responses, and those of paths.
+func markNullableSchemas(swagger *spec.Swagger, version specVersion) {
+	nullableMarker(version).Walk(swagger)
+}
+
+// markNullableSchema rewrites the nullable markers of a schema and all of its sub-schemas.
+func markNullableSchema(schema *spec.Schema, version specVersion) {
+	nullableMarker(version).WalkSchema(schema)
+}
+
+// nullableMarker returns a walker rewriting nullable markers the way version expresses them.
+func nullableMarker(version specVersion) schema.Walker {
+	return schema.Walker{Schema: func(s *spec.Schema) { markNullable(s, version) }}
+}
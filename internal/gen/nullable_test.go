@@ -0,0 +1,136 @@
+package gen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nullableSpec returns a spec whose nullable schemas carry the version-neutral
+// marker set while parsing, in a definition and in a response.
+func nullableSpec() *spec.Swagger {
+	name := *spec.StringProperty()
+	name.Nullable = true
+	tags := *spec.ArrayProperty(spec.StringProperty())
+	tags.Items.Schema.Nullable = true
+	owner := *spec.RefSchema("#/definitions/Owner")
+	owner.Nullable = true
+
+	response := spec.Response{ResponseProps: spec.ResponseProps{Schema: spec.StringProperty()}}
+	response.Schema.Nullable = true
+
+	return &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Definitions: spec.Definitions{
+				"Pet": {SchemaProps: spec.SchemaProps{
+					Type:       []string{"object"},
+					Properties: spec.SchemaProperties{"name": name, "tags": tags, "owner": owner},
+				}},
+			},
+			Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+				"/pets": {PathItemProps: spec.PathItemProps{Get: &spec.Operation{OperationProps: spec.OperationProps{
+					Responses: &spec.Responses{ResponsesProps: spec.ResponsesProps{
+						StatusCodeResponses: map[int]spec.Response{200: response},
+					}},
+				}}}},
+			}},
+		},
+	}
+}
+
+func TestMarkNullableSchemas(t *testing.T) {
+	t.Run("should use the x-nullable extension for Swagger 2.0", func(t *testing.T) {
+		swagger := nullableSpec()
+		markNullableSchemas(swagger, swagger20)
+		markNullableSchemas(swagger, swagger20)
+
+		pet := swagger.Definitions["Pet"]
+		for _, schema := range []spec.Schema{pet.Properties["name"], *pet.Properties["tags"].Items.Schema, pet.Properties["owner"]} {
+			assert.False(t, schema.Nullable)
+			assert.Equal(t, true, schema.Extensions[nullableExtension])
+		}
+		owner := pet.Properties["owner"]
+		assert.Empty(t, owner.Ref.String())
+		require.Len(t, owner.AllOf, 1)
+		assert.Equal(t, "#/definitions/Owner", owner.AllOf[0].Ref.String())
+		response := swagger.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200]
+		assert.False(t, response.Schema.Nullable)
+		assert.Equal(t, true, response.Schema.Extensions[nullableExtension])
+	})
+
+	t.Run("should use nullable: true for OpenAPI 3.0", func(t *testing.T) {
+		swagger := nullableSpec()
+		markNullableSchemas(swagger, swagger20)
+		markNullableSchemas(swagger, openAPI30)
+
+		pet := swagger.Definitions["Pet"]
+		for _, schema := range []spec.Schema{pet.Properties["name"], *pet.Properties["tags"].Items.Schema, pet.Properties["owner"]} {
+			assert.True(t, schema.Nullable)
+			assert.NotContains(t, schema.Extensions, nullableExtension)
+		}
+	})
+
+	t.Run("should add a null type for OpenAPI 3.1", func(t *testing.T) {
+		swagger := nullableSpec()
+		markNullableSchemas(swagger, openAPI31)
+
+		pet := swagger.Definitions["Pet"]
+		name, tag, owner := pet.Properties["name"], pet.Properties["tags"].Items.Schema, pet.Properties["owner"]
+		assert.Equal(t, spec.StringOrArray{"string", "null"}, name.Type)
+		assert.Equal(t, spec.StringOrArray{"string", "null"}, tag.Type)
+		assert.Equal(t, spec.StringOrArray{"array"}, pet.Properties["tags"].Type)
+		assert.Empty(t, owner.Ref.String())
+		assert.Empty(t, owner.AllOf)
+		require.Len(t, owner.AnyOf, 2)
+		assert.Equal(t, "#/definitions/Owner", owner.AnyOf[0].Ref.String())
+		assert.Equal(t, spec.StringOrArray{"null"}, owner.AnyOf[1].Type)
+		for _, schema := range []spec.Schema{name, *tag, owner} {
+			assert.False(t, schema.Nullable)
+			assert.NotContains(t, schema.Extensions, nullableExtension)
+		}
+	})
+
+	t.Run("should wrap a $ref marked for Swagger 2.0 in an anyOf for OpenAPI 3.1", func(t *testing.T) {
+		swagger := nullableSpec()
+		markNullableSchemas(swagger, swagger20)
+		markNullableSchemas(swagger, openAPI31)
+
+		owner := swagger.Definitions["Pet"].Properties["owner"]
+		require.Len(t, owner.AnyOf, 2)
+		assert.Equal(t, "#/definitions/Owner", owner.AnyOf[0].Ref.String())
+		assert.Empty(t, owner.AllOf)
+		assert.NotContains(t, owner.Extensions, nullableExtension)
+	})
+}
+
+func TestGen_NullableOutput(t *testing.T) {
+	swagger := nullableSpec()
+	g := New()
+
+	t.Run("should write x-nullable to the json output", func(t *testing.T) {
+		b, err := g.encodeJSON(swagger)
+		require.NoError(t, err)
+
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &out))
+		name := out["definitions"].(map[string]interface{})["Pet"].(map[string]interface{})["properties"].(map[string]interface{})["name"].(map[string]interface{})
+		assert.Equal(t, true, name["x-nullable"])
+		assert.NotContains(t, name, "nullable")
+		assert.Equal(t, "string", name["type"])
+	})
+
+	t.Run("should write null types to the jsonschema output without changing the spec", func(t *testing.T) {
+		b, err := g.encodeJSONSchema(swagger)
+		require.NoError(t, err)
+
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &out))
+		name := out["definitions"].(map[string]interface{})["Pet"].(map[string]interface{})["properties"].(map[string]interface{})["name"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"string", "null"}, name["type"])
+
+		assert.Equal(t, spec.StringOrArray{"string"}, swagger.Definitions["Pet"].Properties["name"].Type)
+	})
+}
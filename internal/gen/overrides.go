@@ -0,0 +1,146 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/griffnb/core-swag/internal/schemautil"
+	"github.com/griffnb/core-swag/internal/typeregistry"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultYAMLOverridesFile is the structured overrides file used when the default text file is missing.
+const DefaultYAMLOverridesFile = ".swaggo.yaml"
+
+// yamlOverrides is the structured form of the overrides file.
+type yamlOverrides struct {
+	// Replace maps a type to the type it should be documented as
+	Replace map[string]string `json:"replace"`
+	// Skip lists types that should not be documented
+	Skip []string `json:"skip"`
+	// Primitives maps a type to the OpenAPI type and format it should be documented as
+	Primitives map[string]primitiveOverride `json:"primitives"`
+	// PackageAliases maps a short alias to an import path, usable as a prefix in all other entries
+	PackageAliases map[string]string `json:"packageAliases"`
+}
+
+// primitiveOverride documents a type as an OpenAPI primitive.
+type primitiveOverride struct {
+	Type   string `json:"type"`
+	Format string `json:"format"`
+}
+
+// validPrimitiveTypes are the OpenAPI types a primitive override may use.
+var validPrimitiveTypes = map[string]bool{
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+}
+
+// isYAMLOverridesFile reports whether the overrides file uses the structured YAML format.
+func isYAMLOverridesFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// parseOverridesFile reads an overrides file in the format matching its extension:
+// YAML for .yaml/.yml files, the line-based replace/skip format otherwise.
+// Primitive overrides are only supported by the YAML format.
+func parseOverridesFile(filename string, r io.Reader) (map[string]string, map[string]typeregistry.TypeEntry, error) {
+	if !isYAMLOverridesFile(filename) {
+		overrides, err := parseOverrides(r)
+		return overrides, nil, err
+	}
+
+	return parseYAMLOverrides(r)
+}
+
+// parseYAMLOverrides reads and validates a structured overrides file.
+// Replace and skip entries are returned in the same form as the text format.
+func parseYAMLOverrides(r io.Reader) (map[string]string, map[string]typeregistry.TypeEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading overrides file: %w", err)
+	}
+
+	var config yamlOverrides
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, nil, fmt.Errorf("could not parse overrides file: %w", err)
+	}
+
+	for alias, pkgPath := range config.PackageAliases {
+		if strings.TrimSpace(pkgPath) == "" {
+			return nil, nil, overridesEntryError(data, alias, "package alias %q has no import path", alias)
+		}
+	}
+
+	overrides := make(map[string]string)
+
+	for _, name := range schemautil.SortedKeys(config.Replace) {
+		replacement := strings.TrimSpace(config.Replace[name])
+		if strings.TrimSpace(name) == "" || replacement == "" {
+			return nil, nil, overridesEntryError(data, name, "replace entry %q must map a type to a non-empty type", name)
+		}
+		overrides[config.expandAlias(name)] = config.expandAlias(replacement)
+	}
+
+	for _, name := range config.Skip {
+		if strings.TrimSpace(name) == "" {
+			return nil, nil, overridesEntryError(data, "skip", "skip entries must not be empty")
+		}
+		overrides[config.expandAlias(name)] = ""
+	}
+
+	primitives := make(map[string]typeregistry.TypeEntry)
+
+	for _, name := range schemautil.SortedKeys(config.Primitives) {
+		primitive := config.Primitives[name]
+		if !validPrimitiveTypes[primitive.Type] {
+			return nil, nil, overridesEntryError(data, name, "primitive %q has invalid type %q", name, primitive.Type)
+		}
+		primitives[config.expandAlias(name)] = typeregistry.TypeEntry{
+			SchemaType: primitive.Type,
+			Format:     primitive.Format,
+		}
+	}
+
+	return overrides, primitives, nil
+}
+
+// expandAlias replaces a leading package alias (e.g. "models.User") with its import path.
+func (c *yamlOverrides) expandAlias(typeName string) string {
+	typeName = strings.TrimSpace(typeName)
+
+	prefix := strings.TrimPrefix(typeName, "*")
+	alias, rest, found := strings.Cut(prefix, ".")
+	if !found {
+		return typeName
+	}
+
+	pkgPath, ok := c.PackageAliases[alias]
+	if !ok {
+		return typeName
+	}
+
+	return typeName[:len(typeName)-len(prefix)] + pkgPath + "." + rest
+}
+
+// overridesEntryError formats a validation error with the line of the offending entry, when it can be found.
+func overridesEntryError(data []byte, key, format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		trimmed := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(line)), "-"))
+		if strings.HasPrefix(trimmed, key+":") || strings.HasPrefix(trimmed, `"`+key+`":`) {
+			return fmt.Errorf("invalid overrides file: line %d: %s", i+1, msg)
+		}
+	}
+
+	return fmt.Errorf("invalid overrides file: %s", msg)
+}
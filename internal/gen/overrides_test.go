@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/griffnb/core-swag/internal/typeregistry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGen_parseYAMLOverrides(t *testing.T) {
+	t.Run("parses all sections", func(t *testing.T) {
+		data := `
+packageAliases:
+  models: github.com/foo/models
+replace:
+  models.Money: string
+  types.Field[string]: string
+skip:
+  - github.com/foo/bar.Internal
+primitives:
+  models.ID:
+    type: string
+    format: uuid
+  "*big.Int":
+    type: integer
+`
+		overrides, primitives, err := parseYAMLOverrides(strings.NewReader(data))
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"github.com/foo/models.Money": "string",
+			"types.Field[string]":         "string",
+			"github.com/foo/bar.Internal": "",
+		}, overrides)
+
+		assert.Equal(t, map[string]typeregistry.TypeEntry{
+			"github.com/foo/models.ID": {SchemaType: "string", Format: "uuid"},
+			"*big.Int":                 {SchemaType: "integer"},
+		}, primitives)
+	})
+
+	t.Run("reports line of invalid primitive", func(t *testing.T) {
+		data := `replace:
+  foo: bar
+primitives:
+  models.ID:
+    type: uuid
+`
+		_, _, err := parseYAMLOverrides(strings.NewReader(data))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 4")
+		assert.Contains(t, err.Error(), `invalid type "uuid"`)
+	})
+
+	t.Run("reports line of empty replacement", func(t *testing.T) {
+		data := `replace:
+  foo: bar
+  baz: ""
+`
+		_, _, err := parseYAMLOverrides(strings.NewReader(data))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 3")
+	})
+
+	t.Run("rejects unknown sections", func(t *testing.T) {
+		_, _, err := parseYAMLOverrides(strings.NewReader("rename:\n  foo: bar\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("reports syntax errors with line", func(t *testing.T) {
+		_, _, err := parseYAMLOverrides(strings.NewReader("replace:\n  foo: bar\n  - baz\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+}
+
+func TestGen_parseOverridesFile(t *testing.T) {
+	t.Run("uses text format without yaml extension", func(t *testing.T) {
+		overrides, primitives, err := parseOverridesFile(".swaggo", strings.NewReader("replace foo bar"))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"foo": "bar"}, overrides)
+		assert.Nil(t, primitives)
+	})
+
+	t.Run("uses yaml format with yaml extension", func(t *testing.T) {
+		overrides, _, err := parseOverridesFile("overrides.yml", strings.NewReader("skip:\n  - foo\n"))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"foo": ""}, overrides)
+	})
+}
@@ -0,0 +1,81 @@
+package gen
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/griffnb/core-swag/internal/console"
+	"github.com/pkg/errors"
+)
+
+// Profile kinds supported by Config.Profile.
+const (
+	ProfileCPU = "cpu"
+	ProfileMem = "mem"
+)
+
+// startProfile starts the pprof profile configured in config.Profile and returns
+// a function that stops it and writes it to <output>/swagger.<kind>.pprof.
+// A CPU profile covers everything between the two calls, a heap profile is
+// taken when stopping. Returns a no-op when profiling is disabled.
+func startProfile(config *Config) (func() error, error) {
+	if config.Profile == "" {
+		return func() error { return nil }, nil
+	}
+	if config.Profile != ProfileCPU && config.Profile != ProfileMem {
+		return nil, fmt.Errorf("unknown profile %q, expected %s or %s", config.Profile, ProfileCPU, ProfileMem)
+	}
+
+	// nolint:gosec // This is not executing user-provided code, just writing files
+	if err := os.MkdirAll(config.OutputDir, os.ModePerm); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	filename := "swagger." + config.Profile + ".pprof"
+
+	if config.State != "" {
+		filename = config.State + "_" + filename
+	}
+
+	if config.InstanceName != "" && config.InstanceName != DefaultInstanceName {
+		filename = config.InstanceName + "_" + filename
+	}
+
+	profileFileName := path.Join(config.OutputDir, filename)
+
+	f, err := os.Create(profileFileName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if config.Profile == ProfileCPU {
+		if err := pprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return func() error {
+		if config.Profile == ProfileCPU {
+			pprof.StopCPUProfile()
+		} else {
+			// Collect garbage first so the profile reflects live memory
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				_ = f.Close()
+				return errors.WithStack(err)
+			}
+		}
+
+		if err := f.Close(); err != nil {
+			return errors.WithStack(err)
+		}
+
+		console.Logger.Debug("create %s at %+v", filename, profileFileName)
+
+		return nil
+	}, nil
+}
@@ -0,0 +1,213 @@
+package gen
+
+import (
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/schema"
+	"github.com/pkg/errors"
+)
+
+// sharedSpecDir is the output subdirectory of the spec holding only the general info
+// when the spec is split by tag.
+const sharedSpecDir = "_shared"
+
+// writeSplitSpecs writes one spec per operation tag to a subdirectory of the output
+// directory named after the tag, in every configured output type, plus a spec with
+// only the general info to the _shared subdirectory.
+func (g *Gen) writeSplitSpecs(config *Config, swagger *spec.Swagger) error {
+	specs := splitByTag(swagger)
+	specs[sharedSpecDir] = sharedSpec(swagger)
+
+	for dir, tagSpec := range specs {
+		tagConfig := *config
+		tagConfig.OutputDir = path.Join(config.OutputDir, dir)
+
+		// nolint:gosec // This is not executing user-provided code, just writing files
+		if err := os.MkdirAll(tagConfig.OutputDir, os.ModePerm); err != nil {
+			return errors.WithStack(err)
+		}
+
+		for _, outputType := range config.OutputTypes {
+			format, ok := g.outputTypeMap[strings.ToLower(strings.TrimSpace(outputType))]
+			if !ok {
+				continue
+			}
+			if err := g.writeOutput(&tagConfig, tagSpec, format); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// splitByTag returns a spec per operation tag, keyed by the tag with path separators
+// replaced. Each holds the operations carrying the tag and the shared parameters,
+// responses and definitions reachable from them; operations without tags are in none.
+// The general info is shared with the full spec, which is left unchanged.
+func splitByTag(swagger *spec.Swagger) map[string]*spec.Swagger {
+	specs := make(map[string]*spec.Swagger)
+	if swagger.Paths == nil {
+		return specs
+	}
+
+	for _, tag := range operationTags(swagger) {
+		tagSpec := *swagger
+		tagSpec.Paths = &spec.Paths{
+			VendorExtensible: swagger.Paths.VendorExtensible,
+			Paths:            make(map[string]spec.PathItem),
+		}
+
+		for route, pathItem := range swagger.Paths.Paths {
+			if tagItem, ok := pathItemForTag(pathItem, tag); ok {
+				tagSpec.Paths.Paths[route] = tagItem
+			}
+		}
+
+		tagSpec.Tags = nil
+		for _, t := range swagger.Tags {
+			if t.Name == tag {
+				tagSpec.Tags = append(tagSpec.Tags, t)
+			}
+		}
+
+		pruneSharedComponents(&tagSpec)
+
+		tagSpec.Definitions = make(spec.Definitions, len(swagger.Definitions))
+		for name, def := range swagger.Definitions {
+			tagSpec.Definitions[name] = def
+		}
+		schema.RemoveUnusedDefinitions(&tagSpec)
+
+		specs[strings.ReplaceAll(tag, "/", "_")] = &tagSpec
+	}
+
+	return specs
+}
+
+// pruneSharedComponents replaces the shared parameters and responses of the spec with
+// new maps holding only those its paths $ref, directly or through another shared response.
+func pruneSharedComponents(swagger *spec.Swagger) {
+	params := make(map[string]bool)
+	responses := make(map[string]bool)
+
+	var useResponse func(resp *spec.Response)
+	useResponse = func(resp *spec.Response) {
+		name, ok := strings.CutPrefix(resp.Ref.String(), "#/responses/")
+		if !ok || responses[name] {
+			return
+		}
+		responses[name] = true
+		if shared, ok := swagger.Responses[name]; ok {
+			useResponse(&shared)
+		}
+	}
+	useParams := func(parameters []spec.Parameter) {
+		for _, param := range parameters {
+			if name, ok := strings.CutPrefix(param.Ref.String(), "#/parameters/"); ok {
+				params[name] = true
+			}
+		}
+	}
+
+	for _, pathItem := range swagger.Paths.Paths {
+		useParams(pathItem.Parameters)
+		for _, op := range schema.PathItemOperations(pathItem) {
+			useParams(op.Parameters)
+			if op.Responses == nil {
+				continue
+			}
+			if op.Responses.Default != nil {
+				useResponse(op.Responses.Default)
+			}
+			for _, resp := range op.Responses.StatusCodeResponses {
+				useResponse(&resp)
+			}
+		}
+	}
+
+	var sharedParams map[string]spec.Parameter
+	for name, param := range swagger.Parameters {
+		if params[name] {
+			if sharedParams == nil {
+				sharedParams = make(map[string]spec.Parameter)
+			}
+			sharedParams[name] = param
+		}
+	}
+	swagger.Parameters = sharedParams
+
+	var sharedResponses map[string]spec.Response
+	for name, resp := range swagger.Responses {
+		if responses[name] {
+			if sharedResponses == nil {
+				sharedResponses = make(map[string]spec.Response)
+			}
+			sharedResponses[name] = resp
+		}
+	}
+	swagger.Responses = sharedResponses
+}
+
+// sharedSpec returns a copy of the spec with its general info only: no operations
+// and no definitions.
+func sharedSpec(swagger *spec.Swagger) *spec.Swagger {
+	shared := *swagger
+	shared.Paths = &spec.Paths{Paths: map[string]spec.PathItem{}}
+	shared.Definitions = nil
+
+	return &shared
+}
+
+// operationTags returns the sorted tags of all operations of the spec.
+func operationTags(swagger *spec.Swagger) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, pathItem := range swagger.Paths.Paths {
+		for _, op := range schema.PathItemOperations(pathItem) {
+			for _, tag := range op.Tags {
+				if !seen[tag] {
+					seen[tag] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+	sort.Strings(tags)
+
+	return tags
+}
+
+// pathItemForTag returns a copy of the path item with only the operations carrying
+// the tag. Reports false when there are none.
+func pathItemForTag(pathItem spec.PathItem, tag string) (spec.PathItem, bool) {
+	hasTag := func(op *spec.Operation) *spec.Operation {
+		if op == nil {
+			return nil
+		}
+		for _, t := range op.Tags {
+			if t == tag {
+				return op
+			}
+		}
+		return nil
+	}
+
+	tagItem := pathItem
+	tagItem.Get = hasTag(pathItem.Get)
+	tagItem.Put = hasTag(pathItem.Put)
+	tagItem.Post = hasTag(pathItem.Post)
+	tagItem.Delete = hasTag(pathItem.Delete)
+	tagItem.Options = hasTag(pathItem.Options)
+	tagItem.Head = hasTag(pathItem.Head)
+	tagItem.Patch = hasTag(pathItem.Patch)
+	if trace := schema.TraceOperation(pathItem); trace != nil {
+		schema.SetTraceOperation(&tagItem, hasTag(trace))
+	}
+
+	return tagItem, len(schema.PathItemOperations(tagItem)) > 0
+}
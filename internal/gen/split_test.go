@@ -0,0 +1,90 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/schemautil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitByTag_SharedComponents(t *testing.T) {
+	objectRef := func(name string) *spec.Schema {
+		return spec.RefSchema("#/definitions/" + name)
+	}
+	taggedGet := func(tag string, params []spec.Parameter, responses map[int]spec.Response) *spec.Operation {
+		return &spec.Operation{
+			OperationProps: spec.OperationProps{
+				Tags:       []string{tag},
+				Parameters: params,
+				Responses: &spec.Responses{
+					ResponsesProps: spec.ResponsesProps{StatusCodeResponses: responses},
+				},
+			},
+		}
+	}
+
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Parameters: map[string]spec.Parameter{
+				"PageParam":  *spec.QueryParam("page").Typed("integer", ""),
+				"StoreParam": *spec.QueryParam("store").Typed("string", ""),
+			},
+			Responses: map[string]spec.Response{
+				"PetNotFound":   *spec.NewResponse().WithDescription("No pet").WithSchema(objectRef("api.PetError")),
+				"StoreNotFound": *spec.NewResponse().WithDescription("No store").WithSchema(objectRef("api.StoreError")),
+				"StoreGone":     *spec.ResponseRef("#/responses/StoreNotFound"),
+			},
+			Definitions: spec.Definitions{
+				"api.Pet":        *spec.StringProperty(),
+				"api.PetError":   *spec.StringProperty(),
+				"api.Store":      *spec.StringProperty(),
+				"api.StoreError": *spec.StringProperty(),
+			},
+			Paths: &spec.Paths{
+				Paths: map[string]spec.PathItem{
+					"/pets": {
+						PathItemProps: spec.PathItemProps{
+							Get: taggedGet("pets",
+								[]spec.Parameter{*spec.ParamRef("#/parameters/PageParam")},
+								map[int]spec.Response{
+									200: *spec.NewResponse().WithDescription("OK").WithSchema(objectRef("api.Pet")),
+									404: *spec.ResponseRef("#/responses/PetNotFound"),
+								}),
+						},
+					},
+					"/stores": {
+						PathItemProps: spec.PathItemProps{
+							Parameters: []spec.Parameter{*spec.ParamRef("#/parameters/StoreParam")},
+							Get: taggedGet("stores", nil, map[int]spec.Response{
+								200: *spec.NewResponse().WithDescription("OK").WithSchema(objectRef("api.Store")),
+								410: *spec.ResponseRef("#/responses/StoreGone"),
+							}),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	specs := splitByTag(swagger)
+	require.Contains(t, specs, "pets")
+	require.Contains(t, specs, "stores")
+
+	pets := specs["pets"]
+	assert.Equal(t, []string{"PageParam"}, schemautil.SortedKeys(pets.Parameters))
+	assert.Equal(t, []string{"PetNotFound"}, schemautil.SortedKeys(pets.Responses))
+	assert.Equal(t, []string{"api.Pet", "api.PetError"}, schemautil.SortedKeys(pets.Definitions))
+
+	// StoreGone refers to StoreNotFound, which keeps it and its definition
+	stores := specs["stores"]
+	assert.Equal(t, []string{"StoreParam"}, schemautil.SortedKeys(stores.Parameters))
+	assert.Equal(t, []string{"StoreGone", "StoreNotFound"}, schemautil.SortedKeys(stores.Responses))
+	assert.Equal(t, []string{"api.Store", "api.StoreError"}, schemautil.SortedKeys(stores.Definitions))
+
+	// The full spec is left unchanged
+	assert.Len(t, swagger.Parameters, 2)
+	assert.Len(t, swagger.Responses, 3)
+	assert.Len(t, swagger.Definitions, 4)
+}
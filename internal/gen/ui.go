@@ -0,0 +1,105 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/console"
+	"github.com/pkg/errors"
+)
+
+// DefaultUIBundleURL is the pinned swagger-ui-dist release the generated index.html
+// loads its script and stylesheet from when Config.UIBundleURL is empty.
+const DefaultUIBundleURL = "https://unpkg.com/swagger-ui-dist@5.17.14"
+
+var uiTemplate = template.Must(template.New("index.html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="{{.BundleURL}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{.BundleURL}}/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({
+        url: {{.SpecURL}},
+        dom_id: "#swagger-ui",
+      });
+    };
+  </script>
+</body>
+</html>
+`))
+
+// writeUI writes index.html, a static Swagger UI page for the generated spec, to the
+// output directory. The page loads the json output, or the yaml one when json is not
+// generated, from the same directory.
+func (g *Gen) writeUI(config *Config, swagger *spec.Swagger) error {
+	specFile := ""
+	for _, outputType := range config.OutputTypes {
+		switch strings.ToLower(strings.TrimSpace(outputType)) {
+		case "json":
+			specFile = config.OutputFileName + ".json"
+		case "yaml", "yml":
+			if specFile == "" {
+				specFile = config.OutputFileName + ".yaml"
+			}
+		}
+	}
+	if specFile == "" {
+		return fmt.Errorf("the Swagger UI needs the json or yaml output type, got %s", strings.Join(config.OutputTypes, ","))
+	}
+
+	bundleURL := config.UIBundleURL
+	if bundleURL == "" {
+		bundleURL = DefaultUIBundleURL
+	}
+
+	title := "Swagger UI"
+	if swagger.Info != nil && swagger.Info.Title != "" {
+		title = swagger.Info.Title
+	}
+
+	filename := "index.html"
+
+	if config.State != "" {
+		filename = config.State + "_" + filename
+		specFile = config.State + "_" + specFile
+	}
+
+	if config.InstanceName != DefaultInstanceName {
+		filename = config.InstanceName + "_" + filename
+		specFile = config.InstanceName + "_" + specFile
+	}
+
+	var b bytes.Buffer
+	err := uiTemplate.Execute(&b, struct {
+		Title     string
+		BundleURL string
+		SpecURL   string
+	}{
+		Title:     title,
+		BundleURL: strings.TrimSuffix(bundleURL, "/"),
+		SpecURL:   "./" + specFile,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	uiFileName := path.Join(config.OutputDir, filename)
+	if err := g.writeFile(b.Bytes(), uiFileName); err != nil {
+		return err
+	}
+
+	console.Logger.Debug("create %s at %+v", filename, uiFileName)
+
+	return nil
+}
@@ -30,7 +30,7 @@ func (s *Service) LoadDependencies(dirs []string, maxDepth int) (*LoadResult, er
 
 // loadDependenciesWithGoList uses go list to load dependencies
 func (s *Service) loadDependenciesWithGoList(dirs []string, result *LoadResult) (*LoadResult, error) {
-	pkgs, err := listPackages(context.Background(), dirs, nil, "-deps")
+	pkgs, err := listPackages(context.Background(), dirs, nil, append([]string{"-deps"}, s.buildFlags()...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +145,9 @@ func (s *Service) loadFromDepth(pkg *depth.Pkg, parseFlag ParseFlag, dirImported
 		}
 
 		path := filepath.Join(srcDir, f.Name())
+		if !s.matchBuildTags(path) {
+			continue
+		}
 		if err := s.parseFile(pkg.Name, path, nil, parseFlag, result); err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package loader
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"os"
@@ -39,18 +40,35 @@ func (s *Service) LoadWithGoPackages(searchDirs []string, absMainAPIFilePath str
 
 	fset := token.NewFileSet()
 	pkgs, err := packages.Load(&packages.Config{
-		Mode: mode,
-		Fset: fset,
-		Dir:  workDir,
+		Mode:       mode,
+		Fset:       fset,
+		Dir:        workDir,
+		BuildFlags: s.buildFlags(),
 	}, absDirs...)
 	if err != nil {
 		return nil, err
 	}
 
+	return s.LoadFromPackages(pkgs)
+}
+
+// LoadFromPackages builds the load result from packages the caller already loaded
+// with go/packages, in a single packages.Load call so they share one FileSet.
+// The packages must be loaded with at least NeedName, NeedFiles, NeedCompiledGoFiles,
+// NeedImports, NeedTypes, NeedSyntax and NeedTypesInfo, and NeedDeps when
+// dependencies are parsed.
+func (s *Service) LoadFromPackages(pkgs []*packages.Package) (*LoadResult, error) {
+	var fset *token.FileSet
 	for _, pkg := range pkgs {
 		for _, e := range pkg.Errors {
 			return nil, e
 		}
+		if fset == nil {
+			fset = pkg.Fset
+		}
+	}
+	if fset == nil {
+		fset = token.NewFileSet()
 	}
 
 	result := &LoadResult{
@@ -58,7 +76,7 @@ func (s *Service) LoadWithGoPackages(searchDirs []string, absMainAPIFilePath str
 		Packages: pkgs,
 	}
 
-	err = s.walkPackages(pkgs, fset, result, pkgs)
+	err := s.walkPackages(pkgs, fset, result, pkgs)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +100,10 @@ func (s *Service) walkPackagesInternal(pkgs []*packages.Package, fset *token.Fil
 		}
 		pkgSeen[pkg.PkgPath] = struct{}{}
 
+		if len(pkg.Syntax) != len(pkg.CompiledGoFiles) {
+			return fmt.Errorf("package %s was loaded without syntax", pkg.PkgPath)
+		}
+
 		parseFlag := ParseFlag(ParseAll)
 		if !contains(rootPkgs, pkg) {
 			parseFlag = ParseFlag(s.parseDependency)
@@ -92,7 +114,7 @@ func (s *Service) walkPackagesInternal(pkgs []*packages.Package, fset *token.Fil
 			if err != nil {
 				return err
 			}
-			if s.shouldSkipDir(file, fileInfo) != nil {
+			if s.shouldSkipDir(file, fileInfo) != nil || s.isExcluded(file, "") {
 				continue
 			}
 
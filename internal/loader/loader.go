@@ -3,6 +3,7 @@ package loader
 import (
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/token"
 	"os"
 	"path/filepath"
@@ -53,10 +54,13 @@ func (s *Service) walkDirectory(packageDir, searchDir string, result *LoadResult
 		}
 
 		if f.IsDir() {
+			if s.isExcluded(path, searchDir) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
-		if s.shouldSkipFile(path) {
+		if s.shouldSkipFile(path) || s.isExcluded(path, searchDir) || !s.matchBuildTags(path) {
 			return nil
 		}
 
@@ -105,6 +109,26 @@ func (s *Service) shouldSkipFile(path string) bool {
 	return false
 }
 
+// buildFlags returns the go command flags selecting the build tags, if any.
+func (s *Service) buildFlags() []string {
+	if len(s.buildTags) == 0 {
+		return nil
+	}
+	return []string{"-tags=" + strings.Join(s.buildTags, ",")}
+}
+
+// matchBuildTags reports whether the build constraints of a file are satisfied
+// by the build tags. Without build tags every file matches.
+func (s *Service) matchBuildTags(path string) bool {
+	if len(s.buildTags) == 0 {
+		return true
+	}
+	ctx := build.Default
+	ctx.BuildTags = s.buildTags
+	match, err := ctx.MatchFile(filepath.Dir(path), filepath.Base(path))
+	return err != nil || match
+}
+
 // shouldSkipDir checks if a directory should be skipped
 func (s *Service) shouldSkipDir(path string, f os.FileInfo) error {
 	if !f.IsDir() {
@@ -121,13 +145,79 @@ func (s *Service) shouldSkipDir(path string, f os.FileInfo) error {
 		return filepath.SkipDir
 	}
 
-	if s.excludes != nil {
-		if _, ok := s.excludes[path]; ok {
-			return filepath.SkipDir
+	return nil
+}
+
+// globExcludePrefix marks an exclude entry as a filepath.Match pattern rather
+// than an exact path.
+const globExcludePrefix = "glob:"
+
+// isExcluded reports whether path matches an exclude entry. Plain entries are
+// exact paths, either absolute or relative to searchDir, as they have always been.
+// Entries prefixed with "glob:" are filepath.Match patterns: without a path
+// separator, like glob:*_gen.go or glob:testdata, they match the name of the file
+// or of any parent directory below searchDir; with one, like glob:api/*/fixtures,
+// they match the path relative to searchDir. searchDir may be empty when files
+// don't come from a directory walk, then only absolute paths and names match.
+func (s *Service) isExcluded(path, searchDir string) bool {
+	if len(s.excludes) == 0 {
+		return false
+	}
+
+	path = filepath.Clean(path)
+	for entry := range s.excludes {
+		if pattern, ok := strings.CutPrefix(entry, globExcludePrefix); ok {
+			if matchExcludeGlob(filepath.Clean(filepath.FromSlash(pattern)), path, searchDir) {
+				return true
+			}
+			continue
+		}
+
+		entry = filepath.Clean(entry)
+		if path == entry || (searchDir != "" && !filepath.IsAbs(entry) && path == filepath.Join(searchDir, entry)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchExcludeGlob reports whether path, or one of its parent directories below
+// searchDir, matches the glob pattern, see isExcluded.
+func matchExcludeGlob(pattern, path, searchDir string) bool {
+	if searchDir != "" && !isInsideDir(path, searchDir) {
+		return false
+	}
+
+	byName := !strings.ContainsRune(pattern, filepath.Separator)
+	for dir := path; ; dir = filepath.Dir(dir) {
+		candidate := dir
+		switch {
+		case byName:
+			candidate = filepath.Base(dir)
+		case !filepath.IsAbs(pattern) && searchDir != "":
+			rel, err := filepath.Rel(searchDir, dir)
+			if err != nil {
+				return false
+			}
+			candidate = rel
+		}
+		if match, _ := filepath.Match(pattern, candidate); match {
+			return true
+		}
+		if !isInsideDir(filepath.Dir(dir), searchDir) {
+			return false
 		}
 	}
+}
 
-	return nil
+// isInsideDir reports whether path is a directory below dir, not dir itself.
+func isInsideDir(path, dir string) bool {
+	if dir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
 }
 
 // skipPackageByPrefix checks if a package should be skipped based on prefix
@@ -35,7 +35,7 @@ func WithParseInternal(parse bool) Option {
 	}
 }
 
-// WithExcludes sets directory exclusion patterns
+// WithExcludes sets the file and directory exclusion patterns, see Service.isExcluded
 func WithExcludes(excludes map[string]struct{}) Option {
 	return func(s *Service) {
 		s.excludes = excludes
@@ -77,6 +77,13 @@ func WithParseDependency(flag ParseFlag) Option {
 	}
 }
 
+// WithBuildTags sets the build tags files are loaded with
+func WithBuildTags(tags []string) Option {
+	return func(s *Service) {
+		s.buildTags = tags
+	}
+}
+
 // WithDebugger sets the debugger for logging
 func WithDebugger(debugger Debugger) Option {
 	return func(s *Service) {
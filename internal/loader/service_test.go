@@ -4,6 +4,8 @@ import (
 	"go/ast"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 )
@@ -302,6 +304,128 @@ func TestExcludePatterns(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("excludes generated files by glob", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		files := []string{"api.go", "api_gen.go", "mock_store.go", "models/model.go", "models/model_gen.go", "mocks/store.go"}
+		for _, file := range files {
+			path := filepath.Join(tmpDir, file)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte("package api\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		service := NewService(WithExcludes(map[string]struct{}{
+			"glob:*_gen.go":  {},
+			"glob:mock_*.go": {},
+			"./mocks":        {},
+		}))
+
+		// Act
+		result, err := service.LoadSearchDirs([]string{tmpDir})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		var loaded []string
+		for _, info := range result.Files {
+			rel, err := filepath.Rel(tmpDir, info.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			loaded = append(loaded, filepath.ToSlash(rel))
+		}
+		sort.Strings(loaded)
+		if strings.Join(loaded, ",") != "api.go,models/model.go" {
+			t.Errorf("expected api.go and models/model.go to be loaded, got %v", loaded)
+		}
+	})
+
+	t.Run("matches plain entries as exact paths", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		service := NewService(WithExcludes(map[string]struct{}{
+			"mocks":                           {},
+			"./internal/fixtures":             {},
+			"api/api_gen.go":                  {},
+			filepath.Join(tmpDir, "absolute"): {},
+		}))
+
+		tests := []struct {
+			path string
+			want bool
+		}{
+			{filepath.Join(tmpDir, "mocks"), true},
+			{filepath.Join(tmpDir, "pkg", "mocks"), false},
+			{filepath.Join(tmpDir, "internal", "fixtures"), true},
+			{filepath.Join(tmpDir, "fixtures"), false},
+			{filepath.Join(tmpDir, "api", "api_gen.go"), true},
+			{filepath.Join(tmpDir, "api", "other_gen.go"), false},
+			{filepath.Join(tmpDir, "absolute"), true},
+		}
+
+		for _, tt := range tests {
+			// Act
+			got := service.isExcluded(tt.path, tmpDir)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("isExcluded(%s) = %v, want %v", tt.path, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("resolves plain entries against the search dir, not the working dir", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		searchDir := filepath.Join(tmpDir, "api")
+		t.Chdir(tmpDir)
+		service := NewService(WithExcludes(map[string]struct{}{
+			"mocks": {},
+		}))
+
+		// Act & Assert
+		if !service.isExcluded(filepath.Join(searchDir, "mocks"), searchDir) {
+			t.Error("expected mocks in the search dir to be excluded")
+		}
+		if service.isExcluded(filepath.Join(tmpDir, "mocks"), searchDir) {
+			t.Error("expected mocks in the working dir to be ignored")
+		}
+	})
+
+	t.Run("matches glob entries by name and path", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		service := NewService(WithExcludes(map[string]struct{}{
+			"glob:testdata":       {},
+			"glob:api/*/fixtures": {},
+		}))
+
+		tests := []struct {
+			path string
+			want bool
+		}{
+			{filepath.Join(tmpDir, "testdata"), true},
+			{filepath.Join(tmpDir, "pkg", "testdata", "file.go"), true},
+			{filepath.Join(tmpDir, "api", "v1", "fixtures", "file.go"), true},
+			{filepath.Join(tmpDir, "api", "fixtures", "file.go"), false},
+			{filepath.Join(tmpDir, "pkg", "file.go"), false},
+		}
+
+		for _, tt := range tests {
+			// Act
+			got := service.isExcluded(tt.path, tmpDir)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("isExcluded(%s) = %v, want %v", tt.path, got, tt.want)
+			}
+		}
+	})
 }
 
 // TestOptions tests service configuration options
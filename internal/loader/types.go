@@ -31,6 +31,7 @@ type Service struct {
 	useGoList       bool
 	useGoPackages   bool
 	parseDependency ParseFlag
+	buildTags       []string
 	debug           Debugger
 }
 
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/griffnb/core-swag/internal/console"
+	"golang.org/x/tools/go/packages"
+)
+
+// Warning categories reported while building schemas.
+const (
+	WarningUnresolvedPackage = "unresolved-package"
+	WarningUnresolvedType    = "unresolved-type"
+	WarningPackageLoad       = "package-load-failed"
+)
+
+// SetWarnings sets the collector the parser reports the warnings of schema
+// building to; nil only logs.
+func (c *CoreStructParser) SetWarnings(warnings *console.Diagnostics) {
+	c.warnings = warnings
+}
+
+// reportWarning records a warning without source position.
+func (c *CoreStructParser) reportWarning(category, format string, args ...any) {
+	c.warnings.Add(console.Diagnostic{
+		Category: category,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// loadPackage returns the package at pkgPath from the package cache, loading it
+// with the build tags of the parser if needed. Load failures are reported.
+func (c *CoreStructParser) loadPackage(pkgPath string) *packages.Package {
+	pkg, err := Cache().GetOrLoad(pkgPath, c.options.BuildTags)
+	if err != nil {
+		c.reportWarning(WarningPackageLoad, "%v", err)
+	}
+	return pkg
+}
@@ -140,7 +140,8 @@ func (p *ParserEnumLookup) GetEnumsForType(typeName string, file *ast.File) ([]E
 		cfg := &packages.Config{
 			Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
 				packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports,
-			Fset: p.getOrCreateFileSet(),
+			Fset:       p.getOrCreateFileSet(),
+			BuildFlags: BuildTagFlags(p.Parser.options.BuildTags),
 		}
 
 		pkgs, err := packages.Load(cfg, targetPkgPath)
@@ -164,9 +165,14 @@ func (p *ParserEnumLookup) GetEnumsForType(typeName string, file *ast.File) ([]E
 		p.cacheMutex.Unlock()
 	}
 
-	// Look for the type definition and collect const values
+	// The constants of a type may be declared in any file of its package, also
+	// before the file declaring the type
+	if !declaresType(pkg.Syntax, baseTypeName) {
+		return nil, fmt.Errorf("type %s not found", baseTypeName)
+	}
+
+	// Collect const values
 	var enums []EnumValue
-	var typeFound bool
 
 	for _, file := range pkg.Syntax {
 		for _, decl := range file.Decls {
@@ -175,19 +181,8 @@ func (p *ParserEnumLookup) GetEnumsForType(typeName string, file *ast.File) ([]E
 				continue
 			}
 
-			// First, find the type definition
-			if genDecl.Tok == token.TYPE {
-				for _, spec := range genDecl.Specs {
-					typeSpec, ok := spec.(*ast.TypeSpec)
-					if ok && typeSpec.Name.Name == baseTypeName {
-						typeFound = true
-						break
-					}
-				}
-			}
-
 			// Collect constants of this type
-			if genDecl.Tok == token.CONST && typeFound {
+			if genDecl.Tok == token.CONST {
 				for _, spec := range genDecl.Specs {
 					valueSpec, ok := spec.(*ast.ValueSpec)
 					if !ok {
@@ -196,6 +191,10 @@ func (p *ParserEnumLookup) GetEnumsForType(typeName string, file *ast.File) ([]E
 
 					// Check each name in the value spec
 					for _, name := range valueSpec.Names {
+						// Blank constants only advance iota
+						if name.Name == "_" {
+							continue
+						}
 						// Use TypesInfo to determine the actual type of the constant
 						// This handles both explicit types and iota expressions
 						if pkg.TypesInfo != nil {
@@ -250,10 +249,6 @@ func (p *ParserEnumLookup) GetEnumsForType(typeName string, file *ast.File) ([]E
 		}
 	}
 
-	if !typeFound {
-		return nil, fmt.Errorf("type %s not found", baseTypeName)
-	}
-
 	dedupedEnums := make([]EnumValue, 0, len(enums))
 	seenValues := make(map[interface{}]bool)
 	for _, enum := range enums {
@@ -266,3 +261,22 @@ func (p *ParserEnumLookup) GetEnumsForType(typeName string, file *ast.File) ([]E
 	return dedupedEnums, nil
 }
 
+
+// declaresType reports whether one of the files declares a top-level type named typeName.
+func declaresType(files []*ast.File, typeName string) bool {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == typeName {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"github.com/griffnb/core-swag/internal/schemautil"
+	"github.com/griffnb/core-swag/internal/typeregistry"
+)
+
+// SchemaOptions holds generation options that affect how individual field
+// schemas are built. The orchestrator sets them on its CoreStructParser, which
+// passes them down to every schema it builds. The zero value reproduces the
+// default output.
+type SchemaOptions struct {
+	// RequiredByDefault also marks omitempty fields with a required validate or binding
+	// rule as required. Fields without omitempty are required either way.
+	RequiredByDefault bool
+
+	// EmitNullable marks any/interface{} and pointer-to-interface fields as nullable.
+	// Pointers to slices (*[]T) and pointer slice elements ([]*T) are always nullable.
+	// The schema's Nullable field is a version-neutral marker the writers translate,
+	// e.g. to x-nullable for Swagger 2.0.
+	EmitNullable bool
+
+	// PointersOptional leaves pointer fields (*T) out of the required list, since nil
+	// is a valid value, even without omitempty or in @AllRequired structs.
+	PointersOptional bool
+
+	// InlineEnums writes enum values, varnames and descriptions onto the
+	// property schema instead of referencing a shared enum definition.
+	InlineEnums bool
+
+	// PropNamingStrategy names exported fields without a json tag, or whose json tag
+	// has no name (e.g. json:",omitempty"): "camelcase", "snakecase" or "pascalcase".
+	// Empty keeps the Go field name, as encoding/json does.
+	PropNamingStrategy string
+
+	// EmitFieldOrder adds an x-order extension with the declaration index of the field
+	// to every property. Fields of embedded structs are numbered where they are embedded,
+	// so Public variants keep the indexes of the full schema.
+	EmitFieldOrder bool
+
+	// EmitOmitEmpty adds an x-omitempty extension to properties whose json tag has
+	// omitempty, so client generators know zero values are left out.
+	EmitOmitEmpty bool
+
+	// State is the host state being generated. Fields annotated with @Only are
+	// only emitted for the states they list.
+	State string
+
+	// StrictEnums fails schema building with ErrEmptyEnum when a field declared as an
+	// enum (IntConstantField[T], StringConstantField[T]) has no enum values, e.g. because
+	// its constants live in a package that was not parsed, instead of using the base type.
+	StrictEnums bool
+
+	// MaxTypeDepth bounds how deeply nested and embedded types are resolved, so a
+	// pathological type graph fails with ErrTypeDepthExceeded instead of exhausting
+	// the stack. Zero uses DefaultMaxTypeDepth.
+	MaxTypeDepth int
+
+	// BuildTags are the build tags packages are loaded with, so types declared in
+	// files behind build constraints resolve.
+	BuildTags []string
+
+	// Types maps extended primitives such as time.Duration or the primitives of an
+	// overrides file to their schemas. Nil uses the built-in mappings.
+	Types *typeregistry.Registry
+}
+
+// DefaultMaxTypeDepth is the type resolution depth used when SchemaOptions.MaxTypeDepth is unset.
+const DefaultMaxTypeDepth = 100
+
+// maxTypeDepth returns the configured type resolution depth.
+func (o SchemaOptions) maxTypeDepth() int {
+	if o.MaxTypeDepth > 0 {
+		return o.MaxTypeDepth
+	}
+	return DefaultMaxTypeDepth
+}
+
+// propertyName returns the property name for a Go field whose json tag has no
+// name, following the configured naming strategy.
+func (o SchemaOptions) propertyName(fieldName string) string {
+	return schemautil.PropertyName(fieldName, o.PropNamingStrategy)
+}
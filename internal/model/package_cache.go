@@ -23,6 +23,14 @@ type PackageCache struct {
 	misses    int64
 }
 
+// BuildTagFlags returns the go command flags selecting the build tags, if any.
+func BuildTagFlags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	return []string{"-tags=" + strings.Join(tags, ",")}
+}
+
 // singleton holds the process-wide PackageCache instance.
 var (
 	cacheOnce     sync.Once
@@ -42,16 +50,16 @@ func Cache() *PackageCache {
 // GetOrLoad returns the cached *packages.Package for pkgPath if it has Syntax.
 // Otherwise it loads the package (and its transitive deps) via packages.Load,
 // caches everything, and returns the result. Concurrent calls for the same
-// pkgPath are deduplicated by singleflight. Returns nil if the package cannot
-// be loaded.
-func (pc *PackageCache) GetOrLoad(pkgPath string) *packages.Package {
+// pkgPath are deduplicated by singleflight. Packages are loaded with buildTags.
+// Returns an error if the package cannot be loaded.
+func (pc *PackageCache) GetOrLoad(pkgPath string, buildTags []string) (*packages.Package, error) {
 	pc.mu.RLock()
 	pkg, ok := pc.packages[pkgPath]
 	pc.mu.RUnlock()
 
 	if ok && len(pkg.Syntax) > 0 {
 		atomic.AddInt64(&pc.hits, 1)
-		return pkg
+		return pkg, nil
 	}
 
 	atomic.AddInt64(&pc.misses, 1)
@@ -60,7 +68,8 @@ func (pc *PackageCache) GetOrLoad(pkgPath string) *packages.Package {
 		cfg := &packages.Config{
 			Mode: packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo |
 				packages.NeedName | packages.NeedImports | packages.NeedDeps,
-			Fset: token.NewFileSet(),
+			Fset:       token.NewFileSet(),
+			BuildFlags: BuildTagFlags(buildTags),
 		}
 
 		loadPath := pkgPath
@@ -93,13 +102,12 @@ func (pc *PackageCache) GetOrLoad(pkgPath string) *packages.Package {
 		// can't be individually reloaded (e.g., relative module paths).
 		if ok {
 			console.Logger.Debug("PackageCache: LOAD FAILED for %s, returning cached entry (no Syntax): %v\n", pkgPath, err)
-			return pkg
+			return pkg, nil
 		}
-		console.Logger.Debug("PackageCache: LOAD FAILED for %s: %v\n", pkgPath, err)
-		return nil
+		return nil, fmt.Errorf("could not load package %s: %v", pkgPath, err)
 	}
 
-	return val.(*packages.Package)
+	return val.(*packages.Package), nil
 }
 
 // Seed bulk-populates the cache from a batch of packages. Direct packages
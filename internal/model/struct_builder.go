@@ -7,23 +7,38 @@ import (
 	"github.com/go-openapi/spec"
 )
 
+// fieldOrderExtension holds the declaration index of a property, see SchemaOptions.EmitFieldOrder.
+const fieldOrderExtension = "x-order"
+
+// patternPropertiesExtension maps the key pattern of a map property, set by the keypattern
+// struct tag, to the schema of its values. It shares the value schema with additionalProperties
+// so ref rewrites apply to both.
+const patternPropertiesExtension = "x-pattern-properties"
+
 type StructBuilder struct {
-	Fields []*StructField `json:"fields"` // For nested structs
+	Fields      []*StructField `json:"fields"`       // For nested structs
+	AllRequired bool           `json:"all_required"` // Set by the @AllRequired annotation on the struct
+	Description string         `json:"description"`  // Doc comment of the struct, without annotation lines
 }
 
 // BuildSpecSchema builds an OpenAPI spec.Schema for the struct
 // Returns the schema, a list of nested struct type names, and any error
-// forceRequired: if true, all fields are marked as required regardless of omitempty tags
+// opts are the schema options of the build. Fields without omitempty are required,
+// fields with omitempty only under RequiredByDefault when a rule requires them, or
+// when the struct is annotated with @AllRequired.
+// Requiredness doesn't depend on public: a field kept in the Public variant is
+// required there exactly when it is required in the base schema.
 func (this *StructBuilder) BuildSpecSchema(
 	typeName string,
 	public bool,
-	forceRequired bool,
+	opts SchemaOptions,
 	enumLookup TypeEnumLookup,
 ) (*spec.Schema, []string, error) {
 	schema := &spec.Schema{
 		SchemaProps: spec.SchemaProps{
-			Type:       []string{"object"},
-			Properties: make(map[string]spec.Schema),
+			Type:        []string{"object"},
+			Description: this.Description,
+			Properties:  make(map[string]spec.Schema),
 		},
 	}
 
@@ -34,8 +49,8 @@ func (this *StructBuilder) BuildSpecSchema(
 	// public:"view" or public:"edit" tags are included. If no fields have
 	// public tags, the result is an empty object schema.
 
-	for _, field := range this.Fields {
-		propName, propSchema, isRequired, nestedTypes, err := field.ToSpecSchema(public, forceRequired, enumLookup)
+	for i, field := range this.Fields {
+		propName, propSchema, isRequired, nestedTypes, err := field.ToSpecSchema(public, opts, enumLookup)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to build schema for field %s: %w", field.Name, err)
 		}
@@ -45,12 +60,29 @@ func (this *StructBuilder) BuildSpecSchema(
 			continue
 		}
 
+		property := *propSchema
+		if opts.EmitFieldOrder {
+			// A property declared twice keeps the position of its first declaration
+			order := i
+			if existing, ok := schema.Properties[propName]; ok {
+				order, _ = existing.Extensions[fieldOrderExtension].(int)
+			}
+			// Copy the extensions, the field schema may be shared
+			extensions := make(spec.Extensions, len(property.Extensions)+1)
+			for key, value := range property.Extensions {
+				extensions[key] = value
+			}
+			extensions[fieldOrderExtension] = order
+			property.Extensions = extensions
+		}
+
 		// Add property to schema
-		schema.Properties[propName] = *propSchema
+		schema.Properties[propName] = property
 
 		// Add to required list if needed
-		// When forceRequired is true, all fields are required
-		if forceRequired || isRequired {
+		// @AllRequired structs mark every field required, including omitempty ones,
+		// unless pointers are optional: nil is a valid value for them
+		if (this.AllRequired || isRequired) && !(opts.PointersOptional && field.IsPointer()) {
 			required = append(required, propName)
 		}
 
@@ -138,7 +138,7 @@ func TestBuildSpecSchema_Simple(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("User", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("User", false, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, schema)
 	assert.Equal(t, 1, len(schema.Type))
@@ -160,6 +160,21 @@ func TestBuildSpecSchema_Simple(t *testing.T) {
 	assert.Equal(t, 0, len(nestedTypes))
 }
 
+func TestBuildSpecSchema_Description(t *testing.T) {
+	builder := &StructBuilder{
+		Description: "Account represents a user account.",
+		Fields: []*StructField{
+			{Name: "Name", TypeString: "string", Tag: `public:"view" json:"name"`},
+		},
+	}
+
+	for _, public := range []bool{false, true} {
+		schema, _, err := builder.BuildSpecSchema("Account", public, SchemaOptions{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Account represents a user account.", schema.Description)
+	}
+}
+
 func TestBuildSpecSchema_WithNestedStruct(t *testing.T) {
 	builder := &StructBuilder{
 		Fields: []*StructField{
@@ -176,7 +191,7 @@ func TestBuildSpecSchema_WithNestedStruct(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("User", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("User", false, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, schema)
 	assert.Equal(t, 2, len(schema.Properties))
@@ -206,7 +221,7 @@ func TestBuildSpecSchema_PublicMode(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("User", true, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("User", true, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, schema)
 
@@ -240,7 +255,7 @@ func TestBuildSpecSchema_PublicModeWithNestedStruct(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("User", true, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("User", true, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, schema)
 
@@ -281,7 +296,7 @@ func TestBuildSpecSchema_MultipleNestedStructs(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("Contact", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("Contact", false, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, schema)
 
@@ -313,7 +328,7 @@ func TestBuildSpecSchema_ArrayOfStructs(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("Order", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("Order", false, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, schema)
 	assert.Equal(t, 2, len(schema.Properties))
@@ -336,7 +351,7 @@ func TestBuildSpecSchema_EmptyBuilder(t *testing.T) {
 		Fields: []*StructField{},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("Empty", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("Empty", false, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, schema)
 	assert.Equal(t, 1, len(schema.Type))
@@ -368,7 +383,7 @@ func TestExtendedPrimitives_TimeTime(t *testing.T) {
 		},
 	}
 
-	schema, _, err := builder.BuildSpecSchema("TimestampModel", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("TimestampModel", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	assertSchema(t, schema).
@@ -397,7 +412,7 @@ func TestExtendedPrimitives_UUID(t *testing.T) {
 		},
 	}
 
-	schema, _, err := builder.BuildSpecSchema("UUIDModel", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("UUIDModel", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	assertSchema(t, schema).
@@ -426,7 +441,7 @@ func TestExtendedPrimitives_Decimal(t *testing.T) {
 		},
 	}
 
-	schema, _, err := builder.BuildSpecSchema("PriceModel", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("PriceModel", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	assertSchema(t, schema).
@@ -448,7 +463,7 @@ func TestExtendedPrimitives_RawMessage(t *testing.T) {
 		},
 	}
 
-	schema, _, err := builder.BuildSpecSchema("MetadataModel", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("MetadataModel", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	assertSchema(t, schema).
@@ -475,7 +490,7 @@ func TestEnumDetection_StringEnum(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("StatusModel", false, false, enumLookup)
+	schema, nestedTypes, err := builder.BuildSpecSchema("StatusModel", false, SchemaOptions{}, enumLookup)
 	require.NoError(t, err)
 
 	// Enum field should create a $ref to the definition
@@ -503,7 +518,7 @@ func TestEnumDetection_IntEnum(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("PriorityModel", false, false, enumLookup)
+	schema, nestedTypes, err := builder.BuildSpecSchema("PriorityModel", false, SchemaOptions{}, enumLookup)
 	require.NoError(t, err)
 
 	// Enum field should create a $ref to the definition
@@ -529,7 +544,7 @@ func TestGenericExtraction_StructFieldPrimitives(t *testing.T) {
 		},
 	}
 
-	schema, _, err := builder.BuildSpecSchema("GenericModel", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("GenericModel", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	// Should extract inner type (string, int) not StructField
@@ -552,7 +567,7 @@ func TestGenericExtraction_StructFieldPointers(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("ModelWithProps", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("ModelWithProps", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	// Should create reference to Properties (not StructField)
@@ -575,7 +590,7 @@ func TestGenericExtraction_StructFieldArrays(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("CollectionModel", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("CollectionModel", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	// Should create array of Item references
@@ -599,7 +614,7 @@ func TestGenericExtraction_StructFieldMaps(t *testing.T) {
 		},
 	}
 
-	schema, _, err := builder.BuildSpecSchema("MapModel", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("MapModel", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	// Should create object with additionalProperties
@@ -633,7 +648,7 @@ func TestEmbeddedFields_SingleLevel(t *testing.T) {
 		},
 	}
 
-	schema, _, err := builder.BuildSpecSchema("Account", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("Account", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	// All fields should be at top level (embedded fields merged)
@@ -661,7 +676,7 @@ func TestValidation_Required(t *testing.T) {
 		},
 	}
 
-	schema, _, err := builder.BuildSpecSchema("ValidationModel", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("ValidationModel", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	// Email should be required (validate:"required")
@@ -688,7 +703,7 @@ func TestValidation_MinMax(t *testing.T) {
 		},
 	}
 
-	schema, _, err := builder.BuildSpecSchema("ScoreModel", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("ScoreModel", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	assertSchema(t, schema).
@@ -705,6 +720,289 @@ func TestValidation_MinMax(t *testing.T) {
 	}
 }
 
+// TestValidation_StringRules tests len, format and regexp validator rules
+func TestValidation_StringRules(t *testing.T) {
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{Name: "Code", TypeString: "string", Tag: `json:"code" validate:"required,len=10"`},
+			{Name: "Username", TypeString: "string", Tag: `json:"username" validate:"min=3,max=32,alphanum"`},
+			{Name: "Email", TypeString: "string", Tag: `json:"email" validate:"required,email"`},
+			{Name: "Website", TypeString: "string", Tag: `json:"website" binding:"url"`},
+			{Name: "ExternalID", TypeString: "string", Tag: `json:"external_id" validate:"uuid4"`},
+			{Name: "Slug", TypeString: "string", Tag: `json:"slug" validate:"regexp=^[a-z0-9-]+$"`},
+			{Name: "Zip", TypeString: "string", Tag: `json:"zip" pattern:"^[0-9]{5}$"`},
+			{Name: "Contact", TypeString: "string", Tag: `json:"contact" validate:"email|url"`},
+			{Name: "Count", TypeString: "int", Tag: `json:"count" validate:"len=3"`},
+		},
+	}
+
+	schema, _, err := builder.BuildSpecSchema("ValidationModel", false, SchemaOptions{}, nil)
+	require.NoError(t, err)
+
+	code := schema.Properties["code"]
+	require.NotNil(t, code.MinLength)
+	require.NotNil(t, code.MaxLength)
+	assert.Equal(t, int64(10), *code.MinLength)
+	assert.Equal(t, int64(10), *code.MaxLength)
+
+	username := schema.Properties["username"]
+	require.NotNil(t, username.MinLength)
+	require.NotNil(t, username.MaxLength)
+	assert.Equal(t, int64(3), *username.MinLength)
+	assert.Equal(t, int64(32), *username.MaxLength)
+	assert.Empty(t, username.Format, "unknown validators should be ignored")
+
+	assertSchema(t, schema).
+		propertyFormat("email", "email").
+		propertyFormat("website", "uri").
+		propertyFormat("external_id", "uuid").
+		propertyFormat("contact", "")
+
+	assert.Equal(t, "^[a-z0-9-]+$", schema.Properties["slug"].Pattern)
+	assert.Equal(t, "^[0-9]{5}$", schema.Properties["zip"].Pattern)
+
+	count := schema.Properties["count"]
+	require.NotNil(t, count.Minimum)
+	require.NotNil(t, count.Maximum)
+	assert.Equal(t, float64(3), *count.Minimum)
+	assert.Equal(t, float64(3), *count.Maximum)
+	assert.Nil(t, count.MinLength)
+}
+
+// TestValidation_ExclusiveBounds tests strict gt/lt and inclusive gte/lte validators
+func TestValidation_ExclusiveBounds(t *testing.T) {
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{Name: "Ratio", TypeString: "float64", Tag: `json:"ratio" validate:"gt=0,lt=100"`},
+			{Name: "Percent", TypeString: "int", Tag: `json:"percent" validate:"gte=0,lte=100"`},
+			{Name: "Nickname", TypeString: "string", Tag: `json:"nickname" validate:"gt=2,lt=20"`},
+		},
+	}
+
+	schema, _, err := builder.BuildSpecSchema("BoundsModel", false, SchemaOptions{}, nil)
+	require.NoError(t, err)
+
+	ratio := schema.Properties["ratio"]
+	require.NotNil(t, ratio.Minimum)
+	require.NotNil(t, ratio.Maximum)
+	assert.Equal(t, float64(0), *ratio.Minimum)
+	assert.Equal(t, float64(100), *ratio.Maximum)
+	assert.True(t, ratio.ExclusiveMinimum)
+	assert.True(t, ratio.ExclusiveMaximum)
+
+	percent := schema.Properties["percent"]
+	require.NotNil(t, percent.Minimum)
+	require.NotNil(t, percent.Maximum)
+	assert.Equal(t, float64(0), *percent.Minimum)
+	assert.Equal(t, float64(100), *percent.Maximum)
+	assert.False(t, percent.ExclusiveMinimum)
+	assert.False(t, percent.ExclusiveMaximum)
+
+	nickname := schema.Properties["nickname"]
+	require.NotNil(t, nickname.MinLength)
+	require.NotNil(t, nickname.MaxLength)
+	assert.Equal(t, int64(3), *nickname.MinLength)
+	assert.Equal(t, int64(19), *nickname.MaxLength)
+}
+
+// TestValidation_MultipleOf tests the multipleof validator on numeric fields
+func TestValidation_MultipleOf(t *testing.T) {
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{Name: "PriceCents", TypeString: "int64", Tag: `json:"price_cents" validate:"required,multipleof=5"`},
+			{Name: "Step", TypeString: "float64", Tag: `json:"step" validate:"multipleof=0.25"`},
+			{Name: "Code", TypeString: "string", Tag: `json:"code" validate:"multipleof=5"`},
+			{Name: "Zero", TypeString: "int", Tag: `json:"zero" validate:"multipleof=0"`},
+			{Name: "Inf", TypeString: "float64", Tag: `json:"inf" validate:"multipleof=Inf"`},
+		},
+	}
+
+	schema, _, err := builder.BuildSpecSchema("MultipleOfModel", false, SchemaOptions{}, nil)
+	require.NoError(t, err)
+
+	price := schema.Properties["price_cents"]
+	require.NotNil(t, price.MultipleOf)
+	assert.Equal(t, float64(5), *price.MultipleOf)
+
+	step := schema.Properties["step"]
+	require.NotNil(t, step.MultipleOf)
+	assert.Equal(t, 0.25, *step.MultipleOf)
+
+	code := schema.Properties["code"]
+	assert.Nil(t, code.MultipleOf, "multipleof only applies to numbers")
+
+	assert.Nil(t, schema.Properties["zero"].MultipleOf, "multipleOf must be positive")
+	assert.Nil(t, schema.Properties["inf"].MultipleOf, "multipleOf must be finite")
+}
+
+// TestBuildSpecSchema_EmitFieldOrder tests the x-order extension of properties
+func TestBuildSpecSchema_EmitFieldOrder(t *testing.T) {
+	// Fields of embedded structs are flattened where the struct is embedded
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{Name: "Name", TypeString: "string", Tag: `json:"name" public:"view"`},
+			{Name: "ID", TypeString: "string", Tag: `json:"id" public:"view"`},
+			{Name: "CreatedAt", TypeString: "time.Time", Tag: `json:"created_at"`},
+			{Name: "Email", TypeString: "string", Tag: `json:"email" public:"view"`},
+			{Name: "Name", TypeString: "string", Tag: `json:"name"`},
+		},
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		schema, _, err := builder.BuildSpecSchema("User", false, SchemaOptions{}, nil)
+		require.NoError(t, err)
+		for name, prop := range schema.Properties {
+			assert.NotContains(t, prop.Extensions, "x-order", name)
+		}
+	})
+
+	t.Run("numbers properties in declaration order", func(t *testing.T) {
+		opts := SchemaOptions{EmitFieldOrder: true}
+
+		schema, _, err := builder.BuildSpecSchema("User", false, opts, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, schema.Properties["name"].Extensions["x-order"], "redeclared fields keep their first position")
+		assert.Equal(t, 1, schema.Properties["id"].Extensions["x-order"])
+		assert.Equal(t, 2, schema.Properties["created_at"].Extensions["x-order"])
+		assert.Equal(t, 3, schema.Properties["email"].Extensions["x-order"])
+
+		public, _, err := builder.BuildSpecSchema("User", true, opts, nil)
+		require.NoError(t, err)
+		require.Len(t, public.Properties, 3)
+		assert.Equal(t, 1, public.Properties["id"].Extensions["x-order"])
+		assert.Equal(t, 3, public.Properties["email"].Extensions["x-order"], "Public variants keep the full schema order")
+	})
+}
+
+func TestBuildSpecSchema_EmitOmitEmpty(t *testing.T) {
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{Name: "ID", TypeString: "string", Tag: `json:"id"`},
+			{Name: "Nickname", TypeString: "string", Tag: `json:"nickname,omitempty"`},
+			{Name: "Tags", TypeString: "[]string", Tag: `json:"tags,omitempty" public:"view"`},
+		},
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		schema, _, err := builder.BuildSpecSchema("User", false, SchemaOptions{}, nil)
+		require.NoError(t, err)
+		for name, prop := range schema.Properties {
+			assert.NotContains(t, prop.Extensions, "x-omitempty", name)
+		}
+	})
+
+	t.Run("marks omitempty properties", func(t *testing.T) {
+		opts := SchemaOptions{EmitOmitEmpty: true}
+
+		schema, _, err := builder.BuildSpecSchema("User", false, opts, nil)
+		require.NoError(t, err)
+		assert.NotContains(t, schema.Properties["id"].Extensions, "x-omitempty")
+		assert.Equal(t, true, schema.Properties["nickname"].Extensions["x-omitempty"])
+		assert.Equal(t, true, schema.Properties["tags"].Extensions["x-omitempty"])
+
+		public, _, err := builder.BuildSpecSchema("User", true, opts, nil)
+		require.NoError(t, err)
+		assert.Equal(t, true, public.Properties["tags"].Extensions["x-omitempty"])
+	})
+}
+
+func TestBuildSpecSchema_OnlyState(t *testing.T) {
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{Name: "ID", TypeString: "string", Tag: `json:"id" public:"view"`},
+			{Name: "Notes", TypeString: "string", Tag: `json:"notes" public:"view"`, Only: []string{"admin"}},
+			{Name: "Plan", TypeString: "string", Tag: `json:"plan"`, Only: []string{"admin", "user"}},
+		},
+	}
+
+	tests := []struct {
+		state string
+		want  []string
+	}{
+		{state: "", want: []string{"id"}},
+		{state: "admin", want: []string{"id", "notes", "plan"}},
+		{state: "user", want: []string{"id", "plan"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("state "+tt.state, func(t *testing.T) {
+			opts := SchemaOptions{State: tt.state}
+
+			schema, _, err := builder.BuildSpecSchema("Account", false, opts, nil)
+			require.NoError(t, err)
+			var names []string
+			for name := range schema.Properties {
+				names = append(names, name)
+			}
+			assert.ElementsMatch(t, tt.want, names)
+		})
+	}
+}
+
+// TestValidation_SliceRules tests unique and item count validators on slices
+func TestValidation_SliceRules(t *testing.T) {
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{Name: "Tags", TypeString: "[]string", Tag: `json:"tags" validate:"min=1,max=5,unique"`},
+			{Name: "IDs", TypeString: "[]int", Tag: `json:"ids" validate:"len=2"`},
+			{Name: "Names", TypeString: "[]string", Tag: `json:"names"`},
+		},
+	}
+
+	schema, _, err := builder.BuildSpecSchema("SliceModel", false, SchemaOptions{}, nil)
+	require.NoError(t, err)
+
+	tags := schema.Properties["tags"]
+	assert.True(t, tags.UniqueItems)
+	require.NotNil(t, tags.MinItems)
+	require.NotNil(t, tags.MaxItems)
+	assert.Equal(t, int64(1), *tags.MinItems)
+	assert.Equal(t, int64(5), *tags.MaxItems)
+	assert.Nil(t, tags.MinLength, "slice bounds should not become string lengths")
+
+	ids := schema.Properties["ids"]
+	require.NotNil(t, ids.MinItems)
+	require.NotNil(t, ids.MaxItems)
+	assert.Equal(t, int64(2), *ids.MinItems)
+	assert.Equal(t, int64(2), *ids.MaxItems)
+	assert.False(t, ids.UniqueItems)
+
+	names := schema.Properties["names"]
+	assert.False(t, names.UniqueItems)
+	assert.Nil(t, names.MinItems)
+}
+
+// TestValidation_MapRules tests key count validators on maps
+func TestValidation_MapRules(t *testing.T) {
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{Name: "Limits", TypeString: "map[string]int", Tag: `json:"limits" validate:"min=1,max=10"`},
+			{Name: "Labels", TypeString: "map[string]string", Tag: `json:"labels" validate:"gt=0,dive,max=5"`},
+			{Name: "Extra", TypeString: "map[string]string", Tag: `json:"extra"`},
+		},
+	}
+
+	schema, _, err := builder.BuildSpecSchema("MapModel", false, SchemaOptions{}, nil)
+	require.NoError(t, err)
+
+	limits := schema.Properties["limits"]
+	require.NotNil(t, limits.AdditionalProperties)
+	require.NotNil(t, limits.MinProperties)
+	require.NotNil(t, limits.MaxProperties)
+	assert.Equal(t, int64(1), *limits.MinProperties)
+	assert.Equal(t, int64(10), *limits.MaxProperties)
+	assert.Nil(t, limits.Minimum, "map bounds should not become value ranges")
+
+	labels := schema.Properties["labels"]
+	require.NotNil(t, labels.MinProperties)
+	assert.Equal(t, int64(1), *labels.MinProperties)
+	assert.Nil(t, labels.MaxProperties, "rules after dive target the values")
+
+	extra := schema.Properties["extra"]
+	assert.Nil(t, extra.MinProperties)
+	assert.Nil(t, extra.MaxProperties)
+}
+
 // TestPublicMode_ViewOnly tests public:"view" filtering
 func TestPublicMode_ViewOnlyFiltering(t *testing.T) {
 	builder := &StructBuilder{
@@ -728,7 +1026,7 @@ func TestPublicMode_ViewOnlyFiltering(t *testing.T) {
 	}
 
 	// Test with public=false (all fields)
-	schema, _, err := builder.BuildSpecSchema("User", false, false, nil)
+	schema, _, err := builder.BuildSpecSchema("User", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 	assertSchema(t, schema).
 		hasProperty("id").
@@ -737,7 +1035,7 @@ func TestPublicMode_ViewOnlyFiltering(t *testing.T) {
 		propertyCount(3)
 
 	// Test with public=true (only public fields)
-	schemaPublic, _, err := builder.BuildSpecSchema("User", true, false, nil)
+	schemaPublic, _, err := builder.BuildSpecSchema("User", true, SchemaOptions{}, nil)
 	require.NoError(t, err)
 	assertSchema(t, schemaPublic).
 		hasProperty("id").
@@ -768,7 +1066,7 @@ func TestSwaggerIgnore_Tag(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("Account", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("Account", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	// Only email should be included
@@ -794,7 +1092,7 @@ func TestArrays_OfStructs(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("Post", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("Post", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	assertSchema(t, schema).
@@ -817,7 +1115,7 @@ func TestMaps_WithStructValues(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("Config", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("Config", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	assertSchema(t, schema).
@@ -845,7 +1143,7 @@ func TestNestedStructs_DeepNesting(t *testing.T) {
 		},
 	}
 
-	schema, nestedTypes, err := builder.BuildSpecSchema("Company", false, false, nil)
+	schema, nestedTypes, err := builder.BuildSpecSchema("Company", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 
 	assertSchema(t, schema).
@@ -872,7 +1170,7 @@ func TestPublicPropagation_ChildStructWithoutPublicTags(t *testing.T) {
 			},
 		}
 
-		schema, _, err := builder.BuildSpecSchema("Properties", true, false, nil)
+		schema, _, err := builder.BuildSpecSchema("Properties", true, SchemaOptions{}, nil)
 		require.NoError(t, err)
 		require.NotNil(t, schema)
 
@@ -892,7 +1190,7 @@ func TestPublicPropagation_ChildStructWithoutPublicTags(t *testing.T) {
 			},
 		}
 
-		schema, _, err := builder.BuildSpecSchema("Account", true, false, nil)
+		schema, _, err := builder.BuildSpecSchema("Account", true, SchemaOptions{}, nil)
 		require.NoError(t, err)
 		require.NotNil(t, schema)
 
@@ -912,7 +1210,7 @@ func TestPublicPropagation_ChildStructWithoutPublicTags(t *testing.T) {
 			},
 		}
 
-		schema, _, err := builder.BuildSpecSchema("Account", false, false, nil)
+		schema, _, err := builder.BuildSpecSchema("Account", false, SchemaOptions{}, nil)
 		require.NoError(t, err)
 
 		assertSchema(t, schema).
@@ -932,7 +1230,7 @@ func TestPublicPropagation_ChildStructWithoutPublicTags(t *testing.T) {
 			},
 		}
 
-		schema, nestedTypes, err := builder.BuildSpecSchema("Account", true, false, nil)
+		schema, nestedTypes, err := builder.BuildSpecSchema("Account", true, SchemaOptions{}, nil)
 		require.NoError(t, err)
 
 		assertSchema(t, schema).
@@ -964,22 +1262,127 @@ func TestForceRequired(t *testing.T) {
 				TypeString: "string",
 				Tag:        `json:"email"`,
 			},
+			{
+				Name:       "Password",
+				TypeString: "string",
+				Tag:        `json:"password,omitempty" binding:"required,min=8"`,
+			},
 		},
 	}
 
 	// Without forceRequired
-	schema1, _, err := builder.BuildSpecSchema("User", false, false, nil)
+	schema1, _, err := builder.BuildSpecSchema("User", false, SchemaOptions{}, nil)
 	require.NoError(t, err)
 	assertSchema(t, schema1).
 		requiredField("email").
-		notRequiredField("name")
+		notRequiredField("name").
+		notRequiredField("password").
+		requiredCount(1)
 
-	// With forceRequired
-	schema2, _, err := builder.BuildSpecSchema("User", false, true, nil)
+	// With forceRequired, omitempty fields stay optional unless a rule requires them
+	schema2, _, err := builder.BuildSpecSchema("User", false, SchemaOptions{RequiredByDefault: true}, nil)
 	require.NoError(t, err)
 	assertSchema(t, schema2).
 		requiredField("email").
-		requiredField("name"). // Now required due to forceRequired
+		requiredField("password").
+		notRequiredField("name").
+		requiredCount(2)
+
+	// @AllRequired marks every field required, including omitempty ones
+	builder.AllRequired = true
+	schema3, _, err := builder.BuildSpecSchema("User", false, SchemaOptions{}, nil)
+	require.NoError(t, err)
+	assertSchema(t, schema3).
+		requiredField("email").
+		requiredField("name").
+		requiredField("password").
+		requiredCount(3)
+}
+
+// TestBuildSpecSchema_PointersOptional verifies that pointer fields stay out of
+// the required list when PointersOptional is set, even in @AllRequired structs.
+func TestBuildSpecSchema_PointersOptional(t *testing.T) {
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{Name: "Name", TypeString: "string", Tag: `json:"name"`},
+			{Name: "Nickname", TypeString: "*string", Tag: `json:"nickname"`},
+			{Name: "Owner", TypeString: "*User", Tag: `json:"owner"`},
+		},
+	}
+
+	schema, _, err := builder.BuildSpecSchema("Pet", false, SchemaOptions{}, nil)
+	require.NoError(t, err)
+	assertSchema(t, schema).
+		requiredField("name").
+		requiredField("nickname").
+		requiredField("owner")
+
+	opts := SchemaOptions{PointersOptional: true}
+
+	schema, _, err = builder.BuildSpecSchema("Pet", false, opts, nil)
+	require.NoError(t, err)
+	assertSchema(t, schema).
+		requiredField("name").
+		notRequiredField("nickname").
+		notRequiredField("owner").
+		requiredCount(1)
+
+	builder.AllRequired = true
+	schema, _, err = builder.BuildSpecSchema("Pet", false, opts, nil)
+	require.NoError(t, err)
+	assertSchema(t, schema).
+		requiredField("name").
+		requiredCount(1)
+}
+
+// TestPublicMode_RequiredMatchesBase verifies that the Public variant keeps the
+// required status a field has in the base schema.
+func TestPublicMode_RequiredMatchesBase(t *testing.T) {
+	builder := &StructBuilder{
+		Fields: []*StructField{
+			{
+				Name:       "ID",
+				TypeString: "string",
+				Tag:        `public:"view" json:"id"`,
+			},
+			{
+				Name:       "Nickname",
+				TypeString: "string",
+				Tag:        `public:"edit" json:"nickname,omitempty"`,
+			},
+			{
+				Name:       "Secret",
+				TypeString: "string",
+				Tag:        `json:"secret"`,
+			},
+		},
+	}
+
+	base, _, err := builder.BuildSpecSchema("User", false, SchemaOptions{}, nil)
+	require.NoError(t, err)
+	assertSchema(t, base).
+		requiredField("id").
+		requiredField("secret").
+		notRequiredField("nickname")
+
+	public, _, err := builder.BuildSpecSchema("User", true, SchemaOptions{}, nil)
+	require.NoError(t, err)
+	assertSchema(t, public).
+		requiredField("id").
+		notRequiredField("nickname").
+		requiredCount(1)
+
+	// forceRequired and @AllRequired apply to the Public variant the same way
+	forced, _, err := builder.BuildSpecSchema("User", true, SchemaOptions{RequiredByDefault: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, public.Required, forced.Required)
+
+	builder.AllRequired = true
+	allRequired, _, err := builder.BuildSpecSchema("User", true, SchemaOptions{}, nil)
+	require.NoError(t, err)
+	assertSchema(t, allRequired).
+		requiredField("id").
+		requiredField("nickname").
 		requiredCount(2)
 }
 
@@ -1021,7 +1424,7 @@ func TestBuildSpecSchema_EnumFieldInPublicMode_EnumLookupPath(t *testing.T) {
 	}
 
 	// Build Public variant
-	schema, nestedTypes, err := builder.BuildSpecSchema("Account", true, false, enumLookup)
+	schema, nestedTypes, err := builder.BuildSpecSchema("Account", true, SchemaOptions{}, enumLookup)
 	require.NoError(t, err)
 
 	// Enum fields reference base name because enum lookup intercepts before Public suffix
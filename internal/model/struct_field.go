@@ -2,6 +2,7 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/types"
@@ -15,12 +16,20 @@ import (
 	"github.com/griffnb/core-swag/internal/typeregistry"
 )
 
+// omitEmptyExtension marks properties whose json tag has omitempty, see SchemaOptions.EmitOmitEmpty.
+const omitEmptyExtension = "x-omitempty"
+
+// ErrEmptyEnum is returned in strict enum mode for enum fields without enum values.
+var ErrEmptyEnum = errors.New("enum type has no values")
+
 type StructField struct {
 	Name       string         `json:"name"`
 	Type       types.Type     `json:"type"`
 	TypeString string         `json:"type_string"` // For easier JSON serialization
 	Tag        string         `json:"tag"`
 	Fields     []*StructField `json:"fields"` // For nested structs
+	// Only lists the states (from @Only comments) the field is documented in; empty means all
+	Only []string `json:"only,omitempty"`
 }
 
 func (this *StructField) IsPublic() bool {
@@ -28,6 +37,20 @@ func (this *StructField) IsPublic() bool {
 	return ok
 }
 
+// InState reports whether the field is documented when generating for state.
+// Fields without @Only are part of every state.
+func (this *StructField) InState(state string) bool {
+	if len(this.Only) == 0 {
+		return true
+	}
+	for _, only := range this.Only {
+		if strings.EqualFold(only, state) {
+			return true
+		}
+	}
+	return false
+}
+
 func (this *StructField) GetTags() map[string]string {
 	tags := strings.Split(this.Tag, " ")
 	result := make(map[string]string)
@@ -85,8 +108,8 @@ func (this *StructField) GenericTypeArg() (string, error) {
 }
 
 // IsPrimitive returns true if this field's type is a Go primitive or an extended
-// primitive (time.Time, UUID, decimal.Decimal).
-func (this *StructField) IsPrimitive() bool {
+// primitive (time.Time, UUID, decimal.Decimal) of types, nil for the builtins.
+func (this *StructField) IsPrimitive(types *typeregistry.Registry) bool {
 	typeStr := this.EffectiveTypeString()
 	// Strip pointer for Go primitive check
 	clean := strings.TrimPrefix(typeStr, "*")
@@ -97,10 +120,15 @@ func (this *StructField) IsPrimitive() bool {
 	}
 
 	// Check extended primitives via centralized registry
-	_, ok := typeregistry.Lookup(typeStr)
+	_, ok := types.Lookup(typeStr)
 	return ok
 }
 
+// IsPointer returns true if this field's type is a pointer (*T).
+func (this *StructField) IsPointer() bool {
+	return strings.HasPrefix(this.EffectiveTypeString(), "*")
+}
+
 // IsAny returns true if this field's type is any or interface{}.
 func (this *StructField) IsAny() bool {
 	typeStr := this.EffectiveTypeString()
@@ -151,6 +179,10 @@ func (this *StructField) IsSwaggerPrimitive() bool {
 
 	globalNames := []string{"UUID"}
 
+	if isTimeType(named) {
+		return true
+	}
+
 	if typeNames, ok := primitiveTypes[pkgPath]; ok {
 		for _, name := range typeNames {
 			if typeName == name {
@@ -182,24 +214,25 @@ func (this *StructField) IsUnderlyingStruct() bool {
 }
 
 // PrimitiveSchema returns the OpenAPI schema for this field's primitive type.
-func (this *StructField) PrimitiveSchema() *spec.Schema {
-	return primitiveTypeToSchema(this.EffectiveTypeString())
+func (this *StructField) PrimitiveSchema(types *typeregistry.Registry) *spec.Schema {
+	return primitiveTypeToSchema(this.EffectiveTypeString(), types)
 }
 
 // FieldsWrapperSchema returns the OpenAPI schema for a fields package wrapper type
 // (StringField, IntField, IntConstantField[T], etc.).
-func (this *StructField) FieldsWrapperSchema(enumLookup TypeEnumLookup) (*spec.Schema, []string, error) {
-	return getPrimitiveSchemaForFieldType(this.EffectiveTypeString(), this.TypeString, enumLookup)
+func (this *StructField) FieldsWrapperSchema(opts SchemaOptions, enumLookup TypeEnumLookup) (*spec.Schema, []string, error) {
+	return getPrimitiveSchemaForFieldType(this.EffectiveTypeString(), this.TypeString, opts, enumLookup)
 }
 
 // BuildSchema builds an OpenAPI schema for this field's type.
 // Checks for swaggertype tag first to allow user-specified type overrides.
 // Applies struct tags (enums, format, constraints, etc.) to enrich the schema.
 // For recursive types (arrays, maps), creates child StructField instances.
+// opts are the schema options of the build.
 // Returns schema, list of nested struct type names for definition generation, and error.
 func (this *StructField) BuildSchema(
 	public bool,
-	forceRequired bool,
+	opts SchemaOptions,
 	enumLookup TypeEnumLookup,
 ) (*spec.Schema, []string, error) {
 	var nestedTypes []string
@@ -255,12 +288,16 @@ func (this *StructField) BuildSchema(
 	// Create a normalized field for method-based type checks
 	normalizedField := &StructField{TypeString: typeStr}
 
-	// Handle any/interface{} types as empty schema (unknown/any value)
-	if normalizedField.IsAny() {
+	// Handle any/interface{} and pointer-to-interface types as empty schema (unknown/any value)
+	if normalizedField.IsAny() || (isPointer && isPointerToInterface(this.Type)) {
 		if debug {
 			console.Logger.Debug("Detected any/interface{} type: $Bold{%s}\n", typeStr)
 		}
-		return &spec.Schema{}, nil, nil
+		schema := &spec.Schema{}
+		if opts.EmitNullable {
+			schema.Nullable = true
+		}
+		return schema, nil, nil
 	}
 
 	// Check if this is a fields wrapper type
@@ -268,7 +305,7 @@ func (this *StructField) BuildSchema(
 		if debug {
 			console.Logger.Debug("Detected fields wrapper type: $Bold{%s}\n", typeStr)
 		}
-		schema, nestedTypes, err := getPrimitiveSchemaForFieldType(typeStr, this.TypeString, enumLookup)
+		schema, nestedTypes, err := getPrimitiveSchemaForFieldType(typeStr, this.TypeString, opts, enumLookup)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -280,8 +317,8 @@ func (this *StructField) BuildSchema(
 	}
 
 	// Handle primitive types
-	if normalizedField.IsPrimitive() {
-		schema := primitiveTypeToSchema(typeStr)
+	if normalizedField.IsPrimitive(opts.Types) {
+		schema := primitiveTypeToSchema(typeStr, opts.Types)
 		if debug {
 			console.Logger.Debug("Detected Is Primitive type: $Bold{%s} Schema %+v\n", typeStr, schema)
 		}
@@ -299,12 +336,17 @@ func (this *StructField) BuildSchema(
 		if strings.HasPrefix(fullTypeStr, "[]") {
 			fullElemType = strings.TrimPrefix(fullTypeStr, "[]")
 		}
-		elemField := &StructField{TypeString: fullElemType}
-		elemSchema, elemNestedTypes, err := elemField.BuildSchema(public, forceRequired, enumLookup)
+		elemField := &StructField{TypeString: fullElemType, Type: sliceElem(this.Type)}
+		elemSchema, elemNestedTypes, err := elemField.BuildSchema(public, opts, enumLookup)
 		if err != nil {
 			return nil, nil, err
 		}
 		schema := spec.ArrayProperty(elemSchema)
+		// *[]T is a nullable array, []*T an array of nullable items
+		schema.Nullable = isPointer
+		if strings.HasPrefix(elemType, "*") {
+			elemSchema.Nullable = true
+		}
 		// Apply struct tags to enrich the schema
 		if err := this.applyStructTagsToSchema(schema); err != nil {
 			return nil, nil, fmt.Errorf("failed to apply tags to schema: %w", err)
@@ -349,12 +391,17 @@ func (this *StructField) BuildSchema(
 				fullValueType = fullTypeStr[fullValueStart:]
 			}
 		}
-		valueField := &StructField{TypeString: fullValueType}
-		valueSchema, valueNestedTypes, err := valueField.BuildSchema(public, forceRequired, enumLookup)
+		valueField := &StructField{TypeString: fullValueType, Type: mapElem(this.Type)}
+		valueSchema, valueNestedTypes, err := valueField.BuildSchema(public, opts, enumLookup)
 		if err != nil {
 			return nil, nil, err
 		}
 		schema := spec.MapProperty(valueSchema)
+		// Swagger 2.0 can't constrain map keys, so a keypattern tag is emitted as
+		// the patternProperties extension next to additionalProperties
+		if keyPattern := this.GetTags()["keypattern"]; keyPattern != "" {
+			schema.AddExtension(patternPropertiesExtension, map[string]*spec.Schema{keyPattern: valueSchema})
+		}
 		// Apply struct tags to enrich the schema
 		if err := this.applyStructTagsToSchema(schema); err != nil {
 			return nil, nil, fmt.Errorf("failed to apply tags to schema: %w", err)
@@ -368,6 +415,18 @@ func (this *StructField) BuildSchema(
 			console.Logger.Debug("Checking enum for type: $Bold{%s}\n", typeStr)
 		}
 		enums, err := enumLookup.GetEnumsForType(typeStr, nil)
+		if err == nil && len(enums) > 0 && opts.InlineEnums {
+			if debug {
+				console.Logger.Debug("Detected Enum type: $Bold{%s} with %d values, inlining\n", typeStr, len(enums))
+			}
+			schema := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{enumSchemaType(enums)}}}
+			applyEnumsToSchema(schema, enums)
+			// Apply struct tags to enrich the schema
+			if err := this.applyStructTagsToSchema(schema); err != nil {
+				return nil, nil, fmt.Errorf("failed to apply tags to schema: %w", err)
+			}
+			return schema, nil, nil
+		}
 		if err == nil && len(enums) > 0 {
 			if debug {
 				console.Logger.Debug("Detected Enum type: $Bold{%s} with %d values, creating $ref\n", typeStr, len(enums))
@@ -393,6 +452,18 @@ func (this *StructField) BuildSchema(
 		}
 	}
 
+	// Named primitive without enum values (e.g. type Email string) — inline the underlying type
+	if schema := namedPrimitiveSchema(this.Type); schema != nil {
+		if debug {
+			console.Logger.Debug("Detected named primitive type: $Bold{%s} Schema %+v\n", typeStr, schema)
+		}
+		// Apply struct tags to enrich the schema
+		if err := this.applyStructTagsToSchema(schema); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply tags to schema: %w", err)
+		}
+		return schema, nil, nil
+	}
+
 	// Struct ref — validate brackets
 	typeName := typeStr
 	bracketDepth := 0
@@ -429,7 +500,7 @@ func (this *StructField) BuildSchema(
 	// to avoid using wrapper types (e.g., fields.StructField) for the inner type.
 	nestedFullPath := fullTypeStr
 	if !strings.Contains(nestedFullPath, "/") && this.Type != nil {
-		if resolved := resolveFullImportPath(this.Type); resolved != "" {
+		if resolved := resolveFullImportPath(this.Type, opts.BuildTags); resolved != "" {
 			resolvedShort := normalizeTypeName(resolved)
 			if resolvedShort == typeName {
 				nestedFullPath = resolved
@@ -452,8 +523,8 @@ func (this *StructField) BuildSchema(
 }
 
 // applyStructTagsToSchema enriches a base schema with metadata from struct tags.
-// Handles enums, format, title, constraints (min/max, minLength/maxLength),
-// default, example, readonly, multipleOf, and extensions tags.
+// Handles validate/binding rules, enums, format, title, constraints (min/max,
+// minLength/maxLength, pattern), default, example, readonly, multipleOf, and extensions tags.
 // The base schema's type structure should already be set before calling this.
 func (this *StructField) applyStructTagsToSchema(schema *spec.Schema) error {
 	if schema == nil {
@@ -462,6 +533,9 @@ func (this *StructField) applyStructTagsToSchema(schema *spec.Schema) error {
 
 	tags := this.GetTags()
 
+	// Apply validator rules first so explicit tags below take precedence
+	applyValidationTags(schema, tags)
+
 	// Apply format tag
 	if format, ok := tags["format"]; ok {
 		schema.Format = format
@@ -546,6 +620,11 @@ func (this *StructField) applyStructTagsToSchema(schema *spec.Schema) error {
 		}
 	}
 
+	// Apply pattern constraint
+	if pattern, ok := tags["pattern"]; ok {
+		schema.Pattern = pattern
+	}
+
 	// Apply default value
 	if defaultStr, ok := tags["swag_default"]; ok {
 		// Try to parse as JSON, fallback to string
@@ -632,7 +711,7 @@ type DefinitionNameResolver interface {
 // Returns "pkgPath.TypeName" (e.g., "github.com/.../global_struct.EventProperties")
 // or empty string if the path cannot be determined.
 // Unwraps pointers and slices to find the underlying named type.
-func resolveFullImportPath(t types.Type) string {
+func resolveFullImportPath(t types.Type, buildTags []string) string {
 	if t == nil {
 		return ""
 	}
@@ -656,7 +735,7 @@ func resolveFullImportPath(t types.Type) string {
 	if pkg == nil {
 		return ""
 	}
-	return pkg.Path() + "." + named.Obj().Name()
+	return pkg.Path() + "." + scopedTypeName(named.Obj(), buildTags)
 }
 
 // globalNameResolver is the active definition name resolver set by the
@@ -686,12 +765,14 @@ func resolveRefName(shortName, fullPath string) string {
 // ToSpecSchema converts a StructField to OpenAPI spec.Schema
 // propName: extracted from json tag (first part before comma)
 // schema: the OpenAPI schema for this field
-// required: true if omitempty is absent from json tag (or forceRequired is true)
+// required: true if omitempty is absent from json tag, or under RequiredByDefault if the
+// validate or binding tag has the required rule
 // nestedTypes: list of struct type names encountered for recursive definition generation
-// forceRequired: if true, field is always required regardless of omitempty tag
+// opts: the schema options of the build; with RequiredByDefault omitempty fields stay optional
+// unless a rule requires them (use @AllRequired to force them)
 func (this *StructField) ToSpecSchema(
 	public bool,
-	forceRequired bool,
+	opts SchemaOptions,
 	enumLookup TypeEnumLookup,
 ) (propName string, schema *spec.Schema, required bool, nestedTypes []string, err error) {
 	// Filter field if public mode and field is not public
@@ -699,6 +780,12 @@ func (this *StructField) ToSpecSchema(
 		return "", nil, false, nil, nil
 	}
 
+	// Filter field if it is documented for other states only
+	if !this.InState(opts.State) {
+		console.Logger.Debug("Skipping field %s, not documented in state %q\n", this.Name, opts.State)
+		return "", nil, false, nil, nil
+	}
+
 	// Check for swaggerignore tag
 	tags := this.GetTags()
 	if swaggerIgnore, ok := tags["swaggerignore"]; ok && strings.EqualFold(swaggerIgnore, "true") {
@@ -711,30 +798,33 @@ func (this *StructField) ToSpecSchema(
 	if jsonTag == "" {
 		jsonTag = tags["column"]
 	}
-	if jsonTag == "" {
+	// "-" ignores the field, while "-," names it "-" as in encoding/json
+	if jsonTag == "-" || (jsonTag == "" && !ast.IsExported(this.Name)) {
 		return "", nil, false, nil, nil
 	}
 
 	parts := strings.Split(jsonTag, ",")
 	propName = parts[0]
-
-	// Check for omitempty to determine required
-	if forceRequired {
-		required = true
-	} else {
-		required = true
-		for _, part := range parts[1:] {
-			if strings.TrimSpace(part) == "omitempty" {
-				required = false
-				break
-			}
+	// No tag, or only options (json:",omitempty"): name the property after the Go field
+	if propName == "" {
+		propName = opts.propertyName(this.Name)
+	}
+
+	// Collect tag options (omitempty, string)
+	hasOmitEmpty := false
+	hasStringOption := false
+	for _, part := range parts[1:] {
+		switch strings.TrimSpace(part) {
+		case "omitempty":
+			hasOmitEmpty = true
+		case "string":
+			hasStringOption = true
 		}
 	}
 
-	// Skip if json tag is "-"
-	if propName == "-" {
-		return "", nil, false, nil, nil
-	}
+	// Fields without omitempty are required. Under RequiredByDefault an omitempty field
+	// is too when a rule requires it; @AllRequired structs override this.
+	required = !hasOmitEmpty || (opts.RequiredByDefault && hasRequiredRule(tags))
 
 	// Resolve the effective type string for schema building
 	// For generic wrappers, extract the type parameter and build schema from that
@@ -752,8 +842,15 @@ func (this *StructField) ToSpecSchema(
 			}
 		}
 
+		// Constant fields declare an enum, which must have values
+		if enumType := this.ConstantFieldEnumType(); enumType != "" && opts.StrictEnums && enumLookup != nil {
+			if enums, lookupErr := enumLookup.GetEnumsForType(enumType, nil); lookupErr != nil || len(enums) == 0 {
+				return "", nil, false, nil, fmt.Errorf("%w: %s", ErrEmptyEnum, enumType)
+			}
+		}
+
 		schemaField := &StructField{TypeString: extractedType, Type: this.Type}
-		schema, nestedTypes, err = schemaField.BuildSchema(effectivePublic, forceRequired, enumLookup)
+		schema, nestedTypes, err = schemaField.BuildSchema(effectivePublic, opts, enumLookup)
 	} else {
 		// Determine effective public: only struct types get Public suffix
 		effectivePublic := public
@@ -763,13 +860,31 @@ func (this *StructField) ToSpecSchema(
 			}
 		}
 
-		schema, nestedTypes, err = this.BuildSchema(effectivePublic, forceRequired, enumLookup)
+		schema, nestedTypes, err = this.BuildSchema(effectivePublic, opts, enumLookup)
 	}
 
 	if err != nil {
 		return "", nil, false, nil, fmt.Errorf("failed to build schema for type %s: %w", this.EffectiveTypeString(), err)
 	}
 
+	// The ",string" option encodes numbers and booleans as JSON strings
+	if hasStringOption && schema != nil && len(schema.Type) == 1 {
+		switch schema.Type[0] {
+		case "integer", "number", "boolean":
+			schema.Type = spec.StringOrArray{"string"}
+		}
+	}
+
+	if hasOmitEmpty && opts.EmitOmitEmpty && schema != nil {
+		// Copy the extensions, the field schema may be shared
+		extensions := make(spec.Extensions, len(schema.Extensions)+1)
+		for key, value := range schema.Extensions {
+			extensions[key] = value
+		}
+		extensions[omitEmptyExtension] = true
+		schema.Extensions = extensions
+	}
+
 	return propName, schema, required, nestedTypes, nil
 }
 
@@ -870,6 +985,101 @@ func isUnderlyingStruct(t types.Type) bool {
 	return isStruct
 }
 
+// namedPrimitiveSchema returns the schema of the underlying type when t is a
+// named (or pointer to named) string, boolean or numeric type, e.g. type Email string.
+// String types named after a known format (Email, URL, UUID) get that format.
+// Types defined as time.Time, e.g. type DateOnly time.Time, are date or date-time
+// strings, see typeregistry.TimeFormat. Byte arrays named UUID, e.g. type UUID [16]byte,
+// are uuid strings like uuid.UUID. Returns nil for every other type.
+func namedPrimitiveSchema(t types.Type) *spec.Schema {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil
+	}
+	if isTimeType(named) && !isPackageType(named, "time", "Time") {
+		return &spec.Schema{SchemaProps: spec.SchemaProps{
+			Type:   []string{"string"},
+			Format: typeregistry.TimeFormat(named.Obj().Name()),
+		}}
+	}
+	if array, ok := named.Underlying().(*types.Array); ok && named.Obj().Name() == "UUID" && types.Identical(array.Elem(), types.Typ[types.Byte]) {
+		return &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "uuid"}}
+	}
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || basic.Kind() == types.Uintptr || basic.Info()&(types.IsString|types.IsBoolean|types.IsInteger|types.IsFloat) == 0 {
+		return nil
+	}
+
+	schema := primitiveTypeToSchema(basic.Name(), nil)
+	if basic.Info()&types.IsString != 0 {
+		if format, ok := validationFormats[strings.ToLower(named.Obj().Name())]; ok {
+			schema.Format = format
+		}
+	}
+	return schema
+}
+
+// isTimeType reports whether named has the underlying type of time.Time, i.e.
+// is time.Time or a type defined as time.Time.
+func isTimeType(named *types.Named) bool {
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		pkg := st.Field(i).Pkg()
+		if pkg == nil || pkg.Path() != "time" {
+			continue
+		}
+		if obj := pkg.Scope().Lookup("Time"); obj != nil {
+			return types.Identical(st, obj.Type().Underlying())
+		}
+	}
+	return false
+}
+
+// isPackageType reports whether named is the type name of the package with path pkgPath.
+func isPackageType(named *types.Named, pkgPath, name string) bool {
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// sliceElem returns the element type of a slice or pointer to slice type, or nil.
+func sliceElem(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if slice, ok := t.(*types.Slice); ok {
+		return slice.Elem()
+	}
+	return nil
+}
+
+// mapElem returns the value type of a map (or pointer to map) type, or nil.
+func mapElem(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if m, ok := t.(*types.Map); ok {
+		return m.Elem()
+	}
+	return nil
+}
+
+// isPointerToInterface checks whether t is a pointer to an interface type,
+// e.g. *SomeInterface or *any.
+func isPointerToInterface(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	_, ok = ptr.Elem().Underlying().(*types.Interface)
+	return ok
+}
+
 // extractGenericTypeParameter extracts the type parameter from any generic type
 // Handles patterns like StructField[T], IntConstantField[T], StringField[T], etc.
 // Also handles nested brackets like Field[map[string][]User]
@@ -936,7 +1146,7 @@ func makeFullPathDefinitionName(fullTypeStr string) string {
 }
 
 // getPrimitiveSchemaForFieldType returns the appropriate schema for a fields wrapper type
-func getPrimitiveSchemaForFieldType(typeStr string, originalTypeStr string, enumLookup TypeEnumLookup) (*spec.Schema, []string, error) {
+func getPrimitiveSchemaForFieldType(typeStr string, originalTypeStr string, opts SchemaOptions, enumLookup TypeEnumLookup) (*spec.Schema, []string, error) {
 	result, ok := typeregistry.ResolveFieldsWrapper(typeStr)
 	if !ok {
 		// Not a fields wrapper — fallback to string (matches previous default)
@@ -967,6 +1177,9 @@ func getPrimitiveSchemaForFieldType(typeStr string, originalTypeStr string, enum
 				return schema, []string{refName}, nil
 			}
 		}
+		if opts.StrictEnums {
+			return nil, nil, fmt.Errorf("%w: %s", ErrEmptyEnum, fullEnumType)
+		}
 		// Fallback to base type if enum lookup fails
 		return &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{result.FallbackSchemaType}}}, nil, nil
 	}
@@ -979,6 +1192,20 @@ func getPrimitiveSchemaForFieldType(typeStr string, originalTypeStr string, enum
 	return &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}, nil, nil
 }
 
+// enumSchemaType returns the OpenAPI type matching the Go type of the enum values
+func enumSchemaType(enums []EnumValue) string {
+	switch enums[0].Value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "integer"
+	case string:
+		return "string"
+	case float32, float64:
+		return "number"
+	default:
+		return "integer"
+	}
+}
+
 // applyEnumsToSchema applies enum values to a schema
 func applyEnumsToSchema(schema *spec.Schema, enums []EnumValue) {
 	if len(enums) == 0 {
@@ -1018,8 +1245,9 @@ func applyEnumsToSchema(schema *spec.Schema, enums []EnumValue) {
 	}
 }
 
-// primitiveTypeToSchema converts a Go primitive type to OpenAPI schema
-func primitiveTypeToSchema(typeStr string) *spec.Schema {
+// primitiveTypeToSchema converts a Go primitive type, or an extended primitive of
+// types (nil for the builtins), to OpenAPI schema
+func primitiveTypeToSchema(typeStr string, types *typeregistry.Registry) *spec.Schema {
 	// Strip pointer for Go primitive check
 	clean := strings.TrimPrefix(typeStr, "*")
 
@@ -1041,7 +1269,7 @@ func primitiveTypeToSchema(typeStr string) *spec.Schema {
 	}
 
 	// Check extended primitives via centralized registry
-	if schema := typeregistry.ToSchema(typeStr); schema != nil {
+	if schema := types.ToSchema(typeStr); schema != nil {
 		return schema
 	}
 
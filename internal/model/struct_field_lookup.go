@@ -1,6 +1,7 @@
 package model
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
@@ -8,6 +9,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"unicode"
 
 	"github.com/go-openapi/spec"
 	"github.com/griffnb/core-swag/internal/console"
@@ -61,13 +63,55 @@ func (s *SharedTypeCache) set(key string, v *StructBuilder) {
 	s.mu.Unlock()
 }
 
+// ErrTypeDepthExceeded is returned when nested or embedded types are resolved
+// deeper than SchemaOptions.MaxTypeDepth.
+var ErrTypeDepthExceeded = errors.New("type resolution exceeded depth")
+
+// CoreStructParser resolves struct fields through go/packages and builds their
+// schemas with the options and warnings collector set on it.
 type CoreStructParser struct {
+	options  SchemaOptions
+	warnings *console.Diagnostics
+
 	basePackage   *packages.Package
 	visited       map[string]bool
 	typeCache     map[string]*StructBuilder
 	cacheMutex    sync.RWMutex
 	sharedFileSet *token.FileSet
 	sharedCache   *SharedTypeCache
+
+	// depth is the number of types being resolved, err the first ErrTypeDepthExceeded
+	depth int
+	err   error
+}
+
+// SetOptions sets the schema options of the schemas the parser builds.
+func (c *CoreStructParser) SetOptions(opts SchemaOptions) {
+	c.options = opts
+}
+
+// Options returns the schema options of the schemas the parser builds.
+func (c *CoreStructParser) Options() SchemaOptions {
+	return c.options
+}
+
+// enterType increments the resolution depth for typeName. It returns false and
+// records ErrTypeDepthExceeded when the depth limit is reached; otherwise the
+// caller must call leaveType when done.
+func (c *CoreStructParser) enterType(typeName string) bool {
+	if limit := c.options.maxTypeDepth(); c.depth >= limit {
+		if c.err == nil {
+			c.err = fmt.Errorf("%w %d, possible cycle at %s", ErrTypeDepthExceeded, limit, typeName)
+		}
+		return false
+	}
+	c.depth++
+	return true
+}
+
+// leaveType decrements the resolution depth.
+func (c *CoreStructParser) leaveType() {
+	c.depth--
 }
 
 // getOrCreateFileSet returns the shared FileSet, creating one if needed.
@@ -131,7 +175,7 @@ func (c *CoreStructParser) LookupStructFields(_, importPath, typeName string) *S
 	c.cacheMutex.Unlock()
 
 	// Resolve the package — GetOrLoad checks cache then loads if needed.
-	pkg := Cache().GetOrLoad(importPath)
+	pkg := c.loadPackage(importPath)
 	if pkg == nil {
 		// Try suffix match: importPath may be relative ("design/controllers/foo")
 		suffix := "/" + importPath
@@ -149,7 +193,7 @@ func (c *CoreStructParser) LookupStructFields(_, importPath, typeName string) *S
 	}
 
 	if pkg == nil || pkg.PkgPath != importPath {
-		console.Logger.Debug("Package not found or mismatch: %v\n", importPath)
+		c.reportWarning(WarningUnresolvedPackage, "could not resolve package %s for type %s", importPath, typeName)
 		return builder
 	}
 
@@ -159,6 +203,9 @@ func (c *CoreStructParser) LookupStructFields(_, importPath, typeName string) *S
 	visited := make(map[string]bool)
 	c.visited = visited
 	fields := c.ExtractFieldsRecursive(pkg, typeName, visited)
+	doc := typeDoc(pkg, typeName)
+	builder.AllRequired = hasAnnotation(doc, "@allrequired")
+	builder.Description = docDescription(doc)
 
 	for _, f := range fields {
 		console.Logger.Debug("Field: %s, Type: %s, Tag: %s\n", f.Name, f.Type, f.Tag)
@@ -179,6 +226,93 @@ func (c *CoreStructParser) LookupStructFields(_, importPath, typeName string) *S
 	return builder
 }
 
+// typeDecls returns the declarations of file that may declare typeName, and the
+// name to match in them. A function-scoped typeName such as GetFoo.response is
+// looked up among the declarations of that function's body.
+func typeDecls(file *ast.File, typeName string) ([]*ast.GenDecl, string) {
+	var genDecls []*ast.GenDecl
+	funcName, name, scoped := strings.Cut(typeName, ".")
+	if !scoped || strings.Contains(funcName, "[") {
+		for _, decl := range file.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok {
+				genDecls = append(genDecls, genDecl)
+			}
+		}
+		return genDecls, typeName
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil || funcDecl.Name.Name != funcName {
+			continue
+		}
+		for _, stmt := range funcDecl.Body.List {
+			if declStmt, ok := stmt.(*ast.DeclStmt); ok {
+				if genDecl, ok := declStmt.Decl.(*ast.GenDecl); ok {
+					genDecls = append(genDecls, genDecl)
+				}
+			}
+		}
+	}
+	return genDecls, name
+}
+
+// typeDoc returns the doc comment of the declaration of typeName in pkg, or nil.
+// A doc comment on a single-spec type declaration belongs to its type.
+func typeDoc(pkg *packages.Package, typeName string) *ast.CommentGroup {
+	for _, file := range pkg.Syntax {
+		genDecls, name := typeDecls(file, typeName)
+		for _, genDecl := range genDecls {
+			if genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range genDecl.Specs {
+				typeSpec, ok := s.(*ast.TypeSpec)
+				if !ok || typeSpec.Name.Name != name {
+					continue
+				}
+				if typeSpec.Doc == nil && len(genDecl.Specs) == 1 {
+					return genDecl.Doc
+				}
+				return typeSpec.Doc
+			}
+		}
+	}
+	return nil
+}
+
+// docDescription returns the text of a doc comment without annotation lines
+// (e.g. @AllRequired), for use as a schema description.
+func docDescription(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "@") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// hasAnnotation reports whether a comment group contains a line starting with the annotation (case-insensitive)
+func hasAnnotation(doc *ast.CommentGroup, annotation string) bool {
+	if doc == nil {
+		return false
+	}
+	for _, comment := range doc.List {
+		fields := strings.Fields(strings.TrimPrefix(comment.Text, "//"))
+		if len(fields) > 0 && strings.EqualFold(fields[0], annotation) {
+			return true
+		}
+	}
+	return false
+}
+
 // processStructField handles the expansion of StructField[T] types
 func (c *CoreStructParser) processStructField(f *StructField, builder *StructBuilder) {
 	if !f.IsGeneric() {
@@ -194,7 +328,7 @@ func (c *CoreStructParser) processStructField(f *StructField, builder *StructBui
 	// If the inner type is not a struct (map, slice, primitive, any/interface{}),
 	// skip struct expansion and let BuildSchema handle it directly.
 	probe := &StructField{TypeString: subTypeName}
-	if strings.HasPrefix(subTypeName, "map[") || probe.IsPrimitive() || probe.IsAny() {
+	if strings.HasPrefix(subTypeName, "map[") || probe.IsPrimitive(c.options.Types) || probe.IsAny() {
 		f.TypeString = subTypeName
 		builder.Fields = append(builder.Fields, f)
 		return
@@ -215,7 +349,7 @@ func (c *CoreStructParser) processStructField(f *StructField, builder *StructBui
 	// any, or a map type. Catches StructField[[]string] and StructField[[]map[string]any]
 	// where the inner type should not be package-qualified.
 	strippedProbe := &StructField{TypeString: subTypeName}
-	if strippedProbe.IsPrimitive() || strippedProbe.IsAny() || strings.HasPrefix(subTypeName, "map[") {
+	if strippedProbe.IsPrimitive(c.options.Types) || strippedProbe.IsAny() || strings.HasPrefix(subTypeName, "map[") {
 		f.TypeString = arrayPrefix + subTypeName
 		builder.Fields = append(builder.Fields, f)
 		return
@@ -279,9 +413,9 @@ func (c *CoreStructParser) processStructField(f *StructField, builder *StructBui
 	console.Logger.Debug("-----Final Sub type Package %s\n Final Sub Type Name: %s\n", subTypePackage, subTypeName)
 
 	// Find the target package
-	targetPkg := Cache().GetOrLoad(subTypePackage)
+	targetPkg := c.loadPackage(subTypePackage)
 	if targetPkg == nil {
-		console.Logger.Debug("WARNING: Package not found in map for %s\n", subTypePackage)
+		c.reportWarning(WarningUnresolvedPackage, "could not resolve package %s for type %s", subTypePackage, subTypeName)
 		targetPkg = c.basePackage
 	} else {
 		console.Logger.Debug("-----Found target package: %s\n", targetPkg.PkgPath)
@@ -315,25 +449,29 @@ func (c *CoreStructParser) ExtractFieldsRecursive(
 	typeName string,
 	visited map[string]bool,
 ) []*StructField {
-	// Create a unique cache key with package path
+	// Create a unique cache key with package path. A type that is revisited,
+	// e.g. through structs that embed each other, contributes no fields again:
+	// encoding/json shadows them with the shallower ones already collected.
 	cacheKey := pkg.PkgPath + ":" + typeName
 	if visited[cacheKey] {
 		return nil
 	}
 	visited[cacheKey] = true
 
+	if !c.enterType(pkg.PkgPath + "." + typeName) {
+		return nil
+	}
+	defer c.leaveType()
+
 	var fields []*StructField
 
 	for _, file := range pkg.Syntax {
-		for _, decl := range file.Decls {
-			genDecl, ok := decl.(*ast.GenDecl)
-			if !ok {
-				continue
-			}
+		genDecls, name := typeDecls(file, typeName)
+		for _, genDecl := range genDecls {
 			for _, spec := range genDecl.Specs {
 				ts, ok := spec.(*ast.TypeSpec)
 
-				if !ok || ts.Name.Name != typeName {
+				if !ok || ts.Name.Name != name {
 					continue
 				}
 				st, ok := ts.Type.(*ast.StructType)
@@ -346,14 +484,7 @@ func (c *CoreStructParser) ExtractFieldsRecursive(
 					if len(field.Names) > 0 {
 						fieldName = field.Names[0].Name
 					} else {
-						switch expr := field.Type.(type) {
-						case *ast.Ident:
-							fieldName = expr.Name
-						case *ast.SelectorExpr:
-							fieldName = expr.Sel.Name
-						default:
-							fieldName = "unknown"
-						}
+						fieldName = embeddedFieldName(field.Type)
 					}
 
 					tag := ""
@@ -377,6 +508,8 @@ func (c *CoreStructParser) ExtractFieldsRecursive(
 							fieldType = typ.Type
 						}
 					}
+					// Aliases (type A = B) are documented as the type they alias
+					fieldType = unalias(fieldType)
 
 					console.Logger.Debug(
 						"----[Field %d/%d] Validating Field Name: %s, Type: %s (%T), Tag: %s\n",
@@ -414,15 +547,33 @@ func (c *CoreStructParser) ExtractFieldsRecursive(
 
 					// Handle embedded fields BEFORE tag checks
 					// Embedded fields (no explicit name) need recursive expansion
-					// regardless of their tags
+					// regardless of their tags, unless a json name nests them
+					// under that name like encoding/json does
 					isEmbedded := len(field.Names) == 0
+					if isEmbedded && strings.Split(jsonTag, ",")[0] != "" {
+						if subFields, typeName, ok := c.checkStruct(fieldType); ok {
+							fields = append(fields, withOnly([]*StructField{{
+								Name:       fieldName,
+								Type:       fieldType,
+								Tag:        tag,
+								TypeString: typeName,
+								Fields:     subFields,
+							}}, fieldOnlyStates(field))...)
+							continue
+						}
+					}
 					if isEmbedded {
-						if subFields, _, ok := c.checkNamed(fieldType); ok {
+						// Embedded pointers are promoted like values, e.g. *Base
+						embeddedType := fieldType
+						if pointer, ok := embeddedType.(*types.Pointer); ok {
+							embeddedType = pointer.Elem()
+						}
+						if subFields, _, ok := c.checkNamed(embeddedType); ok {
 							if len(subFields) == 0 {
 								console.Logger.Debug("Skipping empty embedded field: %s\n", fieldName)
 								continue
 							}
-							fields = append(fields, subFields...)
+							fields = append(fields, withOnly(subFields, fieldOnlyStates(field))...)
 							continue
 						}
 						// Embedded field that isn't a struct - skip
@@ -430,68 +581,218 @@ func (c *CoreStructParser) ExtractFieldsRecursive(
 						continue
 					}
 
-					// Skip if NEITHER json nor column tag exists (both are empty)
-					if jsonTag == "" && columnTag == "" {
-						console.Logger.Debug("Skipping field %s because it has no json or column tag\n", fieldName)
+					// Unexported fields without a json or column tag aren't encoded
+					if jsonTag == "" && columnTag == "" && !ast.IsExported(fieldName) {
+						console.Logger.Debug("Skipping unexported field %s because it has no json or column tag\n", fieldName)
 						continue
 					}
 
-					// Named struct fields (non-embedded)
-					if subFields, _, ok := c.checkNamed(fieldType); ok {
-						if len(subFields) == 0 {
-							console.Logger.Debug("Skipping empty named field: %s\n", fieldName)
-							continue
-						}
-						fields = append(fields, subFields...)
-						continue
-					}
+					fields = append(fields, withOnly(c.buildField(fieldName, fieldType, tag), fieldOnlyStates(field))...)
+				}
+			}
+		}
+	}
 
-					if subFields, typeName, ok := c.checkStruct(fieldType); ok {
-						fields = append(fields, &StructField{
-							Name:       fieldName,
-							Type:       fieldType,
-							Tag:        tag,
-							TypeString: typeName,
-							Fields:     subFields,
-						})
+	return fields
+}
 
-						console.Logger.Debug("----Added Struct Field: %s of type %s with %d subfields\n", fieldName, typeName, len(subFields))
-						continue
-					}
-					if subFields, typeName, ok := c.checkSlice(fieldType); ok {
-						fields = append(fields, &StructField{
-							Name:       fieldName,
-							Type:       fieldType,
-							Tag:        tag,
-							TypeString: typeName,
-							Fields:     subFields,
-						})
-						continue
-					}
+// unalias replaces the type aliases (type A = B) in t with the types they alias,
+// including the elements of pointers, slices, arrays and maps.
+func unalias(t types.Type) types.Type {
+	switch typ := types.Unalias(t).(type) {
+	case *types.Pointer:
+		if elem := unalias(typ.Elem()); elem != typ.Elem() {
+			return types.NewPointer(elem)
+		}
+		return typ
+	case *types.Slice:
+		if elem := unalias(typ.Elem()); elem != typ.Elem() {
+			return types.NewSlice(elem)
+		}
+		return typ
+	case *types.Array:
+		if elem := unalias(typ.Elem()); elem != typ.Elem() {
+			return types.NewArray(elem, typ.Len())
+		}
+		return typ
+	case *types.Map:
+		key, elem := unalias(typ.Key()), unalias(typ.Elem())
+		if key != typ.Key() || elem != typ.Elem() {
+			return types.NewMap(key, elem)
+		}
+		return typ
+	default:
+		return typ
+	}
+}
 
-					if subFields, typeName, ok := c.checkMap(fieldType); ok {
-						fields = append(fields, &StructField{
-							Name:       fieldName,
-							Type:       fieldType,
-							Tag:        tag,
-							TypeString: typeName,
-							Fields:     subFields,
-						})
-						continue
-					}
+// fieldOnlyStates returns the states listed by the @Only annotations of a
+// field's doc or line comment, e.g. "// @Only admin", "// @Only admin,user" or
+// "// @Only admin user".
+func fieldOnlyStates(field *ast.Field) []string {
+	var states []string
+	for _, group := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if group == nil {
+			continue
+		}
+		for _, comment := range group.List {
+			text := strings.Fields(strings.TrimPrefix(comment.Text, "//"))
+			if len(text) < 2 || !strings.EqualFold(text[0], "@Only") {
+				continue
+			}
+			states = append(states, strings.FieldsFunc(strings.Join(text[1:], " "), func(r rune) bool {
+				return r == ',' || unicode.IsSpace(r)
+			})...)
+		}
+	}
+	return states
+}
 
-					fields = append(fields, &StructField{
-						Name:       fieldName,
-						Type:       fieldType,
-						Tag:        tag,
-						TypeString: fieldType.String(),
-					})
+// withOnly restricts fields to the given states. Fields that are already
+// restricted keep the states in both lists. Fields are copied, since the fields
+// of named types are shared through the cache.
+func withOnly(fields []*StructField, states []string) []*StructField {
+	if len(states) == 0 {
+		return fields
+	}
+	restricted := make([]*StructField, 0, len(fields))
+	for _, field := range fields {
+		copied := *field
+		copied.Only = states
+		if len(field.Only) > 0 {
+			copied.Only = nil
+			for _, state := range states {
+				if field.InState(state) {
+					copied.Only = append(copied.Only, state)
 				}
 			}
+			if len(copied.Only) == 0 {
+				continue
+			}
 		}
+		restricted = append(restricted, &copied)
 	}
+	return restricted
+}
 
-	return fields
+// buildField classifies a named (non-embedded) field by its type. Named struct
+// types are flattened into their fields; structs, slices and maps of structs
+// carry their nested fields. Returns nil when the field should be skipped.
+func (c *CoreStructParser) buildField(fieldName string, fieldType types.Type, tag string) []*StructField {
+	if subFields, _, ok := c.checkNamed(fieldType); ok {
+		if len(subFields) == 0 {
+			console.Logger.Debug("Skipping empty named field: %s\n", fieldName)
+			return nil
+		}
+		return subFields
+	}
+
+	if subFields, typeName, ok := c.checkStruct(fieldType); ok {
+		console.Logger.Debug("----Added Struct Field: %s of type %s with %d subfields\n", fieldName, typeName, len(subFields))
+		return []*StructField{{
+			Name:       fieldName,
+			Type:       fieldType,
+			Tag:        tag,
+			TypeString: typeName,
+			Fields:     subFields,
+		}}
+	}
+
+	if subFields, typeName, ok := c.checkSlice(fieldType); ok {
+		return []*StructField{{
+			Name:       fieldName,
+			Type:       fieldType,
+			Tag:        tag,
+			TypeString: typeName,
+			Fields:     subFields,
+		}}
+	}
+
+	if subFields, typeName, ok := c.checkMap(fieldType); ok {
+		return []*StructField{{
+			Name:       fieldName,
+			Type:       fieldType,
+			Tag:        tag,
+			TypeString: typeName,
+			Fields:     subFields,
+		}}
+	}
+
+	return []*StructField{{
+		Name:       fieldName,
+		Type:       fieldType,
+		Tag:        tag,
+		TypeString: fieldType.String(),
+	}}
+}
+
+// substituteTypeArgs replaces fields typed by a type parameter of a generic
+// struct (e.g. an embedded Base[T]) with the types of the instantiation, which
+// go/types has already substituted in the instance's underlying struct.
+func (c *CoreStructParser) substituteTypeArgs(named *types.Named, fields []*StructField) []*StructField {
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || named.TypeArgs().Len() == 0 {
+		return fields
+	}
+
+	instantiated := make(map[string]types.Type, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		instantiated[st.Field(i).Name()] = st.Field(i).Type()
+	}
+
+	result := make([]*StructField, 0, len(fields))
+	for _, f := range fields {
+		fieldType, ok := instantiated[f.Name]
+		if !ok || !containsTypeParam(f.Type) {
+			result = append(result, f)
+			continue
+		}
+		result = append(result, c.buildField(f.Name, fieldType, f.Tag)...)
+	}
+
+	return result
+}
+
+// containsTypeParam reports whether t is, or is composed of, a type parameter.
+func containsTypeParam(t types.Type) bool {
+	switch typed := t.(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Pointer:
+		return containsTypeParam(typed.Elem())
+	case *types.Slice:
+		return containsTypeParam(typed.Elem())
+	case *types.Array:
+		return containsTypeParam(typed.Elem())
+	case *types.Map:
+		return containsTypeParam(typed.Key()) || containsTypeParam(typed.Elem())
+	case *types.Named:
+		for i := 0; i < typed.TypeArgs().Len(); i++ {
+			if containsTypeParam(typed.TypeArgs().At(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// embeddedFieldName returns the name an embedded field is promoted under,
+// e.g. "Base" for Base, *pkg.Base and Base[T].
+func embeddedFieldName(expr ast.Expr) string {
+	switch typed := expr.(type) {
+	case *ast.Ident:
+		return typed.Name
+	case *ast.SelectorExpr:
+		return typed.Sel.Name
+	case *ast.StarExpr:
+		return embeddedFieldName(typed.X)
+	case *ast.IndexExpr:
+		return embeddedFieldName(typed.X)
+	case *ast.IndexListExpr:
+		return embeddedFieldName(typed.X)
+	default:
+		return "unknown"
+	}
 }
 
 func (c *CoreStructParser) checkNamed(fieldType types.Type) ([]*StructField, *types.Named, bool) {
@@ -514,14 +815,14 @@ func (c *CoreStructParser) checkNamed(fieldType types.Type) ([]*StructField, *ty
 		}
 		if _, ok := named.Underlying().(*types.Struct); ok {
 			console.Logger.Debug("Found sub type Package %s Name %s\n", pkg.Path(), named.Obj().Name())
-			nextPackage := Cache().GetOrLoad(pkg.Path())
+			nextPackage := c.loadPackage(pkg.Path())
 			if nextPackage == nil {
-				console.Logger.Debug("Package not found for %s\n", pkg.Path())
+				c.reportWarning(WarningUnresolvedPackage, "could not resolve package %s for type %s", pkg.Path(), named.Obj().Name())
 				return nil, nil, true
 			}
 			console.Logger.Debug("Next Package: %s\n", nextPackage.PkgPath)
-			subFields := c.ExtractFieldsRecursive(nextPackage, named.Obj().Name(), c.visited)
-			return subFields, named, true
+			subFields := c.ExtractFieldsRecursive(nextPackage, scopedTypeName(named.Obj(), c.options.BuildTags), c.visited)
+			return c.substituteTypeArgs(named, subFields), named, true
 		}
 	}
 
@@ -532,8 +833,8 @@ func (c *CoreStructParser) checkNamed(fieldType types.Type) ([]*StructField, *ty
 // e.g., "github.com/company/project/account.Properties". Using the full
 // path eliminates non-deterministic short-name resolution downstream in
 // buildSchemasRecursive (which would otherwise need to guess the package
-// from a cache scan with random map iteration order).
-func getQualifiedTypeName(namedType *types.Named) string {
+// from a cache scan with random map iteration order). Packages are loaded with buildTags.
+func getQualifiedTypeName(namedType *types.Named, buildTags []string) string {
 	if namedType == nil {
 		return ""
 	}
@@ -541,7 +842,59 @@ func getQualifiedTypeName(namedType *types.Named) string {
 	if pkg == nil {
 		return namedType.Obj().Name()
 	}
-	return fmt.Sprintf("%s.%s", pkg.Path(), namedType.Obj().Name())
+	return fmt.Sprintf("%s.%s", pkg.Path(), scopedTypeName(namedType.Obj(), buildTags))
+}
+
+// scopedTypeName returns the name of a type as the registry names it: a type
+// declared inside a function is qualified by it, e.g. GetFoo.owner. The package is
+// loaded with buildTags when its syntax isn't cached.
+func scopedTypeName(obj *types.TypeName, buildTags []string) string {
+	pkg := obj.Pkg()
+	if pkg == nil || obj.Parent() == nil || obj.Parent() == pkg.Scope() {
+		return obj.Name()
+	}
+	loaded, _ := Cache().GetOrLoad(pkg.Path(), buildTags)
+	if loaded == nil {
+		return obj.Name()
+	}
+	for _, file := range loaded.Syntax {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if ok && funcDecl.Body != nil && funcDecl.Body.Pos() <= obj.Pos() && obj.Pos() < funcDecl.Body.End() {
+				return funcDecl.Name.Name + "." + obj.Name()
+			}
+		}
+	}
+	return obj.Name()
+}
+
+// splitTypePath splits a full type path into its package path and type name.
+// A function-scoped type such as example.com/api.GetFoo.owner keeps its
+// function in the type name when the package declares that function.
+func splitTypePath(typePath string) (string, string) {
+	lastDot := strings.LastIndex(typePath, ".")
+	if lastDot < 0 {
+		return "", typePath
+	}
+	pkgPath, typeName := typePath[:lastDot], typePath[lastDot+1:]
+
+	funcDot := strings.LastIndex(pkgPath, ".")
+	if funcDot <= strings.LastIndex(pkgPath, "/") || Cache().get(pkgPath) != nil {
+		return pkgPath, typeName
+	}
+	pkg := Cache().get(pkgPath[:funcDot])
+	if pkg == nil {
+		return pkgPath, typeName
+	}
+	funcName := pkgPath[funcDot+1:]
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Name.Name == funcName {
+				return pkgPath[:funcDot], funcName + "." + typeName
+			}
+		}
+	}
+	return pkgPath, typeName
 }
 
 func (c *CoreStructParser) checkStruct(fieldType types.Type) ([]*StructField, string, bool) {
@@ -549,13 +902,13 @@ func (c *CoreStructParser) checkStruct(fieldType types.Type) ([]*StructField, st
 	if isPointer {
 		fields, namedType, ok := c.checkNamed(pointer.Elem())
 		if ok && namedType != nil {
-			qualifiedName := getQualifiedTypeName(namedType)
+			qualifiedName := getQualifiedTypeName(namedType, c.options.BuildTags)
 			return fields, fmt.Sprintf("*%s", qualifiedName), true
 		}
 	} else {
 		fields, namedType, ok := c.checkNamed(fieldType)
 		if ok && namedType != nil {
-			return fields, getQualifiedTypeName(namedType), true
+			return fields, getQualifiedTypeName(namedType, c.options.BuildTags), true
 		}
 	}
 
@@ -602,7 +955,13 @@ func (c *CoreStructParser) checkMap(fieldType types.Type) ([]*StructField, strin
 // BuildAllSchemasWithCache is like BuildAllSchemas but uses a SharedTypeCache
 // to avoid redundant type resolution across concurrent goroutines.
 func BuildAllSchemasWithCache(baseModule, pkgPath, typeName string, cache *SharedTypeCache, packageNameOverride ...string) (map[string]*spec.Schema, error) {
-	return buildAllSchemasInternal(baseModule, pkgPath, typeName, cache, packageNameOverride...)
+	return buildAllSchemasInternal(&CoreStructParser{}, baseModule, pkgPath, typeName, cache, packageNameOverride...)
+}
+
+// BuildAllSchemasWithCache is like the package-level BuildAllSchemasWithCache but
+// builds with the schema options, build tags and warnings collector of c.
+func (c *CoreStructParser) BuildAllSchemasWithCache(baseModule, pkgPath, typeName string, cache *SharedTypeCache, packageNameOverride ...string) (map[string]*spec.Schema, error) {
+	return buildAllSchemasInternal(c, baseModule, pkgPath, typeName, cache, packageNameOverride...)
 }
 
 // BuildAllSchemas generates both public and non-public schema variants for a type.
@@ -612,11 +971,11 @@ func BuildAllSchemasWithCache(baseModule, pkgPath, typeName string, cache *Share
 // path ".../stripe-go/v84").
 // Returns a map of schema names to schemas (includes both base and Public variants).
 func BuildAllSchemas(baseModule, pkgPath, typeName string, packageNameOverride ...string) (map[string]*spec.Schema, error) {
-	return buildAllSchemasInternal(baseModule, pkgPath, typeName, nil, packageNameOverride...)
+	return buildAllSchemasInternal(&CoreStructParser{}, baseModule, pkgPath, typeName, nil, packageNameOverride...)
 }
 
-func buildAllSchemasInternal(baseModule, pkgPath, typeName string, cache *SharedTypeCache, packageNameOverride ...string) (map[string]*spec.Schema, error) {
-	parser := &CoreStructParser{sharedCache: cache}
+func buildAllSchemasInternal(settings *CoreStructParser, baseModule, pkgPath, typeName string, cache *SharedTypeCache, packageNameOverride ...string) (map[string]*spec.Schema, error) {
+	parser := &CoreStructParser{options: settings.options, warnings: settings.warnings, sharedCache: cache}
 
 	// Use override if provided, otherwise derive from pkgPath
 	packageName := pkgPath
@@ -631,6 +990,9 @@ func buildAllSchemasInternal(baseModule, pkgPath, typeName string, cache *Shared
 	if builder == nil {
 		return nil, fmt.Errorf("failed to lookup struct fields for %s", typeName)
 	}
+	if parser.err != nil {
+		return nil, parser.err
+	}
 
 	allSchemas := make(map[string]*spec.Schema)
 	processed := make(map[string]bool) // Track processed types to avoid infinite recursion
@@ -650,6 +1012,18 @@ func buildAllSchemasInternal(baseModule, pkgPath, typeName string, cache *Shared
 	return allSchemas, nil
 }
 
+// titleCase capitalizes each dot-separated part of a type name and joins them,
+// so a function-scoped GetFoo.response becomes GetFooResponse.
+func titleCase(typeName string) string {
+	parts := strings.Split(typeName, ".")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
 // buildSchemasRecursive recursively builds schemas for a type and all its nested types
 func buildSchemasRecursive(
 	builder *StructBuilder,
@@ -668,6 +1042,14 @@ func buildSchemasRecursive(
 	}
 	processed[processedKey] = true
 
+	if parser.err != nil {
+		return parser.err
+	}
+	if !parser.enterType(pkgPath + "." + schemaName) {
+		return parser.err
+	}
+	defer parser.leaveType()
+
 	// Extract base type name (remove Public suffix if present)
 	baseTypeName := schemaName
 	if public && strings.HasSuffix(schemaName, "Public") {
@@ -677,8 +1059,8 @@ func buildSchemasRecursive(
 	// Build schema for current type
 	// Create a parser-based enum lookup that can access the packages
 	enumLookup := &ParserEnumLookup{Parser: parser, BaseModule: baseModule, PkgPath: pkgPath}
-	// Respect omitempty tags: fields without omitempty are required, fields with omitempty are not
-	schema, nestedTypes, err := builder.BuildSpecSchema(baseTypeName, public, false, enumLookup)
+	// Build with the schema options of the parser
+	schema, nestedTypes, err := builder.BuildSpecSchema(baseTypeName, public, parser.options, enumLookup)
 	if err != nil {
 		return fmt.Errorf("failed to build schema for %s: %w", schemaName, err)
 	}
@@ -704,7 +1086,7 @@ func buildSchemasRecursive(
 		// e.g., account.Account → Account, account.AccountJoined → AccountJoined
 		//       billing_plan.BillingPlanJoined → BillingPlanJoined
 		// Ensure title starts with uppercase for proper PascalCase
-		schema.Title = strings.ToUpper(typeName[:1]) + typeName[1:]
+		schema.Title = titleCase(typeName)
 	} else {
 		// Package and type don't align - combine them
 		// e.g., account.Properties → AccountProperties
@@ -712,7 +1094,7 @@ func buildSchemasRecursive(
 		packagePascal := toPascalCase(packageName)
 		// Capitalize first letter of typeName so combined title is proper PascalCase
 		// e.g., "DesignTemplateCategoryItems" + "previewResponse" → "DesignTemplateCategoryItemsPreviewResponse"
-		titleTypeName := strings.ToUpper(typeName[:1]) + typeName[1:]
+		titleTypeName := titleCase(typeName)
 		schema.Title = packagePascal + titleTypeName
 	}
 
@@ -745,10 +1127,8 @@ func buildSchemasRecursive(
 
 		if strings.Contains(nestedTypeName, "/") {
 			// Full import path — extract package path, package name, and type name
-			lastDot := strings.LastIndex(nestedTypeName, ".")
-			if lastDot >= 0 {
-				nestedPkgPath = nestedTypeName[:lastDot]
-				baseNestedType = nestedTypeName[lastDot+1:]
+			if strings.Contains(nestedTypeName, ".") {
+				nestedPkgPath, baseNestedType = splitTypePath(nestedTypeName)
 				if lastSlash := strings.LastIndex(nestedPkgPath, "/"); lastSlash >= 0 {
 					nestedPackageName = nestedPkgPath[lastSlash+1:]
 				} else {
@@ -820,8 +1200,9 @@ func buildSchemasRecursive(
 
 		// Case 1: nil builder — external/unloadable type. Create opaque object definition.
 		if nestedBuilder == nil {
-			console.Logger.Debug(
-				"$Yellow{Nested type %s in package %s has nil builder (external/unloadable), creating opaque object}\n",
+			parser.reportWarning(
+				WarningUnresolvedType,
+				"could not resolve type %s in package %s, documenting it as an opaque object",
 				cleanNestedType,
 				nestedPkgPath,
 			)
@@ -867,21 +1248,13 @@ func buildSchemasRecursive(
 				baseEnumSchema := &spec.Schema{
 					SchemaProps: spec.SchemaProps{
 						Title: toPascalCase(nestedPackageName) + cleanNestedType,
+						Type:  []string{enumSchemaType(enums)},
 					},
 				}
 
-				switch enums[0].Value.(type) {
-				case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-					baseEnumSchema.Type = []string{"integer"}
-				case string:
-					baseEnumSchema.Type = []string{"string"}
-				case float32, float64:
-					baseEnumSchema.Type = []string{"number"}
-				default:
-					baseEnumSchema.Type = []string{"integer"}
-				}
-
 				applyEnumsToSchema(baseEnumSchema, enums)
+				// Give docs UIs a sample value for fields that $ref the enum
+				baseEnumSchema.Example = baseEnumSchema.Enum[0]
 
 				baseSchemaKey := nestedPackageName + "." + cleanNestedType
 				allSchemas[baseSchemaKey] = baseEnumSchema
@@ -2,9 +2,13 @@ package model
 
 import (
 	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
 	"sync"
 	"testing"
 
+	"github.com/go-openapi/spec"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/go/packages"
@@ -235,7 +239,8 @@ func TestPackageCache_GetOrLoad_HitPath(t *testing.T) {
 	}
 	Cache().Seed([]*packages.Package{seeded})
 
-	result := Cache().GetOrLoad("example.com/cached")
+	result, err := Cache().GetOrLoad("example.com/cached", nil)
+	require.NoError(t, err)
 	require.NotNil(t, result)
 	assert.Equal(t, "example.com/cached", result.PkgPath)
 
@@ -251,8 +256,327 @@ func TestPackageCache_GetOrLoad_MissPath(t *testing.T) {
 	// GetOrLoad for a non-existent package still returns a package object
 	// (packages.Load returns a package with Errors set rather than an error).
 	// The important thing is that the miss counter increments.
-	_ = Cache().GetOrLoad("example.com/does-not-exist-at-all")
+	_, _ = Cache().GetOrLoad("example.com/does-not-exist-at-all", nil)
 
 	_, misses := Cache().Stats()
 	assert.Equal(t, int64(1), misses)
 }
+
+func TestTypeDoc(t *testing.T) {
+	src := `package models
+
+// Strict is fully required
+// @AllRequired
+type Strict struct {
+	Name string ` + "`json:\"name,omitempty\"`" + `
+}
+
+type (
+	// @allrequired
+	Grouped struct{}
+
+	Loose struct{}
+)
+
+// Account represents a user account.
+//
+// It is shared by all tenants.
+// @NoPublic
+type Account struct{}
+`
+	file, err := goparser.ParseFile(token.NewFileSet(), "models.go", src, goparser.ParseComments)
+	require.NoError(t, err)
+	pkg := &packages.Package{Syntax: []*ast.File{file}}
+
+	t.Run("annotations", func(t *testing.T) {
+		assert.True(t, hasAnnotation(typeDoc(pkg, "Strict"), "@allrequired"))
+		assert.True(t, hasAnnotation(typeDoc(pkg, "Grouped"), "@allrequired"))
+		assert.False(t, hasAnnotation(typeDoc(pkg, "Loose"), "@allrequired"))
+		assert.False(t, hasAnnotation(typeDoc(pkg, "Account"), "@allrequired"))
+		assert.Nil(t, typeDoc(pkg, "Missing"))
+	})
+
+	t.Run("description excludes annotation lines", func(t *testing.T) {
+		assert.Equal(t, "Strict is fully required", docDescription(typeDoc(pkg, "Strict")))
+		assert.Equal(t, "", docDescription(typeDoc(pkg, "Grouped")))
+		assert.Equal(t, "", docDescription(typeDoc(pkg, "Loose")))
+		assert.Equal(t, "Account represents a user account.\n\nIt is shared by all tenants.", docDescription(typeDoc(pkg, "Account")))
+	})
+}
+
+func TestLookupStructFields_EmbeddedGeneric(t *testing.T) {
+	resetGlobalPackageCache()
+	t.Cleanup(resetGlobalPackageCache)
+
+	src := `package generic
+
+type Base[T any] struct {
+	ID    T   ` + "`json:\"id\"`" + `
+	Items []T ` + "`json:\"items\"`" + `
+	Note  string ` + "`json:\"note\"`" + `
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K ` + "`json:\"key\"`" + `
+	Value V ` + "`json:\"value\"`" + `
+}
+
+type User struct {
+	Base[int64]
+	Pair[string, bool]
+	Name string ` + "`json:\"name\"`" + `
+}
+`
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "generic.go", src, goparser.ParseComments)
+	require.NoError(t, err)
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	typesPkg, err := (&types.Config{}).Check("example.com/generic", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	SeedGlobalPackageCache([]*packages.Package{{
+		PkgPath:   "example.com/generic",
+		Name:      "generic",
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		Types:     typesPkg,
+		TypesInfo: info,
+	}})
+
+	builder := (&CoreStructParser{}).LookupStructFields("", "example.com/generic", "User")
+	require.NotNil(t, builder)
+
+	fieldTypes := make(map[string]string)
+	for _, f := range builder.Fields {
+		fieldTypes[f.Name] = f.TypeString
+	}
+
+	assert.Equal(t, map[string]string{
+		"ID":    "int64",
+		"Items": "[]int64",
+		"Note":  "string",
+		"Key":   "string",
+		"Value": "bool",
+		"Name":  "string",
+	}, fieldTypes)
+}
+
+func TestLookupStructFields_EmbeddedWithJSONName(t *testing.T) {
+	resetGlobalPackageCache()
+	t.Cleanup(resetGlobalPackageCache)
+
+	src := `package embed
+
+type Base struct {
+	ID string ` + "`json:\"id\" public:\"view\"`" + `
+}
+
+type Inner struct {
+	A string ` + "`json:\"a\"`" + `
+	B int    ` + "`json:\"b\" public:\"view\"`" + `
+}
+
+type Other struct {
+	C string ` + "`json:\"c\"`" + `
+}
+
+type Outer struct {
+	Base
+	Inner  ` + "`json:\"inner\" public:\"view\"`" + `
+	*Other ` + "`json:\"other,omitempty\"`" + `
+	Name   string ` + "`json:\"name\" public:\"view\"`" + `
+}
+`
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "embed.go", src, goparser.ParseComments)
+	require.NoError(t, err)
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	typesPkg, err := (&types.Config{}).Check("example.com/embed", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	SeedGlobalPackageCache([]*packages.Package{{
+		PkgPath:   "example.com/embed",
+		Name:      "embed",
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		Types:     typesPkg,
+		TypesInfo: info,
+	}})
+
+	builder := (&CoreStructParser{}).LookupStructFields("", "example.com/embed", "Outer")
+	require.NotNil(t, builder)
+
+	fieldTypes := make(map[string]string)
+	for _, f := range builder.Fields {
+		fieldTypes[f.Name] = f.TypeString
+	}
+	assert.Equal(t, map[string]string{
+		"ID":    "string",
+		"Inner": "example.com/embed.Inner",
+		"Other": "*example.com/embed.Other",
+		"Name":  "string",
+	}, fieldTypes)
+
+	schema, nestedTypes, err := builder.BuildSpecSchema("Outer", false, SchemaOptions{}, nil)
+	require.NoError(t, err)
+	inner, other := schema.Properties["inner"], schema.Properties["other"]
+	assert.Equal(t, "#/definitions/example_com_embed.Inner", inner.Ref.String())
+	assert.Equal(t, "#/definitions/example_com_embed.Other", other.Ref.String())
+	assert.NotContains(t, schema.Properties, "a")
+	assert.Contains(t, nestedTypes, "example.com/embed.Inner")
+
+	publicSchema, publicNested, err := builder.BuildSpecSchema("Outer", true, SchemaOptions{}, nil)
+	require.NoError(t, err)
+	publicInner := publicSchema.Properties["inner"]
+	assert.Equal(t, "#/definitions/example_com_embed.InnerPublic", publicInner.Ref.String())
+	assert.Contains(t, publicNested, "example.com/embed.InnerPublic")
+}
+
+func TestBuildAllSchemas_MutuallyEmbeddedStructs(t *testing.T) {
+	resetGlobalPackageCache()
+	t.Cleanup(resetGlobalPackageCache)
+
+	src := `package cycle
+
+type Parent struct {
+	*Child
+	Name   string ` + "`json:\"name\" public:\"view\"`" + `
+	Secret string ` + "`json:\"secret\"`" + `
+}
+
+type Child struct {
+	*Parent
+	Title string ` + "`json:\"title\" public:\"view\"`" + `
+}
+
+type Folder struct {
+	*File ` + "`json:\"file\" public:\"view\"`" + `
+	Path  string ` + "`json:\"path\" public:\"view\"`" + `
+}
+
+type File struct {
+	*Folder ` + "`json:\"folder\" public:\"view\"`" + `
+	Size    int ` + "`json:\"size\" public:\"view\"`" + `
+}
+`
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "cycle.go", src, goparser.ParseComments)
+	require.NoError(t, err)
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	typesPkg, err := (&types.Config{}).Check("example.com/cycle", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	SeedGlobalPackageCache([]*packages.Package{{
+		PkgPath:   "example.com/cycle",
+		Name:      "cycle",
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		Types:     typesPkg,
+		TypesInfo: info,
+	}})
+
+	t.Run("should promote the fields of embedded pointers once", func(t *testing.T) {
+		schemas, err := BuildAllSchemas("", "example.com/cycle", "Parent")
+		require.NoError(t, err)
+		assertSchema(t, schemas["cycle.Parent"]).
+			hasProperty("name").hasProperty("secret").hasProperty("title").propertyCount(3)
+		assertSchema(t, schemas["cycle.ParentPublic"]).
+			hasProperty("name").hasProperty("title").propertyCount(2)
+
+		schemas, err = BuildAllSchemas("", "example.com/cycle", "Child")
+		require.NoError(t, err)
+		assertSchema(t, schemas["cycle.Child"]).
+			hasProperty("name").hasProperty("secret").hasProperty("title").propertyCount(3)
+		assertSchema(t, schemas["cycle.ChildPublic"]).
+			hasProperty("name").hasProperty("title").propertyCount(2)
+	})
+
+	t.Run("should reference embedded structs with a json name", func(t *testing.T) {
+		schemas, err := BuildAllSchemas("", "example.com/cycle", "Folder")
+		require.NoError(t, err)
+		assertSchema(t, schemas["cycle.Folder"]).
+			propertyRef("file", "#/definitions/example_com_cycle.File")
+		assertSchema(t, schemas["cycle.FolderPublic"]).
+			propertyRef("file", "#/definitions/example_com_cycle.FilePublic")
+		assertSchema(t, schemas["cycle.File"]).
+			propertyRef("folder", "#/definitions/example_com_cycle.Folder")
+		assertSchema(t, schemas["cycle.FilePublic"]).
+			propertyRef("folder", "#/definitions/example_com_cycle.FolderPublic")
+	})
+}
+
+func TestCoreStructParser_BuildAllSchemasWithCache_Options(t *testing.T) {
+	resetGlobalPackageCache()
+	t.Cleanup(resetGlobalPackageCache)
+
+	src := `package accounts
+
+type Account struct {
+	FirstName string
+	Nickname  string ` + "`json:\"nickname,omitempty\"`" + `
+}
+`
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "accounts.go", src, goparser.ParseComments)
+	require.NoError(t, err)
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	typesPkg, err := (&types.Config{}).Check("example.com/accounts", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	SeedGlobalPackageCache([]*packages.Package{{
+		PkgPath:   "example.com/accounts",
+		Name:      "accounts",
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		Types:     typesPkg,
+		TypesInfo: info,
+	}})
+
+	snake := &CoreStructParser{}
+	snake.SetOptions(SchemaOptions{PropNamingStrategy: "snakecase", EmitOmitEmpty: true})
+	pascal := &CoreStructParser{}
+	pascal.SetOptions(SchemaOptions{PropNamingStrategy: "pascalcase"})
+
+	// Parsers building at the same time each keep their own options
+	var wg sync.WaitGroup
+	results := make([]map[string]*spec.Schema, 2)
+	errs := make([]error, 2)
+	for i, parser := range []*CoreStructParser{snake, pascal} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = parser.BuildAllSchemasWithCache("", "example.com/accounts", "Account", NewSharedTypeCache())
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+
+	assertSchema(t, results[0]["accounts.Account"]).
+		hasProperty("first_name").hasProperty("nickname").propertyCount(2)
+	assert.Equal(t, true, results[0]["accounts.Account"].Properties["nickname"].Extensions["x-omitempty"])
+
+	assertSchema(t, results[1]["accounts.Account"]).
+		hasProperty("FirstName").hasProperty("nickname").propertyCount(2)
+	assert.NotContains(t, results[1]["accounts.Account"].Properties["nickname"].Extensions, "x-omitempty")
+}
@@ -1,8 +1,10 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/types"
 	"testing"
 
 	"github.com/go-openapi/spec"
@@ -164,7 +166,7 @@ func TestToSpecSchema_PrimitiveTypes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(tt.public, false, nil)
+			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(tt.public, SchemaOptions{}, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.wantPropName, propName)
 			assert.Equal(t, tt.wantRequired, required)
@@ -189,7 +191,7 @@ func TestToSpecSchema_StructField_Simple(t *testing.T) {
 		Tag:        `json:"properties"`,
 	}
 
-	propName, schema, required, nestedTypes, err := field.ToSpecSchema(false, false, nil)
+	propName, schema, required, nestedTypes, err := field.ToSpecSchema(false, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "properties", propName)
 	assert.True(t, required)
@@ -206,7 +208,7 @@ func TestToSpecSchema_StructField_Public(t *testing.T) {
 		Tag:        `public:"view" json:"user"`,
 	}
 
-	propName, schema, required, nestedTypes, err := field.ToSpecSchema(true, false, nil)
+	propName, schema, required, nestedTypes, err := field.ToSpecSchema(true, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "user", propName)
 	assert.True(t, required)
@@ -224,7 +226,7 @@ func TestToSpecSchema_StructField_NotPublic(t *testing.T) {
 	}
 
 	// When public=true but field has no public tag, should return nil
-	propName, schema, required, nestedTypes, err := field.ToSpecSchema(true, false, nil)
+	propName, schema, required, nestedTypes, err := field.ToSpecSchema(true, SchemaOptions{}, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "", propName)
 	assert.Nil(t, schema)
@@ -353,7 +355,7 @@ func TestBuildSchemaForType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			schema, nestedTypes, err := (&StructField{TypeString: tt.typeStr}).BuildSchema(tt.public, false, nil)
+			schema, nestedTypes, err := (&StructField{TypeString: tt.typeStr}).BuildSchema(tt.public, SchemaOptions{}, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, schema)
 
@@ -458,7 +460,7 @@ func TestToSpecSchema_ArrayElementTypes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(false, false, nil)
+			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(false, SchemaOptions{}, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, schema)
 			assert.True(t, required, "Array fields should be required by default")
@@ -532,7 +534,7 @@ func TestToSpecSchema_AnyInterfaceTypes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(false, false, nil)
+			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(false, SchemaOptions{}, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, schema)
 			assert.True(t, required, "Any/interface fields should be required by default")
@@ -618,7 +620,7 @@ func TestToSpecSchema_EnumWithUnderlyingType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(false, false, tt.enumLookup)
+			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(false, SchemaOptions{}, tt.enumLookup)
 			assert.NoError(t, err)
 			assert.NotNil(t, schema)
 			assert.True(t, required)
@@ -655,7 +657,7 @@ func TestPrimitiveSchema(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			schema := tt.field.PrimitiveSchema()
+			schema := tt.field.PrimitiveSchema(nil)
 			assert.Equal(t, tt.wantType, schema.Type[0])
 			if tt.wantFormat != "" {
 				assert.Equal(t, tt.wantFormat, schema.Format)
@@ -679,7 +681,7 @@ func TestFieldsWrapperSchema(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			schema, nested, err := tt.field.FieldsWrapperSchema(nil)
+			schema, nested, err := tt.field.FieldsWrapperSchema(SchemaOptions{}, nil)
 			assert.NoError(t, err)
 			assert.Nil(t, nested)
 			assert.Equal(t, tt.wantType, schema.Type[0])
@@ -779,7 +781,7 @@ func TestIsPrimitive(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.want, tt.field.IsPrimitive())
+			assert.Equal(t, tt.want, tt.field.IsPrimitive(nil))
 		})
 	}
 }
@@ -883,7 +885,7 @@ func TestBuildSchema(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			schema, nestedTypes, err := tt.field.BuildSchema(tt.public, false, nil)
+			schema, nestedTypes, err := tt.field.BuildSchema(tt.public, SchemaOptions{}, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, schema)
 
@@ -903,6 +905,83 @@ func TestBuildSchema(t *testing.T) {
 	}
 }
 
+func TestBuildSchema_NestedSlices(t *testing.T) {
+	t.Run("[][]string nests array items", func(t *testing.T) {
+		schema, nestedTypes, err := (&StructField{TypeString: "[][]string"}).BuildSchema(false, SchemaOptions{}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, nestedTypes)
+
+		assert.Equal(t, []string{"array"}, []string(schema.Type))
+		inner := schema.Items.Schema
+		assert.Equal(t, []string{"array"}, []string(inner.Type))
+		assert.Equal(t, []string{"string"}, []string(inner.Items.Schema.Type))
+	})
+
+	t.Run("[][]Account references the struct at the leaf", func(t *testing.T) {
+		schema, nestedTypes, err := (&StructField{TypeString: "[][]account.Account"}).BuildSchema(false, SchemaOptions{}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"account.Account"}, nestedTypes)
+
+		inner := schema.Items.Schema
+		assert.Equal(t, []string{"array"}, []string(inner.Type))
+		assert.Equal(t, "#/definitions/account.Account", inner.Items.Schema.Ref.String())
+	})
+
+	t.Run("[][]*Account strips the pointer at the leaf", func(t *testing.T) {
+		schema, nestedTypes, err := (&StructField{TypeString: "[][]*account.Account"}).BuildSchema(true, SchemaOptions{}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"account.AccountPublic"}, nestedTypes)
+
+		inner := schema.Items.Schema
+		assert.Equal(t, []string{"array"}, []string(inner.Type))
+		assert.Equal(t, "#/definitions/account.AccountPublic", inner.Items.Schema.Ref.String())
+	})
+
+	t.Run("[]map[string]Account nests a map schema", func(t *testing.T) {
+		schema, nestedTypes, err := (&StructField{TypeString: "[]map[string]account.Account"}).BuildSchema(false, SchemaOptions{}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"account.Account"}, nestedTypes)
+
+		inner := schema.Items.Schema
+		assert.Equal(t, []string{"object"}, []string(inner.Type))
+		assert.Equal(t, "#/definitions/account.Account", inner.AdditionalProperties.Schema.Ref.String())
+	})
+
+	t.Run("[][][]int64 nests every level", func(t *testing.T) {
+		schema, _, err := (&StructField{TypeString: "[][][]int64"}).BuildSchema(false, SchemaOptions{}, nil)
+		assert.NoError(t, err)
+
+		leaf := schema.Items.Schema.Items.Schema.Items.Schema
+		assert.Equal(t, []string{"integer"}, []string(leaf.Type))
+		assert.Equal(t, "int64", leaf.Format)
+	})
+}
+
+func TestBuildSchema_KeyPattern(t *testing.T) {
+	t.Run("keypattern sets x-pattern-properties next to additionalProperties", func(t *testing.T) {
+		field := &StructField{TypeString: "map[string]account.Account", Tag: `json:"accounts" keypattern:"^[a-z]+$"`}
+		schema, nestedTypes, err := field.BuildSchema(false, SchemaOptions{}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"account.Account"}, nestedTypes)
+
+		assert.Equal(t, "#/definitions/account.Account", schema.AdditionalProperties.Schema.Ref.String())
+		patterns, ok := schema.Extensions[patternPropertiesExtension].(map[string]*spec.Schema)
+		if assert.True(t, ok) {
+			assert.Same(t, schema.AdditionalProperties.Schema, patterns["^[a-z]+$"])
+		}
+
+		raw, err := json.Marshal(schema)
+		assert.NoError(t, err)
+		assert.Contains(t, string(raw), `"x-pattern-properties":{"^[a-z]+$":{"$ref":"#/definitions/account.Account"}}`)
+	})
+
+	t.Run("maps without keypattern have no extension", func(t *testing.T) {
+		schema, _, err := (&StructField{TypeString: "map[string]string", Tag: `json:"labels"`}).BuildSchema(false, SchemaOptions{}, nil)
+		assert.NoError(t, err)
+		assert.NotContains(t, schema.Extensions, patternPropertiesExtension)
+	})
+}
+
 func TestApplyStructTagsToSchema(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1115,7 +1194,7 @@ func TestBuildSchema_SwaggerType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			schema, nestedTypes, err := tt.field.BuildSchema(false, false, nil)
+			schema, nestedTypes, err := tt.field.BuildSchema(false, SchemaOptions{}, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -1186,7 +1265,7 @@ func TestToSpecSchema_SwaggerType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(false, false, nil)
+			propName, schema, required, nestedTypes, err := tt.field.ToSpecSchema(false, SchemaOptions{}, nil)
 
 			assert.NoError(t, err)
 			assert.Equal(t, tt.wantPropName, propName)
@@ -1199,3 +1278,434 @@ func TestToSpecSchema_SwaggerType(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildSchema_EmitNullable(t *testing.T) {
+	pkg := types.NewPackage("example.com/app/service", "service")
+	doer := types.NewNamed(
+		types.NewTypeName(0, pkg, "Doer", nil),
+		types.NewInterfaceType(nil, nil).Complete(),
+		nil,
+	)
+
+	tests := []struct {
+		name         string
+		field        *StructField
+		emitNullable bool
+		wantNullable bool
+		wantRef      bool
+	}{
+		{
+			name:         "any field is nullable when enabled",
+			field:        &StructField{Name: "Payload", TypeString: "any", Tag: `json:"payload"`},
+			emitNullable: true,
+			wantNullable: true,
+		},
+		{
+			name:         "interface{} field is nullable when enabled",
+			field:        &StructField{Name: "Payload", TypeString: "interface{}", Tag: `json:"payload"`},
+			emitNullable: true,
+			wantNullable: true,
+		},
+		{
+			name: "pointer to interface is nullable when enabled",
+			field: &StructField{
+				Name:       "Handler",
+				Type:       types.NewPointer(doer),
+				TypeString: "*example.com/app/service.Doer",
+				Tag:        `json:"handler"`,
+			},
+			emitNullable: true,
+			wantNullable: true,
+		},
+		{
+			name:         "any field is not nullable when disabled",
+			field:        &StructField{Name: "Payload", TypeString: "any", Tag: `json:"payload"`},
+			emitNullable: false,
+			wantNullable: false,
+		},
+		{
+			name: "non-pointer interface is unaffected",
+			field: &StructField{
+				Name:       "Handler",
+				Type:       doer,
+				TypeString: "example.com/app/service.Doer",
+				Tag:        `json:"handler"`,
+			},
+			emitNullable: true,
+			wantNullable: false,
+			wantRef:      true,
+		},
+		{
+			name:         "primitive is unaffected",
+			field:        &StructField{Name: "Name", TypeString: "*string", Tag: `json:"name"`},
+			emitNullable: true,
+			wantNullable: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := SchemaOptions{EmitNullable: tt.emitNullable}
+
+			_, schema, _, _, err := tt.field.ToSpecSchema(false, opts, nil)
+			assert.NoError(t, err)
+			assert.NotNil(t, schema)
+			assert.Equal(t, tt.wantNullable, schema.Nullable)
+			assert.Equal(t, tt.wantRef, schema.Ref.String() != "")
+		})
+	}
+}
+
+func TestBuildSchema_NullableSlices(t *testing.T) {
+	tests := []struct {
+		name              string
+		typeString        string
+		wantNullable      bool
+		wantItemsNullable bool
+	}{
+		{
+			name:         "pointer to slice is a nullable array",
+			typeString:   "*[]account.Account",
+			wantNullable: true,
+		},
+		{
+			name:              "slice of pointers has nullable items",
+			typeString:        "[]*account.Account",
+			wantItemsNullable: true,
+		},
+		{
+			name:              "pointer to slice of pointers is nullable at both levels",
+			typeString:        "*[]*string",
+			wantNullable:      true,
+			wantItemsNullable: true,
+		},
+		{
+			name:       "plain slice is unaffected",
+			typeString: "[]account.Account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Pointer slices are nullable without EmitNullable
+			schema, _, err := (&StructField{TypeString: tt.typeString}).BuildSchema(false, SchemaOptions{}, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"array"}, []string(schema.Type))
+			assert.Equal(t, tt.wantNullable, schema.Nullable)
+			assert.Equal(t, tt.wantItemsNullable, schema.Items.Schema.Nullable)
+		})
+	}
+}
+
+func TestBuildSchema_NamedPrimitive(t *testing.T) {
+	pkg := types.NewPackage("example.com/app/api", "api")
+	named := func(name string, underlying types.Type) *types.Named {
+		return types.NewNamed(types.NewTypeName(0, pkg, name, nil), underlying, nil)
+	}
+	email := named("Email", types.Typ[types.String])
+	count := named("Count", types.Typ[types.Int64])
+	role := named("Role", types.Typ[types.Int])
+	uuid := named("UUID", types.NewArray(types.Typ[types.Byte], 16))
+
+	enumLookup := &mockEnumLookup{
+		enums: map[string][]EnumValue{
+			"api.Role": {{Key: "RoleAdmin", Value: 1}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		field      *StructField
+		wantType   string
+		wantFormat string
+		wantRef    string
+	}{
+		{
+			name:       "string type resolves to string with format from its name",
+			field:      &StructField{Name: "Email", Type: email, TypeString: email.String(), Tag: `json:"email"`},
+			wantType:   "string",
+			wantFormat: "email",
+		},
+		{
+			name:       "int64 type resolves to integer",
+			field:      &StructField{Name: "Count", Type: count, TypeString: count.String(), Tag: `json:"count"`},
+			wantType:   "integer",
+			wantFormat: "int64",
+		},
+		{
+			name: "pointer resolves to the underlying type",
+			field: &StructField{
+				Name:       "Count",
+				Type:       types.NewPointer(count),
+				TypeString: "*" + count.String(),
+				Tag:        `json:"count"`,
+			},
+			wantType:   "integer",
+			wantFormat: "int64",
+		},
+		{
+			name:       "byte array named UUID resolves to a uuid string",
+			field:      &StructField{Name: "ID", Type: uuid, TypeString: uuid.String(), Tag: `json:"id"`},
+			wantType:   "string",
+			wantFormat: "uuid",
+		},
+		{
+			name:    "enum type keeps its $ref",
+			field:   &StructField{Name: "Role", Type: role, TypeString: "api.Role", Tag: `json:"role"`},
+			wantRef: "#/definitions/api.Role",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, nestedTypes, err := tt.field.BuildSchema(false, SchemaOptions{}, enumLookup)
+			assert.NoError(t, err)
+			if !assert.NotNil(t, schema) {
+				return
+			}
+
+			if tt.wantRef != "" {
+				assert.Equal(t, tt.wantRef, schema.Ref.String())
+				return
+			}
+			assert.Empty(t, schema.Ref.String())
+			assert.Empty(t, nestedTypes)
+			assert.Equal(t, []string{tt.wantType}, []string(schema.Type))
+			assert.Equal(t, tt.wantFormat, schema.Format)
+		})
+	}
+
+	t.Run("map values resolve to the underlying type", func(t *testing.T) {
+		field := &StructField{
+			Name:       "Emails",
+			Type:       types.NewMap(types.Typ[types.String], email),
+			TypeString: "map[string]" + email.String(),
+			Tag:        `json:"emails"`,
+		}
+		schema, nestedTypes, err := field.BuildSchema(false, SchemaOptions{}, enumLookup)
+		assert.NoError(t, err)
+		assert.Empty(t, nestedTypes)
+		if !assert.NotNil(t, schema) || !assert.NotNil(t, schema.AdditionalProperties) {
+			return
+		}
+		assert.Equal(t, []string{"object"}, []string(schema.Type))
+		value := schema.AdditionalProperties.Schema
+		if !assert.NotNil(t, value) {
+			return
+		}
+		assert.Empty(t, value.Ref.String())
+		assert.Equal(t, []string{"string"}, []string(value.Type))
+		assert.Equal(t, "email", value.Format)
+	})
+}
+
+func TestToSpecSchema_JSONTagOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		field        *StructField
+		wantPropName string
+		wantType     string
+		wantFormat   string
+		wantRequired bool
+	}{
+		{
+			name:         "omitempty and string together",
+			field:        &StructField{Name: "Count", TypeString: "int64", Tag: `json:"count,omitempty,string"`},
+			wantPropName: "count",
+			wantType:     "string",
+			wantFormat:   "int64",
+			wantRequired: false,
+		},
+		{
+			name:         "string option on bool",
+			field:        &StructField{Name: "Enabled", TypeString: "bool", Tag: `json:"enabled,string"`},
+			wantPropName: "enabled",
+			wantType:     "string",
+			wantRequired: true,
+		},
+		{
+			name:         "string option leaves strings untouched",
+			field:        &StructField{Name: "Label", TypeString: "string", Tag: `json:"label,string,omitempty"`},
+			wantPropName: "label",
+			wantType:     "string",
+			wantRequired: false,
+		},
+		{
+			name:         "empty name falls back to field name",
+			field:        &StructField{Name: "Total", TypeString: "int", Tag: `json:",omitempty"`},
+			wantPropName: "Total",
+			wantType:     "integer",
+			wantRequired: false,
+		},
+		{
+			name:         "dash with comma names the property dash",
+			field:        &StructField{Name: "Dash", TypeString: "string", Tag: `json:"-,"`},
+			wantPropName: "-",
+			wantType:     "string",
+			wantRequired: true,
+		},
+		{
+			name:         "dash with options names the property dash",
+			field:        &StructField{Name: "Dash", TypeString: "string", Tag: `json:"-,omitempty"`},
+			wantPropName: "-",
+			wantType:     "string",
+			wantRequired: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			propName, schema, required, _, err := tt.field.ToSpecSchema(false, SchemaOptions{}, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPropName, propName)
+			assert.Equal(t, tt.wantRequired, required)
+			if assert.NotNil(t, schema) {
+				assert.Equal(t, spec.StringOrArray{tt.wantType}, schema.Type)
+				assert.Equal(t, tt.wantFormat, schema.Format)
+			}
+		})
+	}
+}
+
+func TestToSpecSchema_JSONTagDash(t *testing.T) {
+	field := &StructField{Name: "Secret", TypeString: "string", Tag: `json:"-"`}
+
+	propName, schema, _, _, err := field.ToSpecSchema(false, SchemaOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, propName)
+	assert.Nil(t, schema)
+}
+
+func TestToSpecSchema_PropNamingStrategy(t *testing.T) {
+	tests := []struct {
+		strategy     string
+		fieldName    string
+		wantPropName string
+	}{
+		{strategy: "", fieldName: "FirstName", wantPropName: "FirstName"},
+		{strategy: "camelcase", fieldName: "FirstName", wantPropName: "firstName"},
+		{strategy: "snakecase", fieldName: "FirstName", wantPropName: "first_name"},
+		{strategy: "pascalcase", fieldName: "FirstName", wantPropName: "FirstName"},
+		{strategy: "camelcase", fieldName: "ID", wantPropName: "id"},
+		{strategy: "camelcase", fieldName: "HTTPServer", wantPropName: "httpServer"},
+		{strategy: "snakecase", fieldName: "UserID", wantPropName: "user_id"},
+		{strategy: "snakecase", fieldName: "HTTPServer", wantPropName: "http_server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy+" "+tt.fieldName, func(t *testing.T) {
+			opts := SchemaOptions{PropNamingStrategy: tt.strategy}
+
+			field := &StructField{Name: tt.fieldName, TypeString: "string", Tag: `json:",omitempty"`}
+			propName, _, _, _, err := field.ToSpecSchema(false, opts, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPropName, propName)
+		})
+	}
+
+	t.Run("fields without a json tag are named by the strategy", func(t *testing.T) {
+		opts := SchemaOptions{PropNamingStrategy: "snakecase"}
+
+		field := &StructField{Name: "FirstName", TypeString: "string"}
+		propName, schema, _, _, err := field.ToSpecSchema(false, opts, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "first_name", propName)
+		assert.NotNil(t, schema)
+
+		unexported := &StructField{Name: "firstName", TypeString: "string"}
+		propName, schema, _, _, err = unexported.ToSpecSchema(false, opts, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, propName)
+		assert.Nil(t, schema)
+	})
+
+	t.Run("explicit json names are kept", func(t *testing.T) {
+		opts := SchemaOptions{PropNamingStrategy: "snakecase"}
+
+		field := &StructField{Name: "FirstName", TypeString: "string", Tag: `json:"firstName"`}
+		propName, _, _, _, err := field.ToSpecSchema(false, opts, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "firstName", propName)
+	})
+}
+
+func TestBuildSchema_InlineEnums(t *testing.T) {
+	enumLookup := &mockEnumLookup{
+		enums: map[string][]EnumValue{
+			"constants.Role": {
+				{Key: "RoleAdmin", Value: 1, Comment: "Administrator"},
+				{Key: "RoleUser", Value: 2, Comment: "Regular user"},
+			},
+			"constants.Color": {
+				{Key: "ColorRed", Value: "red"},
+				{Key: "ColorBlue", Value: "blue"},
+			},
+		},
+	}
+
+	t.Run("references enum definition by default", func(t *testing.T) {
+		field := &StructField{Name: "Role", TypeString: "constants.Role", Tag: `json:"role"`}
+
+		schema, nested, err := field.BuildSchema(false, SchemaOptions{}, enumLookup)
+		assert.NoError(t, err)
+		assert.Equal(t, "#/definitions/constants.Role", schema.Ref.String())
+		assert.Equal(t, []string{"constants.Role"}, nested)
+	})
+
+	t.Run("inlines values with varnames and descriptions", func(t *testing.T) {
+		opts := SchemaOptions{InlineEnums: true}
+
+		field := &StructField{Name: "Role", TypeString: "constants.Role", Tag: `json:"role"`}
+
+		schema, nested, err := field.BuildSchema(false, opts, enumLookup)
+		assert.NoError(t, err)
+		assert.Nil(t, nested)
+		assert.Empty(t, schema.Ref.String())
+		assert.Equal(t, spec.StringOrArray{"integer"}, schema.Type)
+		assert.Equal(t, []interface{}{1, 2}, schema.Enum)
+		assert.Equal(t, []string{"RoleAdmin", "RoleUser"}, schema.Extensions["x-enum-varnames"])
+		assert.Equal(t, []string{"Administrator", "Regular user"}, schema.Extensions["x-enum-descriptions"])
+	})
+
+	t.Run("inlines string enums without descriptions", func(t *testing.T) {
+		opts := SchemaOptions{InlineEnums: true}
+
+		field := &StructField{Name: "Color", TypeString: "constants.Color", Tag: `json:"color"`}
+
+		schema, _, err := field.BuildSchema(false, opts, enumLookup)
+		assert.NoError(t, err)
+		assert.Equal(t, spec.StringOrArray{"string"}, schema.Type)
+		assert.Equal(t, []string{"ColorRed", "ColorBlue"}, schema.Extensions["x-enum-varnames"])
+		assert.NotContains(t, schema.Extensions, "x-enum-descriptions")
+	})
+}
+
+func TestBuildSchema_StrictEnums(t *testing.T) {
+	enumLookup := &mockEnumLookup{
+		enums: map[string][]EnumValue{
+			"constants.Role": {{Key: "RoleAdmin", Value: 1}},
+		},
+	}
+	missing := &StructField{Name: "Status", TypeString: "*fields.IntConstantField[constants.Status]", Tag: `json:"status"`}
+
+	t.Run("falls back to the base type by default", func(t *testing.T) {
+		schema, _, err := missing.BuildSchema(false, SchemaOptions{}, enumLookup)
+		assert.NoError(t, err)
+		assert.Equal(t, spec.StringOrArray{"integer"}, schema.Type)
+	})
+
+	t.Run("fails for enums without values", func(t *testing.T) {
+		opts := SchemaOptions{StrictEnums: true}
+
+		builder := &StructBuilder{Fields: []*StructField{missing}}
+		_, _, err := builder.BuildSpecSchema("Account", false, opts, enumLookup)
+		assert.ErrorIs(t, err, ErrEmptyEnum)
+		assert.ErrorContains(t, err, "field Status")
+		assert.ErrorContains(t, err, "constants.Status")
+
+		field := &StructField{Name: "Role", TypeString: "*fields.IntConstantField[constants.Role]", Tag: `json:"role"`}
+		schema, _, err := field.BuildSchema(false, opts, enumLookup)
+		assert.NoError(t, err)
+		assert.Equal(t, "#/definitions/constants.Role", schema.Ref.String())
+	})
+}
@@ -0,0 +1,163 @@
+package model
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// validationFormats maps go-playground/validator rules to OpenAPI string formats.
+var validationFormats = map[string]string{
+	"email": "email",
+	"url":   "uri",
+	"uri":   "uri",
+	"uuid":  "uuid",
+	"uuid3": "uuid",
+	"uuid4": "uuid",
+	"uuid5": "uuid",
+}
+
+// applyValidationTags translates go-playground/validator rules from the
+// `validate` and `binding` tags into schema constraints.
+// Supported rules: min, max, len, gt, gte, lt, lte, multipleof, unique, email, url/uri, uuid and regexp.
+// Unknown rules are ignored.
+func applyValidationTags(schema *spec.Schema, tags map[string]string) {
+	for _, key := range []string{"validate", "binding"} {
+		if rules, ok := tags[key]; ok {
+			applyValidationRules(schema, rules)
+		}
+	}
+}
+
+// hasRequiredRule reports whether the `validate` or `binding` tag lists the
+// "required" rule for the field itself, not for collection elements after "dive".
+func hasRequiredRule(tags map[string]string) bool {
+	for _, key := range []string{"validate", "binding"} {
+		for _, rule := range strings.Split(tags[key], ",") {
+			rule = strings.TrimSpace(rule)
+			if rule == "dive" {
+				break
+			}
+			if rule == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyValidationRules applies a comma-separated validator rule list to the schema.
+// Rules after "dive" target collection elements and are not applied to the field itself.
+func applyValidationRules(schema *spec.Schema, rules string) {
+	schemaType := ""
+	if len(schema.Type) > 0 {
+		schemaType = schema.Type[0]
+	}
+
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "dive" {
+			return
+		}
+		// Alternatives (e.g. "email|url") can't be expressed as a single constraint
+		if rule == "" || strings.Contains(rule, "|") {
+			continue
+		}
+
+		name, value, _ := strings.Cut(rule, "=")
+		// Validator escapes literal commas inside rule values as 0x2C
+		value = strings.ReplaceAll(value, "0x2C", ",")
+
+		switch name {
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			applyValidationBound(schema, schemaType, name, value)
+		case "multipleof":
+			if schemaType != "integer" && schemaType != "number" {
+				continue
+			}
+			// multipleOf must be strictly positive; Inf/NaN are not valid JSON
+			if n, err := strconv.ParseFloat(value, 64); err == nil && n > 0 && !math.IsInf(n, 0) {
+				schema.MultipleOf = &n
+			}
+		case "unique":
+			if schemaType == "array" {
+				schema.UniqueItems = true
+			}
+		case "regexp":
+			if value != "" && schemaType == "string" {
+				schema.Pattern = value
+			}
+		default:
+			if format, ok := validationFormats[name]; ok && schemaType == "string" && schema.Format == "" {
+				schema.Format = format
+			}
+		}
+	}
+}
+
+// applyValidationBound applies a min/max/len/gt/gte/lt/lte rule as a length
+// constraint for strings, an item count constraint for arrays, a key count
+// constraint for maps, or a range constraint for numbers. Strict bounds (gt/lt)
+// become exclusive ranges for numbers and are shifted by one for counts.
+func applyValidationBound(schema *spec.Schema, schemaType, name, value string) {
+	isLower := name == "min" || name == "len" || name == "gt" || name == "gte"
+	isUpper := name == "max" || name == "len" || name == "lt" || name == "lte"
+
+	// Only maps count keys; other objects ($ref'd structs) have no size to bound
+	if schemaType == "object" && schema.AdditionalProperties == nil {
+		return
+	}
+
+	switch schemaType {
+	case "string", "array", "object":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return
+		}
+		if isLower {
+			minLen := n
+			if name == "gt" {
+				minLen++
+			}
+			switch schemaType {
+			case "array":
+				schema.MinItems = &minLen
+			case "object":
+				schema.MinProperties = &minLen
+			default:
+				schema.MinLength = &minLen
+			}
+		}
+		if isUpper {
+			maxLen := n
+			if name == "lt" {
+				maxLen--
+			}
+			switch schemaType {
+			case "array":
+				schema.MaxItems = &maxLen
+			case "object":
+				schema.MaxProperties = &maxLen
+			default:
+				schema.MaxLength = &maxLen
+			}
+		}
+	case "integer", "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return
+		}
+		if isLower {
+			minVal := n
+			schema.Minimum = &minVal
+			schema.ExclusiveMinimum = name == "gt"
+		}
+		if isUpper {
+			maxVal := n
+			schema.Maximum = &maxVal
+			schema.ExclusiveMaximum = name == "lt"
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package orchestrator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/griffnb/core-swag/internal/model"
+)
+
+const (
+	benchTypeCount  = 500
+	benchRouteCount = 100
+)
+
+// writeBenchFixture writes a module with benchTypeCount model types, each
+// embedding a few scalar fields and a list of the previous type, and
+// benchRouteCount annotated handlers that reference them. Returns the
+// search dir and the main API file.
+func writeBenchFixture(tb testing.TB) (string, string) {
+	tb.Helper()
+
+	files := map[string]string{
+		"go.mod": "module example.com/benchapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Bench API
+// @version 1.0
+func main() {}
+`,
+	}
+
+	var models strings.Builder
+	models.WriteString("package models\n\nimport \"time\"\n")
+	for i := 0; i < benchTypeCount; i++ {
+		fmt.Fprintf(&models, "\n// Model%d is a generated benchmark model.\ntype Model%d struct {\n", i, i)
+		fmt.Fprintf(&models, "\tID string `json:\"id\"`\n\tName string `json:\"name\" validate:\"min=1,max=64\"`\n")
+		fmt.Fprintf(&models, "\tCount int64 `json:\"count\"`\n\tTags []string `json:\"tags\"`\n\tCreatedAt time.Time `json:\"created_at\"`\n")
+		if i > 0 {
+			fmt.Fprintf(&models, "\tRelated []Model%d `json:\"related\"`\n", i-1)
+		}
+		models.WriteString("}\n")
+	}
+	files["models/models.go"] = models.String()
+
+	var handlers strings.Builder
+	handlers.WriteString("package handlers\n\nimport _ \"example.com/benchapi/models\"\n")
+	for i := 0; i < benchRouteCount; i++ {
+		model := i * benchTypeCount / benchRouteCount
+		fmt.Fprintf(&handlers, `
+// @Summary Get model %d
+// @Tags bench
+// @Param id path string true "ID"
+// @Success 200 {object} models.Model%d
+// @Failure 400 {object} models.Model%d
+// @Router /models/%d/{id} [get]
+func GetModel%d() {}
+`, i, model+benchTypeCount/benchRouteCount-1, model, i, i)
+	}
+	files["handlers/handlers.go"] = handlers.String()
+
+	dir := writeModule(tb, files)
+
+	return dir, filepath.Join(dir, "main.go")
+}
+
+// BenchmarkService_Parse measures a cold parse: the package cache is reset
+// before every iteration, as each CLI run starts with an empty cache.
+func BenchmarkService_Parse(b *testing.B) {
+	dir, mainFile := writeBenchFixture(b)
+	// Packages are loaded relative to the working directory, as when run from a project root
+	b.Chdir(dir)
+
+	// Check the fixture once so a broken fixture fails instead of benchmarking nothing
+	swagger, err := New(&Config{}).Parse([]string{dir}, mainFile, 0)
+	if err != nil {
+		b.Fatalf("parse failed: %v", err)
+	}
+	if len(swagger.Definitions) < benchTypeCount {
+		b.Fatalf("expected at least %d definitions, got %d", benchTypeCount, len(swagger.Definitions))
+	}
+	if len(swagger.Definitions["models.Model1"].Properties) == 0 {
+		b.Fatal("expected model properties to be resolved")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		model.Cache().Reset()
+		if _, err := New(&Config{}).Parse([]string{dir}, mainFile, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
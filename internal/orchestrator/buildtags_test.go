@@ -0,0 +1,89 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/griffnb/core-swag/internal/model"
+)
+
+func TestParse_BuildTags(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/buildtagsapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Build Tags API
+// @version 1.0
+func main() {}
+`,
+		"api/api.go": `package api
+
+// @Success 200 {string} string
+// @Router /always [get]
+func GetAlways() {}
+`,
+		"api/integration.go": `//go:build integration
+
+package api
+
+type Report struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// @Success 200 {object} Report
+// @Router /integration [get]
+func GetIntegration() {}
+`,
+		"api/default.go": `//go:build !integration
+
+package api
+
+// @Success 200 {string} string
+// @Router /default [get]
+func GetDefault() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	tests := []struct {
+		name            string
+		parseGoPackages bool
+		buildTags       []string
+		want            []string
+		wantNot         []string
+	}{
+		{name: "go/packages without tags", parseGoPackages: true, want: []string{"/always", "/default"}, wantNot: []string{"/integration"}},
+		{name: "go/packages with tags", parseGoPackages: true, buildTags: []string{"integration"}, want: []string{"/always", "/integration"}, wantNot: []string{"/default"}},
+		{name: "directory walk with tags", buildTags: []string{"integration"}, want: []string{"/always", "/integration"}, wantNot: []string{"/default"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model.Cache().Reset()
+
+			service := New(&Config{ParseGoPackages: tt.parseGoPackages, BuildTags: tt.buildTags})
+			swagger, err := service.Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, path := range tt.want {
+				if _, ok := swagger.Paths.Paths[path]; !ok {
+					t.Errorf("expected path %s", path)
+				}
+			}
+			for _, path := range tt.wantNot {
+				if _, ok := swagger.Paths.Paths[path]; ok {
+					t.Errorf("expected no path %s", path)
+				}
+			}
+
+			if len(tt.buildTags) > 0 {
+				if _, ok := swagger.Definitions["api.Report"].Properties["name"]; !ok {
+					t.Errorf("expected api.Report from the tagged file, got %+v", swagger.Definitions["api.Report"])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
+	"github.com/griffnb/core-swag/internal/schema"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+// ErrDanglingRef is returned in strict mode when the spec references a definition that was never generated.
+var ErrDanglingRef = errors.New("unresolved $ref")
+
+// refSource is a place in the spec that holds $refs, used to report where a dangling ref came from.
+type refSource struct {
+	name string
+	refs map[string]bool
+	file string
+	line int
+}
+
+// checkDanglingRefs reports every local $ref whose target is missing from the
+// definitions, e.g. a type from an external package parsed without --parseDependency.
+// Dangling refs fail in strict mode and are recorded as warnings otherwise.
+func (s *Service) checkDanglingRefs(routes []*routedomain.Route) error {
+	for _, source := range s.refSources(routes) {
+		var missing []string
+		for name := range source.refs {
+			if _, exists := s.swagger.Definitions[name]; !exists {
+				missing = append(missing, name)
+			}
+		}
+		sort.Strings(missing)
+
+		for _, name := range missing {
+			msg := fmt.Sprintf("%s references #/definitions/%s, which was not generated", source.name, name)
+			if s.config.Strict {
+				return fmt.Errorf("%w: %s", ErrDanglingRef, msg)
+			}
+			s.addWarning(Warning{
+				Category: WarningDanglingRef,
+				Message:  msg,
+				File:     source.file,
+				Line:     source.line,
+			})
+		}
+	}
+
+	return nil
+}
+
+// refSources lists the definitions, operations, shared parameters and shared
+// responses of the spec with the refs they hold, in a stable order.
+func (s *Service) refSources(routes []*routedomain.Route) []refSource {
+	positions := make(map[string]*routedomain.Route, len(routes))
+	for _, r := range routes {
+		if r != nil {
+			positions[strings.ToUpper(r.Method)+" "+r.Path] = r
+		}
+	}
+
+	var sources []refSource
+
+	for _, name := range schemautil.SortedKeys(s.swagger.Definitions) {
+		sources = append(sources, refSource{
+			name: "definition " + name,
+			refs: schema.ReferencedDefinitions(s.swagger.Definitions[name]),
+		})
+	}
+
+	if s.swagger.Paths != nil {
+		paths := make([]string, 0, len(s.swagger.Paths.Paths))
+		for path := range s.swagger.Paths.Paths {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			item := s.swagger.Paths.Paths[path]
+			for _, method := range []struct {
+				name string
+				op   *spec.Operation
+			}{
+				{"GET", item.Get}, {"PUT", item.Put}, {"POST", item.Post}, {"DELETE", item.Delete},
+				{"OPTIONS", item.Options}, {"HEAD", item.Head}, {"PATCH", item.Patch},
+				{"TRACE", schema.TraceOperation(item)},
+			} {
+				if method.op == nil {
+					continue
+				}
+				source := refSource{
+					name: method.name + " " + path,
+					refs: schema.ReferencedDefinitions(method.op),
+				}
+				if r, ok := positions[source.name]; ok {
+					source.file = r.FilePath
+					source.line = r.LineNumber
+				}
+				sources = append(sources, source)
+			}
+		}
+	}
+
+	for _, name := range schemautil.SortedKeys(s.swagger.Parameters) {
+		sources = append(sources, refSource{
+			name: "parameter " + name,
+			refs: schema.ReferencedDefinitions(s.swagger.Parameters[name]),
+		})
+	}
+
+	for _, name := range schemautil.SortedKeys(s.swagger.Responses) {
+		sources = append(sources, refSource{
+			name: "response " + name,
+			refs: schema.ReferencedDefinitions(s.swagger.Responses[name]),
+		})
+	}
+
+	return sources
+}
@@ -0,0 +1,102 @@
+package orchestrator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
+)
+
+func newDanglingRefSpec() *spec.Swagger {
+	swagger := newSwaggerSpec()
+	swagger.Definitions = spec.Definitions{
+		"api.User": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: spec.SchemaProperties{
+					"account": *spec.RefSchema("#/definitions/external.Account"),
+					"self":    *spec.RefSchema("#/definitions/api.User"),
+				},
+			},
+		},
+	}
+	swagger.Paths = &spec.Paths{
+		Paths: map[string]spec.PathItem{
+			"/users": {
+				PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Responses: &spec.Responses{
+								ResponsesProps: spec.ResponsesProps{
+									StatusCodeResponses: map[int]spec.Response{
+										200: {ResponseProps: spec.ResponseProps{Schema: spec.RefSchema("#/definitions/api.Missing")}},
+										201: {ResponseProps: spec.ResponseProps{Schema: spec.RefSchema("#/definitions/api.User")}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return swagger
+}
+
+func TestCheckDanglingRefs(t *testing.T) {
+	routes := []*routedomain.Route{
+		{Method: "GET", Path: "/users", FilePath: "users.go", LineNumber: 12},
+	}
+
+	t.Run("warns for each missing definition with its referrer", func(t *testing.T) {
+		svc := newTestService()
+		svc.swagger = newDanglingRefSpec()
+
+		if err := svc.checkDanglingRefs(routes); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		warnings := svc.Warnings()
+		if len(warnings) != 2 {
+			t.Fatalf("expected 2 warnings, got %d: %v", len(warnings), warnings)
+		}
+		for _, warning := range warnings {
+			if warning.Category != WarningDanglingRef {
+				t.Errorf("unexpected category %q", warning.Category)
+			}
+		}
+		if want := "definition api.User references #/definitions/external.Account, which was not generated"; warnings[0].Message != want {
+			t.Errorf("unexpected message %q", warnings[0].Message)
+		}
+		if want := "GET /users references #/definitions/api.Missing, which was not generated"; warnings[1].Message != want {
+			t.Errorf("unexpected message %q", warnings[1].Message)
+		}
+		if warnings[1].File != "users.go" || warnings[1].Line != 12 {
+			t.Errorf("expected warning at users.go:12, got %s:%d", warnings[1].File, warnings[1].Line)
+		}
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		svc := newTestService()
+		svc.config.Strict = true
+		svc.swagger = newDanglingRefSpec()
+
+		err := svc.checkDanglingRefs(routes)
+		if !errors.Is(err, ErrDanglingRef) {
+			t.Fatalf("expected ErrDanglingRef, got %v", err)
+		}
+	})
+
+	t.Run("passes when all refs resolve", func(t *testing.T) {
+		svc := newTestService()
+		svc.config.Strict = true
+		svc.swagger = newDanglingRefSpec()
+		svc.swagger.Definitions["external.Account"] = spec.Schema{}
+		svc.swagger.Definitions["api.Missing"] = spec.Schema{}
+
+		if err := svc.checkDanglingRefs(routes); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
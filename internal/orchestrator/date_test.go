@@ -0,0 +1,91 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+)
+
+func TestParse_DateFormats(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/dateapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Date API
+// @version 1.0
+func main() {}
+`,
+		"api/api.go": `package api
+
+import "time"
+
+// DateOnly is a calendar date without a time.
+type DateOnly time.Time
+
+// Timestamp is a point in time.
+type Timestamp time.Time
+
+type Event struct {
+	Day      DateOnly   ` + "`json:\"day\"`" + `
+	Until    *DateOnly  ` + "`json:\"until\"`" + `
+	Holidays []DateOnly ` + "`json:\"holidays\"`" + `
+	Seen     Timestamp  ` + "`json:\"seen\"`" + `
+	Birthday time.Time  ` + "`json:\"birthday\" format:\"date\"`" + `
+	At       time.Time  ` + "`json:\"at\"`" + `
+}
+
+// @Param on query DateOnly false "on"
+// @Param since query time.Time false "since"
+// @Success 200 {object} Event
+// @Router /events [get]
+func GetEvent() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	swagger, err := New(&Config{}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"api.DateOnly", "api.Timestamp"} {
+		if _, ok := swagger.Definitions[name]; ok {
+			t.Errorf("expected no %s definition", name)
+		}
+	}
+
+	event := swagger.Definitions["api.Event"]
+	holidays := event.Properties["holidays"]
+	if holidays.Items == nil || holidays.Items.Schema == nil {
+		t.Fatalf("expected holidays to be an array, got %+v", holidays)
+	}
+
+	wantFormats := map[string]string{"date": "date", "date-time": "date-time"}
+	for name, tt := range map[string]struct {
+		schema spec.Schema
+		format string
+	}{
+		"day":          {event.Properties["day"], "date"},
+		"until":        {event.Properties["until"], "date"},
+		"holiday item": {*holidays.Items.Schema, "date"},
+		"seen":         {event.Properties["seen"], "date-time"},
+		"birthday":     {event.Properties["birthday"], "date"},
+		"at":           {event.Properties["at"], "date-time"},
+	} {
+		if !tt.schema.Type.Contains("string") || tt.schema.Format != wantFormats[tt.format] {
+			t.Errorf("%s: expected a %s string, got %+v", name, tt.format, tt.schema.SchemaProps)
+		}
+	}
+
+	params := swagger.Paths.Paths["/events"].Get.Parameters
+	if len(params) != 2 {
+		t.Fatalf("expected 2 parameters, got %+v", params)
+	}
+	for i, format := range []string{"date", "date-time"} {
+		if params[i].Type != "string" || params[i].Format != format {
+			t.Errorf("%s: expected a %s string, got type %q and format %q", params[i].Name, format, params[i].Type, params[i].Format)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/schema"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+// dedupeIdenticalDefinitions merges definitions of the same type from different
+// packages whose schemas are exactly equal, e.g. a.Pagination and b.Pagination,
+// into the first name in sorted order and rewrites every $ref to the others.
+// Definitions with different type names are never merged, even when equal.
+// Merging can make the definitions referencing the merged ones equal too, so it
+// repeats until nothing changes.
+func (s *Service) dedupeIdenticalDefinitions() {
+	merged := 0
+	for {
+		renames := identicalDefinitions(s.swagger.Definitions)
+		if len(renames) == 0 {
+			break
+		}
+
+		for name := range renames {
+			delete(s.swagger.Definitions, name)
+		}
+		schema.RenameDefinitions(s.swagger, renames)
+		merged += len(renames)
+	}
+
+	if merged > 0 && s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Merged %d identical definitions", merged)
+	}
+}
+
+// identicalDefinitions maps every package qualified definition that equals an
+// earlier one of the same type name to that one. Schemas are compared by their
+// JSON encoding, which orders properties and map keys, without their title.
+func identicalDefinitions(definitions spec.Definitions) map[string]string {
+	canonical := make(map[string]string)
+	renames := make(map[string]string)
+
+	for _, name := range schemautil.SortedKeys(definitions) {
+		dot := strings.LastIndex(name, ".")
+		if dot < 0 {
+			continue
+		}
+
+		// The title is generated from the package and type name, so it differs anyway
+		def := definitions[name]
+		def.Title = ""
+		data, err := json.Marshal(def)
+		if err != nil {
+			continue
+		}
+
+		key := name[dot+1:] + "\n" + string(data)
+		if first, ok := canonical[key]; ok {
+			renames[name] = first
+		} else {
+			canonical[key] = name
+		}
+	}
+
+	return renames
+}
@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/model"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+func TestParse_DedupeIdenticalDefinitions(t *testing.T) {
+	pagination := func(pkg string) string {
+		return `package ` + pkg + `
+
+type Link struct {
+	Rel  string ` + "`json:\"rel\"`" + `
+	Href string ` + "`json:\"href\"`" + `
+}
+
+type Pagination struct {
+	Page  int    ` + "`json:\"page\"`" + `
+	Total int    ` + "`json:\"total\"`" + `
+	Links []Link ` + "`json:\"links\"`" + `
+}
+`
+	}
+
+	files := map[string]string{
+		"go.mod": "module example.com/dedupeapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Dedupe API
+// @version 1.0
+func main() {}
+`,
+		"a/a.go": pagination("a"),
+		"b/b.go": pagination("b"),
+		"c/c.go": `package c
+
+// Paging has the fields of Pagination under another name
+type Paging struct {
+	Page  int ` + "`json:\"page\"`" + `
+	Total int ` + "`json:\"total\"`" + `
+}
+
+// Link has the name of a.Link but another schema
+type Link struct {
+	Href string ` + "`json:\"href\"`" + `
+}
+`,
+		"api/api.go": `package api
+
+import (
+	_ "example.com/dedupeapi/a"
+	_ "example.com/dedupeapi/b"
+	_ "example.com/dedupeapi/c"
+)
+
+// @Success 200 {object} a.Pagination
+// @Router /a [get]
+func GetA() {}
+
+// @Success 200 {object} b.Pagination
+// @Router /b [get]
+func GetB() {}
+
+// @Success 200 {object} c.Paging
+// @Router /c/paging [get]
+func GetPaging() {}
+
+// @Success 200 {object} c.Link
+// @Router /c/link [get]
+func GetLink() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	parse := func(t *testing.T, dedupe bool) *spec.Swagger {
+		t.Helper()
+		model.Cache().Reset()
+		swagger, err := New(&Config{DedupeIdenticalDefinitions: dedupe}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return swagger
+	}
+
+	t.Run("keeps identical definitions unless enabled", func(t *testing.T) {
+		swagger := parse(t, false)
+		if got := strings.Join(schemautil.SortedKeys(swagger.Definitions), ","); got != "a.Link,a.LinkPublic,a.Pagination,a.PaginationPublic,b.Link,b.LinkPublic,b.Pagination,b.PaginationPublic,c.Link,c.LinkPublic,c.Paging,c.PagingPublic" {
+			t.Errorf("unexpected definitions %s", got)
+		}
+	})
+
+	t.Run("merges identical definitions of the same type", func(t *testing.T) {
+		// b.Link equals a.Link, which makes b.Pagination equal a.Pagination
+		swagger := parse(t, true)
+		if got := strings.Join(schemautil.SortedKeys(swagger.Definitions), ","); got != "a.Link,a.LinkPublic,a.Pagination,a.PaginationPublic,c.Link,c.LinkPublic,c.Paging,c.PagingPublic" {
+			t.Errorf("unexpected definitions %s", got)
+		}
+
+		var refs []string
+		for path, item := range swagger.Paths.Paths {
+			refs = append(refs, path+" "+item.Get.Responses.StatusCodeResponses[200].Schema.Ref.String())
+		}
+		sort.Strings(refs)
+		want := "/a #/definitions/a.Pagination,/b #/definitions/a.Pagination,/c/link #/definitions/c.Link,/c/paging #/definitions/c.Paging"
+		if got := strings.Join(refs, ","); got != want {
+			t.Errorf("expected refs %s, got %s", want, got)
+		}
+	})
+}
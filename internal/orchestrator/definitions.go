@@ -0,0 +1,92 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+// ErrDefinitionConflict is returned when an extra definition clashes with another definition of the same name.
+var ErrDefinitionConflict = errors.New("definition conflict")
+
+// AddDefinition registers an extra schema definition, e.g. for a type defined outside
+// the parsed tree. Registered definitions are merged into the spec by Parse.
+// Registering the same name twice is an error.
+func (s *Service) AddDefinition(name string, schema spec.Schema) error {
+	if err := ValidateDefinition(s.extraDefinitions, name); err != nil {
+		return err
+	}
+
+	if s.extraDefinitions == nil {
+		s.extraDefinitions = make(map[string]spec.Schema)
+	}
+	s.extraDefinitions[name] = schema
+
+	return nil
+}
+
+// ValidateDefinition checks that an extra definition can be registered under name
+// next to the registered definitions: the name must be set and not yet taken.
+func ValidateDefinition(registered map[string]spec.Schema, name string) error {
+	if name == "" {
+		return fmt.Errorf("definition name must not be empty")
+	}
+	if _, exists := registered[name]; exists {
+		return fmt.Errorf("%w: %q is already registered", ErrDefinitionConflict, name)
+	}
+
+	return nil
+}
+
+// runDefinitionHook calls Config.DefinitionHook with every definition, in name
+// order, and stores the schemas it changed.
+func (s *Service) runDefinitionHook() {
+	if s.config.DefinitionHook == nil {
+		return
+	}
+
+	for _, name := range schemautil.SortedKeys(s.swagger.Definitions) {
+		schema := s.swagger.Definitions[name]
+		s.config.DefinitionHook(name, &schema)
+		s.swagger.Definitions[name] = schema
+	}
+}
+
+// mergeExtraDefinitions adds the registered definitions to the spec. A registered
+// definition that differs from a generated one of the same name fails in strict
+// mode; otherwise the generated definition is kept and a warning is recorded.
+func (s *Service) mergeExtraDefinitions() error {
+	names := make([]string, 0, len(s.extraDefinitions))
+	for name := range s.extraDefinitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		extra := s.extraDefinitions[name]
+
+		existing, exists := s.swagger.Definitions[name]
+		if !exists {
+			s.swagger.Definitions[name] = extra
+			continue
+		}
+		if reflect.DeepEqual(existing, extra) {
+			continue
+		}
+
+		err := fmt.Errorf("%w: registered definition %q differs from the generated one", ErrDefinitionConflict, name)
+		if s.config.Strict {
+			return err
+		}
+		s.addWarning(Warning{
+			Category: WarningDefinitionConflict,
+			Message:  err.Error() + ", keeping the generated definition",
+		})
+	}
+
+	return nil
+}
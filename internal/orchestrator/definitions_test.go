@@ -0,0 +1,75 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+)
+
+func TestParse_DefinitionHook(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/hookapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Hook API
+// @version 1.0
+func main() {}
+`,
+		"api/api.go": `package api
+
+type User struct {
+	Name string ` + "`json:\"name\" public:\"view\"`" + `
+}
+
+// @Success 200 {object} User
+// @Router /users [get]
+func GetUser() {}
+`,
+	}
+	dir := writeModule(t, files)
+	// Packages are loaded relative to the working directory, as when run from a project root
+	t.Chdir(dir)
+
+	t.Run("adds a property to every definition", func(t *testing.T) {
+		var hooked []string
+		service := New(&Config{
+			DefinitionHook: func(name string, schema *spec.Schema) {
+				hooked = append(hooked, name)
+				schema.SetProperty("_links", *spec.MapProperty(spec.StringProperty()))
+			},
+		})
+		if err := service.AddDefinition("Money", *spec.StringProperty()); err != nil {
+			t.Fatal(err)
+		}
+
+		swagger, err := service.Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, name := range []string{"api.User", "api.UserPublic", "Money"} {
+			def, ok := swagger.Definitions[name]
+			if !ok {
+				t.Errorf("expected %s definition, got %v", name, swagger.Definitions)
+				continue
+			}
+			if _, ok := def.Properties["_links"]; !ok {
+				t.Errorf("expected the hook to add _links to %s, got %+v", name, def.Properties)
+			}
+		}
+		if len(hooked) != len(swagger.Definitions) {
+			t.Errorf("expected the hook to run once per definition, ran for %v", hooked)
+		}
+	})
+
+	t.Run("nil hook leaves definitions untouched", func(t *testing.T) {
+		swagger, err := New(&Config{}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := swagger.Definitions["api.User"].Properties["_links"]; ok {
+			t.Error("expected no _links property")
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/griffnb/core-swag/internal/model"
+)
+
+func TestParse_TypeDepth(t *testing.T) {
+	// A chain of 10 nested types and a cycle between Node and Edge
+	var chain strings.Builder
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(&chain, "type Level%d struct {\n\tNext *Level%d `json:\"next\"`\n}\n\n", i, i+1)
+	}
+	chain.WriteString("type Level10 struct {\n\tName string `json:\"name\"`\n}\n")
+
+	files := map[string]string{
+		"go.mod": "module example.com/depthapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Depth API
+// @version 1.0
+func main() {}
+`,
+		"api/levels.go": "package api\n\n" + chain.String(),
+		"api/api.go": `package api
+
+type Node struct {
+	Edges []Edge ` + "`json:\"edges\"`" + `
+}
+
+type Edge struct {
+	To *Node ` + "`json:\"to\"`" + `
+}
+
+// @Success 200 {object} Level0
+// @Router /levels [get]
+func GetLevels() {}
+
+// @Success 200 {object} Node
+// @Router /nodes [get]
+func GetNode() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	t.Run("resolves graphs within the depth", func(t *testing.T) {
+		model.Cache().Reset()
+		swagger, err := New(&Config{}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, name := range []string{"api.Level10", "api.Node", "api.Edge"} {
+			if _, ok := swagger.Definitions[name]; !ok {
+				t.Errorf("expected definition %s", name)
+			}
+		}
+	})
+
+	t.Run("fails beyond the depth", func(t *testing.T) {
+		model.Cache().Reset()
+		_, err := New(&Config{MaxTypeDepth: 5}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if !errors.Is(err, model.ErrTypeDepthExceeded) {
+			t.Fatalf("expected ErrTypeDepthExceeded, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "type resolution exceeded depth 5, possible cycle at example.com/depthapi/api.Level") {
+			t.Errorf("unexpected error message: %v", err)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+)
+
+func TestParse_Duration(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/durationapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Duration API
+// @version 1.0
+func main() {}
+`,
+		"api/api.go": `package api
+
+import "time"
+
+type Job struct {
+	Timeout time.Duration    ` + "`json:\"timeout\"`" + `
+	Retries []*time.Duration ` + "`json:\"retries\"`" + `
+}
+
+// @Success 200 {object} Job
+// @Router /jobs [get]
+func GetJob() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	tests := []struct {
+		name          string
+		durationAsInt bool
+		wantType      string
+		wantFormat    string
+		wantExample   interface{}
+	}{
+		{name: "string by default", wantType: "string", wantExample: "5s"},
+		{name: "integer nanoseconds", durationAsInt: true, wantType: "integer", wantFormat: "int64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			swagger, err := New(&Config{DurationAsInt: tt.durationAsInt}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, ok := swagger.Definitions["time.Duration"]; ok {
+				t.Error("expected no time.Duration definition")
+			}
+
+			job := swagger.Definitions["api.Job"]
+			timeout := job.Properties["timeout"]
+			retries := job.Properties["retries"]
+			if retries.Items == nil || retries.Items.Schema == nil {
+				t.Fatalf("expected retries to be an array, got %+v", retries)
+			}
+
+			for name, schema := range map[string]spec.Schema{"timeout": timeout, "retries item": *retries.Items.Schema} {
+				if !schema.Type.Contains(tt.wantType) || schema.Format != tt.wantFormat || schema.Example != tt.wantExample {
+					t.Errorf("%s: expected type %q, format %q and example %v, got %+v", name, tt.wantType, tt.wantFormat, tt.wantExample, schema.SchemaProps)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package orchestrator
+
+import (
+	"github.com/griffnb/core-swag/internal/schema"
+)
+
+// dropIgnoredDefinitions removes the definitions of @SwaggerIgnore types, and
+// their Public variants, and replaces every $ref to them with a generic object,
+// so types referenced only transitively never show up in the spec.
+func (s *Service) dropIgnoredDefinitions() {
+	if s.registry == nil {
+		return
+	}
+
+	names := make(map[string]bool)
+	for _, def := range s.registry.IgnoredTypes() {
+		for _, name := range []string{def.SimpleTypeName(), makeFullPathDefName(def.PkgPath, def.Name())} {
+			names[name] = true
+			names[name+"Public"] = true
+		}
+	}
+
+	replaced := schema.InlineDefinitions(s.swagger, names)
+	if replaced > 0 && s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Replaced %d refs to @SwaggerIgnore types with generic objects", replaced)
+	}
+}
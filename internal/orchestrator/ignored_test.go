@@ -0,0 +1,62 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDropIgnoredDefinitions(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/ignoreapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Ignore API
+// @version 1.0
+func main() {}
+`,
+		"api/api.go": `package api
+
+type User struct {
+	Name  string ` + "`json:\"name\"`" + `
+	History []state ` + "`json:\"history\"`" + `
+}
+
+// state is internal bookkeeping.
+// @SwaggerIgnore
+type state struct {
+	Counter int ` + "`json:\"counter\"`" + `
+}
+
+// @Success 200 {object} User
+// @Router /users [get]
+func GetUser() {}
+`,
+	}
+	dir := writeModule(t, files)
+	// Packages are loaded relative to the working directory, as when run from a project root
+	t.Chdir(dir)
+
+	swagger, err := New(&Config{Strict: true}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name := range swagger.Definitions {
+		if name == "api.state" || name == "api.statePublic" {
+			t.Errorf("expected %s to be dropped", name)
+		}
+	}
+
+	user, ok := swagger.Definitions["api.User"]
+	if !ok {
+		t.Fatalf("expected api.User definition, got %v", swagger.Definitions)
+	}
+	history := user.Properties["history"]
+	if history.Items == nil || history.Items.Schema == nil {
+		t.Fatalf("expected history to be an array, got %+v", history)
+	}
+	item := history.Items.Schema
+	if item.Ref.String() != "" || len(item.Type) != 1 || item.Type[0] != "object" {
+		t.Errorf("expected history items to be generic objects, got %+v", item)
+	}
+}
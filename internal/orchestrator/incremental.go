@@ -0,0 +1,542 @@
+package orchestrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/build"
+	goparser "go/parser"
+	"go/token"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/console"
+	"github.com/griffnb/core-swag/internal/model"
+	"github.com/griffnb/core-swag/internal/schemautil"
+	"golang.org/x/tools/go/packages"
+)
+
+// buildStateVersion is bumped whenever the layout of BuildState or the way
+// definitions are built changes, so older state files are ignored.
+const buildStateVersion = 3
+
+// BuildState is what an incremental build keeps between runs: the inputs the
+// schema definitions were built from and the definitions themselves, per
+// package of the referenced struct types.
+//
+// The definitions of a package are a function of the declarations of its source
+// files and those of its dependencies, the types the routes reference in it and
+// the configuration. Parse reuses the definitions of every package whose inputs
+// did not change, e.g. after editing a handler body or another package, instead
+// of loading and walking it again. Routes and non-struct types are always
+// parsed, so the result is identical to a full build.
+type BuildState struct {
+	Version int `json:"version"`
+
+	// Fingerprint identifies the configuration and core-swag build that produced the state
+	Fingerprint string `json:"fingerprint"`
+
+	// Inputs maps go.mod, go.sum and the Config.StateInputs to their hash. The
+	// definitions of every package are rebuilt when one of them changed.
+	Inputs map[string]string `json:"inputs"`
+
+	// Names hashes the types whose definition names are qualified by their package
+	// path, so a new type with a clashing name renames the refs of every package.
+	Names string `json:"names"`
+
+	// Packages maps the import path of each package with referenced struct types to its state
+	Packages map[string]*PackageState `json:"packages"`
+}
+
+// PackageState is the state of the definitions built for the referenced struct
+// types of one package.
+type PackageState struct {
+	// Referenced lists the types of the package referenced by routes and shared responses
+	Referenced []string `json:"referenced"`
+
+	// Files maps the source files of the package and of its dependencies to a hash of
+	// everything but their functions. The standard library and the module cache are
+	// tracked through the fingerprint and go.sum instead.
+	Files map[string]string `json:"files"`
+
+	// Definitions are the definitions as built, before naming and pruning
+	Definitions spec.Definitions `json:"definitions"`
+
+	// Warnings are the ones reported while building the definitions
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// State returns the build state of the last Parse call, to be passed as
+// Config.PreviousState to the next one. Nil unless Config.Incremental is set.
+func (s *Service) State() *BuildState {
+	return s.state
+}
+
+// buildDefinitions builds the definitions of the referenced types, reusing the
+// ones of the packages of Config.PreviousState whose inputs did not change.
+// Records the state of the build when incremental builds are enabled.
+func (s *Service) buildDefinitions(ctx context.Context, referencedTypes map[string]RefInfo) error {
+	if !s.config.Incremental {
+		return s.buildDemandDrivenSchemas(ctx, referencedTypes)
+	}
+
+	if s.swagger.Definitions == nil {
+		s.swagger.Definitions = make(spec.Definitions)
+	}
+
+	state := &BuildState{
+		Version:     buildStateVersion,
+		Fingerprint: s.buildFingerprint(),
+		Inputs:      s.stateInputs(),
+		Names:       s.qualifiedNamesHash(),
+		Packages:    make(map[string]*PackageState),
+	}
+
+	prev := s.config.PreviousState
+	if prev == nil || prev.Version != state.Version || prev.Fingerprint != state.Fingerprint ||
+		prev.Names != state.Names || !maps.Equal(prev.Inputs, state.Inputs) {
+		prev = nil
+	}
+
+	structWork, nonStructRefs := s.resolveWork(referencedTypes)
+
+	// structWork is sorted by package, so each package's work is contiguous
+	var pkgPaths []string
+	work := make(map[string][]structRefWork)
+	for _, w := range structWork {
+		if _, ok := work[w.pkgPath]; !ok {
+			pkgPaths = append(pkgPaths, w.pkgPath)
+		}
+		work[w.pkgPath] = append(work[w.pkgPath], w)
+	}
+
+	var reused, stale []string
+	var staleWork []structRefWork
+	for _, pkgPath := range pkgPaths {
+		if prev != nil && prev.Packages[pkgPath].reusable(referencedWorkKeys(work[pkgPath])) {
+			reused = append(reused, pkgPath)
+			continue
+		}
+		stale = append(stale, pkgPath)
+		staleWork = append(staleWork, work[pkgPath]...)
+	}
+
+	s.preWarm(staleWork)
+
+	// Stale packages are built one after the other, each with its own collector,
+	// so the warnings of each package can be restored along with its definitions.
+	packageDefinitions := make(map[string]spec.Definitions, len(pkgPaths))
+	for _, pkgPath := range stale {
+		warnings := console.NewDiagnostics()
+		parser := &model.CoreStructParser{}
+		parser.SetOptions(s.structParser.Options())
+		parser.SetWarnings(warnings)
+		results, err := s.buildStructSchemasConcurrent(ctx, parser, work[pkgPath])
+		if err != nil {
+			return err
+		}
+
+		definitions := make(spec.Definitions)
+		mergeStructResults(definitions, results)
+		packageDefinitions[pkgPath] = definitions
+
+		pkgState, err := s.packageState(pkgPath, work[pkgPath], definitions, warnings.List())
+		if err != nil {
+			return err
+		}
+		if pkgState != nil {
+			state.Packages[pkgPath] = pkgState
+		}
+	}
+	for _, pkgPath := range reused {
+		definitions, err := cloneDefinitions(prev.Packages[pkgPath].Definitions)
+		if err != nil {
+			return err
+		}
+		packageDefinitions[pkgPath] = definitions
+		state.Packages[pkgPath] = prev.Packages[pkgPath]
+	}
+
+	// Packages are merged and their warnings restored in the same order, whether
+	// they were reused or rebuilt. Types built for several packages warn once.
+	seen := make(map[Warning]bool)
+	for _, pkgPath := range pkgPaths {
+		for name, schema := range packageDefinitions[pkgPath] {
+			mergeStructSchema(s.swagger.Definitions, name, schema)
+		}
+		if pkgState := state.Packages[pkgPath]; pkgState != nil {
+			for _, warning := range pkgState.Warnings {
+				if !seen[warning] {
+					seen[warning] = true
+					s.warnings.Add(warning)
+				}
+			}
+		}
+	}
+
+	if s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Reused the definitions of %d packages, rebuilt %d", len(reused), len(stale))
+	}
+
+	s.state = state
+
+	return s.buildNonStructSchemas(ctx, nonStructRefs)
+}
+
+// reusable reports whether the definitions of the package state are still those
+// a full build of the referenced types would produce.
+func (p *PackageState) reusable(referenced []string) bool {
+	if p == nil || len(p.Files) == 0 || !slices.Equal(p.Referenced, referenced) {
+		return false
+	}
+
+	dirs := make(map[string]bool)
+	for path, hash := range p.Files {
+		// Missing files hash to "", so deleted and created files are both noticed
+		current, err := declarationsHash(path)
+		if (err != nil && !os.IsNotExist(err)) || current != hash {
+			return false
+		}
+		dirs[filepath.Dir(path)] = true
+	}
+
+	// A new file in a known package may declare new types
+	for dir := range dirs {
+		files, err := goSourceFiles(dir)
+		if err != nil {
+			return false
+		}
+		for _, file := range files {
+			if _, ok := p.Files[file]; !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// packageState records the state of a freshly built package. Returns nil when
+// the files of the package or one of its dependencies are unknown, so it is
+// rebuilt next time.
+func (s *Service) packageState(pkgPath string, work []structRefWork, definitions spec.Definitions, warnings []Warning) (*PackageState, error) {
+	files := packageDeclarations(pkgPath)
+	if files == nil {
+		if s.config.Debug != nil {
+			s.config.Debug.Printf("Orchestrator: Not recording the state of %s, its files are unknown", pkgPath)
+		}
+		return nil, nil
+	}
+
+	clone, err := cloneDefinitions(definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PackageState{
+		Referenced:  referencedWorkKeys(work),
+		Files:       files,
+		Definitions: clone,
+		Warnings:    warnings,
+	}, nil
+}
+
+// packageDeclarations hashes the source files of the loaded package pkgPath and
+// of its transitive imports, except those of the standard library and the module
+// cache. Returns nil when the package or one of its dependencies was loaded
+// without its files.
+func packageDeclarations(pkgPath string) map[string]string {
+	cache := model.Cache()
+	cache.RLock()
+	pkg := cache.Packages()[pkgPath]
+	cache.RUnlock()
+	if pkg == nil {
+		return nil
+	}
+
+	ignored := []string{build.Default.GOROOT, moduleCacheDir()}
+	declarations := make(map[string]string)
+	visited := make(map[string]bool)
+
+	var walk func(pkg *packages.Package) bool
+	walk = func(pkg *packages.Package) bool {
+		if visited[pkg.PkgPath] {
+			return true
+		}
+		visited[pkg.PkgPath] = true
+
+		files := packageFiles(pkg)
+		if len(files) == 0 {
+			// unsafe is the only package without files
+			return pkg.PkgPath == "unsafe"
+		}
+		if withinAny(files[0], ignored) {
+			return true
+		}
+
+		for _, file := range files {
+			hash, err := declarationsHash(file)
+			if err != nil && !os.IsNotExist(err) {
+				return false
+			}
+			declarations[file] = hash
+		}
+		for _, imp := range pkg.Imports {
+			if !walk(imp) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !walk(pkg) {
+		return nil
+	}
+	return declarations
+}
+
+// stateInputs hashes go.mod and go.sum of the working directory and the
+// Config.StateInputs.
+func (s *Service) stateInputs() map[string]string {
+	files := append([]string{"go.mod", "go.sum"}, s.config.StateInputs...)
+
+	inputs := make(map[string]string, len(files))
+	for _, file := range files {
+		file = absPath(file)
+		if hash, err := declarationsHash(file); err == nil || os.IsNotExist(err) {
+			inputs[file] = hash
+		}
+	}
+
+	return inputs
+}
+
+// qualifiedNamesHash hashes the types of the registry whose names are not unique.
+func (s *Service) qualifiedNamesHash() string {
+	var names []string
+	for _, typeDef := range s.registry.UniqueDefinitions() {
+		if typeDef != nil && typeDef.NotUnique {
+			names = append(names, typeDef.FullPath())
+		}
+	}
+	sort.Strings(names)
+
+	sum := sha256.Sum256([]byte(strings.Join(names, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// buildFingerprint hashes the configuration that affects definitions and the
+// version of core-swag that builds them.
+func (s *Service) buildFingerprint() string {
+	version := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version = info.Main.Version + " " + info.Main.Sum
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+				version += " " + setting.Value
+			}
+		}
+	}
+
+	hash := sha256.New()
+	write := func(name string, value any) {
+		fmt.Fprintf(hash, "%s=%#v\n", name, value)
+	}
+
+	config := s.config
+	write("BuildStateVersion", buildStateVersion)
+	write("Version", version)
+	write("ParseVendor", config.ParseVendor)
+	write("ParseInternal", config.ParseInternal)
+	write("ParseDependency", config.ParseDependency)
+	write("ParseExtension", config.ParseExtension)
+	write("ParseGoList", config.ParseGoList)
+	write("ParseGoPackages", config.ParseGoPackages)
+	write("ParseFuncBody", config.ParseFuncBody)
+	write("Excludes", schemautil.SortedKeys(config.Excludes))
+	write("PackagePrefix", config.PackagePrefix)
+	write("BuildTags", config.BuildTags)
+	write("PropNamingStrategy", config.PropNamingStrategy)
+	write("RequiredByDefault", config.RequiredByDefault)
+	write("HostState", config.HostState)
+	write("EmitNullable", config.EmitNullable)
+	write("PointersOptional", config.PointersOptional)
+	write("InlineEnums", config.InlineEnums)
+	write("DurationAsInt", config.DurationAsInt)
+	write("EmitFieldOrder", config.EmitFieldOrder)
+	write("EmitOmitEmpty", config.EmitOmitEmpty)
+	write("StrictEnums", config.StrictEnums)
+	write("MaxTypeDepth", config.MaxTypeDepth)
+	for _, name := range schemautil.SortedKeys(config.Overrides) {
+		write("Overrides."+name, config.Overrides[name])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// declarationsHash hashes a Go file without its functions, i.e. its header,
+// package clause, imports and type, constant and variable declarations with
+// their comments, including the types declared inside functions. Other files,
+// like go.mod, are hashed whole.
+func declarationsHash(path string) (string, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	if !strings.HasSuffix(path, ".go") {
+		hash.Write(src)
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, path, src, goparser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+
+	writeDecl := func(genDecl *ast.GenDecl) {
+		start := genDecl.Pos()
+		if genDecl.Doc != nil {
+			start = genDecl.Doc.Pos()
+		}
+		hash.Write(src[offset(start):offset(genDecl.End())])
+		hash.Write([]byte{'\n'})
+	}
+
+	hash.Write(src[:offset(file.Name.End())])
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			writeDecl(decl)
+		case *ast.FuncDecl:
+			// Types declared in a function are definitions too, named after it
+			if decl.Body == nil {
+				continue
+			}
+			ast.Inspect(decl.Body, func(node ast.Node) bool {
+				declStmt, ok := node.(*ast.DeclStmt)
+				if !ok {
+					return true
+				}
+				if genDecl, ok := declStmt.Decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
+					hash.Write([]byte(decl.Name.Name + "\n"))
+					writeDecl(genDecl)
+				}
+				return true
+			})
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// goSourceFiles lists the non-test Go files of dir that match the build context.
+func goSourceFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	return files, nil
+}
+
+// packageFiles returns the source files of a loaded package.
+func packageFiles(pkg *packages.Package) []string {
+	if len(pkg.GoFiles) > 0 {
+		return pkg.GoFiles
+	}
+
+	files := make([]string, 0, len(pkg.Syntax))
+	for _, file := range pkg.Syntax {
+		if tokenFile := pkg.Fset.File(file.Pos()); tokenFile != nil {
+			files = append(files, tokenFile.Name())
+		}
+	}
+	return files
+}
+
+// referencedWorkKeys returns the sorted referenced types of a package with the
+// names they are built as.
+func referencedWorkKeys(work []structRefWork) []string {
+	keys := make([]string, 0, len(work))
+	for _, w := range work {
+		keys = append(keys, w.baseName+" "+w.typeName)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// cloneDefinitions deep copies definitions, so the state is not changed by
+// the renames and pruning applied to the spec afterwards.
+func cloneDefinitions(definitions spec.Definitions) (spec.Definitions, error) {
+	data, err := json.Marshal(definitions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy definitions: %w", err)
+	}
+
+	var clone spec.Definitions
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to copy definitions: %w", err)
+	}
+	if clone == nil {
+		clone = make(spec.Definitions)
+	}
+	return clone, nil
+}
+
+// absPath returns the absolute form of path, or path itself when it cannot be resolved.
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// moduleCacheDir returns the directory of the Go module cache.
+func moduleCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	if gopath := filepath.SplitList(build.Default.GOPATH); len(gopath) > 0 {
+		return filepath.Join(gopath[0], "pkg", "mod")
+	}
+	return ""
+}
+
+// withinAny reports whether path is inside one of dirs.
+func withinAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return false
+}
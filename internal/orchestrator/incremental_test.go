@@ -0,0 +1,326 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/griffnb/core-swag/internal/model"
+)
+
+const incrementalModels = `package models
+
+// Account is a customer account.
+type Account struct {
+	ID    string ` + "`json:\"id\"`" + `
+	Owner User   ` + "`json:\"owner\"`" + `
+	Tags  []Tag  ` + "`json:\"tags\"`" + `
+}
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Tag struct {
+	Label string ` + "`json:\"label\"`" + `
+}
+`
+
+const incrementalHandlers = `package handlers
+
+import _ "example.com/incrementalapi/models"
+
+// @Summary Get account
+// @Success 200 {object} models.Account
+// @Router /accounts/{id} [get]
+func GetAccount() {}
+`
+
+const billingModels = `package billing
+
+type Invoice struct {
+	Total int ` + "`json:\"total\"`" + `
+}
+`
+
+const sharedMoney = `package shared
+
+type Money struct {
+	Cents    int    ` + "`json:\"cents\"`" + `
+	Currency string ` + "`json:\"currency\"`" + `
+}
+`
+
+const scopedHandlers = `package handlers
+
+// @Success 200 {object} handlers.GetScoped.response
+// @Router /scoped [get]
+func GetScoped() {
+	type response struct {
+		Label string ` + "`json:\"label\"`" + `
+	}
+	_ = response{}
+}
+`
+
+// writeIncrementalFixture writes the incremental test module with the extra
+// files, relative to the module, and returns its search dir and main API file.
+func writeIncrementalFixture(t *testing.T, extra map[string]string) (string, string) {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "api")
+	files := map[string]string{
+		"go.mod": "module example.com/incrementalapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Incremental API
+// @version 1.0
+func main() {}
+`,
+		"models/models.go":     incrementalModels,
+		"handlers/handlers.go": incrementalHandlers,
+	}
+	for name, content := range extra {
+		files[name] = content
+	}
+	for name, content := range files {
+		writeFile(t, filepath.Join(dir, name), content)
+	}
+
+	return dir, filepath.Join(dir, "main.go")
+}
+
+// parseIncremental parses the fixture from a cold package cache and returns
+// the marshaled spec, the new state and whether model packages were loaded.
+func parseIncremental(t *testing.T, dir, mainFile string, prev *BuildState) (string, *BuildState, bool) {
+	t.Helper()
+
+	model.Cache().Reset()
+
+	service := New(&Config{Incremental: true, PreviousState: prev})
+	swagger, err := service.Parse([]string{dir}, mainFile, 0)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	data, err := json.Marshal(swagger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := model.Cache().IsCached("example.com/incrementalapi/models")
+
+	// Round trip the state as it is stored between runs
+	stateData, err := json.Marshal(service.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state BuildState
+	if err := json.Unmarshal(stateData, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data), &state, loaded
+}
+
+// parseFull parses the fixture without any state.
+func parseFull(t *testing.T, dir, mainFile string) string {
+	t.Helper()
+
+	model.Cache().Reset()
+	swagger, err := New(&Config{}).Parse([]string{dir}, mainFile, 0)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	data, err := json.Marshal(swagger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParse_Incremental(t *testing.T) {
+	t.Run("reuses definitions when only handlers changed", func(t *testing.T) {
+		dir, mainFile := writeIncrementalFixture(t, nil)
+		t.Chdir(dir)
+
+		_, state, _ := parseIncremental(t, dir, mainFile, nil)
+		if len(state.Packages["example.com/incrementalapi/models"].Definitions) == 0 {
+			t.Fatal("expected the state to record definitions")
+		}
+
+		writeFile(t, filepath.Join(dir, "handlers/handlers.go"),
+			strings.Replace(incrementalHandlers, "@Summary Get account", "@Summary Fetch an account\n// @Description Returns the account.", 1))
+
+		incremental, _, loaded := parseIncremental(t, dir, mainFile, state)
+		if loaded {
+			t.Error("expected the model packages not to be loaded")
+		}
+		if !strings.Contains(incremental, "Fetch an account") {
+			t.Errorf("expected the changed summary, got %s", incremental)
+		}
+		if full := parseFull(t, dir, mainFile); incremental != full {
+			t.Errorf("incremental output differs from a full build:\nincremental: %s\nfull:        %s", incremental, full)
+		}
+	})
+
+	t.Run("rebuilds definitions when a model changed", func(t *testing.T) {
+		dir, mainFile := writeIncrementalFixture(t, nil)
+		t.Chdir(dir)
+
+		_, state, _ := parseIncremental(t, dir, mainFile, nil)
+
+		writeFile(t, filepath.Join(dir, "models/models.go"),
+			strings.Replace(incrementalModels, `json:"name"`, `json:"full_name"`, 1))
+
+		incremental, _, loaded := parseIncremental(t, dir, mainFile, state)
+		if !loaded {
+			t.Error("expected the model packages to be loaded")
+		}
+		if !strings.Contains(incremental, "full_name") {
+			t.Errorf("expected the changed model, got %s", incremental)
+		}
+		if full := parseFull(t, dir, mainFile); incremental != full {
+			t.Errorf("incremental output differs from a full build:\nincremental: %s\nfull:        %s", incremental, full)
+		}
+	})
+
+	t.Run("rebuilds definitions when a route references a new type", func(t *testing.T) {
+		dir, mainFile := writeIncrementalFixture(t, nil)
+		t.Chdir(dir)
+
+		_, state, _ := parseIncremental(t, dir, mainFile, nil)
+
+		writeFile(t, filepath.Join(dir, "handlers/handlers.go"), incrementalHandlers+`
+// @Success 200 {array} models.Tag
+// @Router /tags [get]
+func ListTags() {}
+`)
+
+		incremental, _, loaded := parseIncremental(t, dir, mainFile, state)
+		if !loaded {
+			t.Error("expected the model packages to be loaded")
+		}
+		if full := parseFull(t, dir, mainFile); incremental != full {
+			t.Errorf("incremental output differs from a full build:\nincremental: %s\nfull:        %s", incremental, full)
+		}
+	})
+
+	t.Run("rebuilds definitions when a file is added to a package", func(t *testing.T) {
+		dir, mainFile := writeIncrementalFixture(t, nil)
+		t.Chdir(dir)
+
+		_, state, _ := parseIncremental(t, dir, mainFile, nil)
+
+		writeFile(t, filepath.Join(dir, "models/extra.go"), "package models\n\ntype Extra struct{}\n")
+
+		_, _, loaded := parseIncremental(t, dir, mainFile, state)
+		if !loaded {
+			t.Error("expected the model packages to be loaded")
+		}
+	})
+
+	t.Run("reuses the definitions of unchanged packages", func(t *testing.T) {
+		dir, mainFile := writeIncrementalFixture(t, map[string]string{
+			"billing/billing.go": billingModels,
+			"handlers/billing.go": `package handlers
+
+import _ "example.com/incrementalapi/billing"
+
+// @Success 200 {object} billing.Invoice
+// @Router /invoices/{id} [get]
+func GetInvoice() {}
+`,
+		})
+		t.Chdir(dir)
+
+		_, state, _ := parseIncremental(t, dir, mainFile, nil)
+
+		writeFile(t, filepath.Join(dir, "billing/billing.go"),
+			strings.Replace(billingModels, `json:"total"`, `json:"amount"`, 1))
+
+		incremental, _, loaded := parseIncremental(t, dir, mainFile, state)
+		if loaded {
+			t.Error("expected the unchanged models package not to be loaded")
+		}
+		if !model.Cache().IsCached("example.com/incrementalapi/billing") {
+			t.Error("expected the changed billing package to be loaded")
+		}
+		if !strings.Contains(incremental, `"amount"`) {
+			t.Errorf("expected the changed model, got %s", incremental)
+		}
+		if full := parseFull(t, dir, mainFile); incremental != full {
+			t.Errorf("incremental output differs from a full build:\nincremental: %s\nfull:        %s", incremental, full)
+		}
+	})
+
+	t.Run("rebuilds definitions when a dependency outside the working directory changed", func(t *testing.T) {
+		dir, mainFile := writeIncrementalFixture(t, map[string]string{
+			"go.mod": "module example.com/incrementalapi\n\ngo 1.24\n\nrequire example.com/shared v0.0.0\n\nreplace example.com/shared => ../shared\n",
+			"models/models.go": strings.Replace(incrementalModels, "package models\n",
+				"package models\n\nimport \"example.com/shared\"\n\ntype Balance struct {\n\tMoney shared.Money `json:\"money\"`\n}\n", 1),
+			"handlers/balance.go": `package handlers
+
+// @Success 200 {object} models.Balance
+// @Router /balance [get]
+func GetBalance() {}
+`,
+			"../shared/go.mod":   "module example.com/shared\n\ngo 1.24\n",
+			"../shared/money.go": sharedMoney,
+		})
+		t.Chdir(dir)
+
+		_, state, _ := parseIncremental(t, dir, mainFile, nil)
+		files := state.Packages["example.com/incrementalapi/models"].Files
+		if _, ok := files[filepath.Join(dir, "../shared/money.go")]; !ok {
+			t.Errorf("expected the dependency to be tracked, got %v", files)
+		}
+
+		writeFile(t, filepath.Join(dir, "../shared/money.go"),
+			strings.Replace(sharedMoney, `json:"cents"`, `json:"minor_units"`, 1))
+
+		incremental, _, loaded := parseIncremental(t, dir, mainFile, state)
+		if !loaded {
+			t.Error("expected the model packages to be loaded")
+		}
+		if !strings.Contains(incremental, "minor_units") {
+			t.Errorf("expected the changed dependency, got %s", incremental)
+		}
+		if full := parseFull(t, dir, mainFile); incremental != full {
+			t.Errorf("incremental output differs from a full build:\nincremental: %s\nfull:        %s", incremental, full)
+		}
+	})
+
+	t.Run("rebuilds definitions when a function-scoped type changed", func(t *testing.T) {
+		dir, mainFile := writeIncrementalFixture(t, map[string]string{
+			"handlers/scoped.go": scopedHandlers,
+		})
+		t.Chdir(dir)
+
+		_, state, _ := parseIncremental(t, dir, mainFile, nil)
+
+		writeFile(t, filepath.Join(dir, "handlers/scoped.go"),
+			strings.Replace(scopedHandlers, `json:"label"`, `json:"title"`, 1))
+
+		incremental, _, _ := parseIncremental(t, dir, mainFile, state)
+		if !strings.Contains(incremental, `"title"`) {
+			t.Errorf("expected the changed function-scoped type, got %s", incremental)
+		}
+		if full := parseFull(t, dir, mainFile); incremental != full {
+			t.Errorf("incremental output differs from a full build:\nincremental: %s\nfull:        %s", incremental, full)
+		}
+	})
+
+	t.Run("does not record state unless enabled", func(t *testing.T) {
+		dir, mainFile := writeIncrementalFixture(t, nil)
+		t.Chdir(dir)
+
+		service := New(&Config{})
+		if _, err := service.Parse([]string{dir}, mainFile, 0); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if service.State() != nil {
+			t.Error("expected no state")
+		}
+	})
+}
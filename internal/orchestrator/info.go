@@ -0,0 +1,50 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMissingInfo is returned in strict mode when the main API file has no @title or @version.
+var ErrMissingInfo = errors.New("missing general API info")
+
+// addInfoWarnings reports the general API annotations the base parser rejected.
+func (s *Service) addInfoWarnings(mainFilePath string) {
+	for _, problem := range s.baseParser.Problems() {
+		s.addWarning(Warning{
+			Category: WarningInvalidInfo,
+			Message:  problem.Message,
+			File:     mainFilePath,
+			Line:     problem.Line,
+		})
+	}
+}
+
+// checkRequiredInfo reports a main API file without @title or @version, which
+// Swagger 2.0 requires in the info object. Validators reject the spec without them.
+// Fails in strict mode and is recorded as a warning otherwise.
+func (s *Service) checkRequiredInfo(mainFilePath string) error {
+	var missing []string
+	if s.swagger.Info == nil || s.swagger.Info.Title == "" {
+		missing = append(missing, "@title")
+	}
+	if s.swagger.Info == nil || s.swagger.Info.Version == "" {
+		missing = append(missing, "@version")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("main API file %s has no %s annotation", mainFilePath, strings.Join(missing, " or "))
+	if s.config.Strict {
+		return fmt.Errorf("%w: %s", ErrMissingInfo, msg)
+	}
+	s.addWarning(Warning{
+		Category: WarningMissingInfo,
+		Message:  msg,
+		File:     mainFilePath,
+	})
+
+	return nil
+}
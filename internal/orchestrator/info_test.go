@@ -0,0 +1,107 @@
+package orchestrator
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// writeInfoModule writes a module whose main.go holds the given source and
+// changes into it. Returns the path of main.go.
+func writeInfoModule(t *testing.T, mainSrc string) string {
+	t.Helper()
+	dir := writeModule(t, map[string]string{
+		"go.mod":  "module example.com/infoapi\n\ngo 1.24\n",
+		"main.go": mainSrc,
+	})
+	t.Chdir(dir)
+	return filepath.Join(dir, "main.go")
+}
+
+func TestParse_InvalidInfoWarnings(t *testing.T) {
+	mainFile := writeInfoModule(t, `package main
+
+// @title Info API
+// @version 1.0
+// @contact.name API Support
+// @contact.email support(at)example.com
+// @contact.url https://example.com/support
+// @license.name MIT
+// @license.url opensource.org/licenses/MIT
+func main() {}
+`)
+	dir := filepath.Dir(mainFile)
+
+	svc := New(&Config{})
+	swagger, err := svc.Parse([]string{dir}, mainFile, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	contact := swagger.Info.Contact
+	if contact.Name != "API Support" || contact.Email != "" || contact.URL != "https://example.com/support" {
+		t.Errorf("unexpected contact %+v", contact)
+	}
+	if swagger.Info.License == nil || swagger.Info.License.Name != "MIT" || swagger.Info.License.URL != "" {
+		t.Errorf("unexpected license %+v", swagger.Info.License)
+	}
+
+	expected := []Warning{
+		{Category: WarningInvalidInfo, Message: `@contact.email: "support(at)example.com" is not a valid email address`, File: mainFile, Line: 6},
+		{Category: WarningInvalidInfo, Message: `@license.url: "opensource.org/licenses/MIT" is not a valid absolute URL`, File: mainFile, Line: 9},
+	}
+	var warnings []Warning
+	for _, warning := range svc.Warnings() {
+		if warning.Category == WarningInvalidInfo {
+			warnings = append(warnings, warning)
+		}
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("expected %d info warnings, got %v", len(expected), warnings)
+	}
+	for i := range expected {
+		if warnings[i] != expected[i] {
+			t.Errorf("warning %d: expected %+v, got %+v", i, expected[i], warnings[i])
+		}
+	}
+}
+
+func TestParse_MissingInfo(t *testing.T) {
+	const src = `package main
+
+// @title Info API
+// @description No version here
+func main() {}
+`
+
+	t.Run("warns naming the main API file", func(t *testing.T) {
+		mainFile := writeInfoModule(t, src)
+		svc := New(&Config{})
+		if _, err := svc.Parse([]string{filepath.Dir(mainFile)}, mainFile, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := Warning{
+			Category: WarningMissingInfo,
+			Message:  "main API file " + mainFile + " has no @version annotation",
+			File:     mainFile,
+		}
+		found := false
+		for _, warning := range svc.Warnings() {
+			if warning == expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected warning %+v, got %v", expected, svc.Warnings())
+		}
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		mainFile := writeInfoModule(t, src)
+		_, err := New(&Config{Strict: true}).Parse([]string{filepath.Dir(mainFile)}, mainFile, 0)
+		if !errors.Is(err, ErrMissingInfo) {
+			t.Fatalf("expected ErrMissingInfo, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package orchestrator
+
+import (
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/schema"
+)
+
+// inlineSingleUseDefinitions replaces the only $ref to an object definition with
+// the definition's schema and removes the definition, the inverse of
+// dedupeIdenticalDefinitions. Enums and definitions that refer to themselves,
+// directly or through other definitions, keep their definition.
+func (s *Service) inlineSingleUseDefinitions() {
+	names := make(map[string]bool)
+	for name, count := range schema.CountRefs(s.swagger) {
+		def, ok := s.swagger.Definitions[name]
+		if !ok || count != 1 || !isInlinableDefinition(def) || refersToItself(s.swagger.Definitions, name) {
+			continue
+		}
+		names[name] = true
+	}
+
+	inlined := schema.ExpandDefinitions(s.swagger, names)
+	if inlined > 0 && s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Inlined %d single-use definitions", inlined)
+	}
+}
+
+// isInlinableDefinition reports whether a definition is a plain object schema,
+// not an enum.
+func isInlinableDefinition(def spec.Schema) bool {
+	if !def.Type.Contains("object") || len(def.Enum) > 0 {
+		return false
+	}
+	_, isEnum := def.Extensions["x-enum-varnames"]
+	return !isEnum
+}
+
+// refersToItself reports whether the named definition can reach itself through
+// the $refs of definitions.
+func refersToItself(definitions spec.Definitions, name string) bool {
+	visited := make(map[string]bool)
+	pending := []string{name}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for ref := range schema.ReferencedDefinitions(definitions[current]) {
+			if ref == name {
+				return true
+			}
+			if !visited[ref] {
+				visited[ref] = true
+				pending = append(pending, ref)
+			}
+		}
+	}
+
+	return false
+}
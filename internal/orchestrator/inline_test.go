@@ -0,0 +1,112 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/model"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+func TestParse_InlineSingleUseDefinitions(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/inlineapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Inline API
+// @version 1.0
+func main() {}
+`,
+		"api/api.go": `package api
+
+type Status string
+
+const (
+	StatusOpen   Status = "open"
+	StatusClosed Status = "closed"
+)
+
+// Address is used by Pet only
+type Address struct {
+	Street string ` + "`json:\"street\"`" + `
+}
+
+// Owner is used by Pet and Store
+type Owner struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// Node refers to itself
+type Node struct {
+	Children []Node ` + "`json:\"children\"`" + `
+}
+
+type Pet struct {
+	Address *Address ` + "`json:\"address\"`" + `
+	Owner   *Owner   ` + "`json:\"owner\"`" + `
+	Status  Status   ` + "`json:\"status\"`" + `
+	Tree    *Node    ` + "`json:\"tree\"`" + `
+}
+
+type Store struct {
+	Owner *Owner ` + "`json:\"owner\"`" + `
+}
+
+// @Success 200 {object} Pet
+// @Router /pet [get]
+func GetPet() {}
+
+// @Success 200 {object} Store
+// @Router /store [get]
+func GetStore() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	parse := func(t *testing.T, inline bool) *spec.Swagger {
+		t.Helper()
+		model.Cache().Reset()
+		swagger, err := New(&Config{InlineSingleUseDefinitions: inline}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return swagger
+	}
+
+	t.Run("keeps single-use definitions unless enabled", func(t *testing.T) {
+		swagger := parse(t, false)
+		if _, ok := swagger.Definitions["api.Address"]; !ok {
+			t.Errorf("expected api.Address, got %s", strings.Join(schemautil.SortedKeys(swagger.Definitions), ","))
+		}
+	})
+
+	t.Run("inlines object definitions used once", func(t *testing.T) {
+		swagger := parse(t, true)
+		names := strings.Join(schemautil.SortedKeys(swagger.Definitions), ",")
+		for _, name := range []string{"api.Address", "api.Pet", "api.Store"} {
+			if _, ok := swagger.Definitions[name]; ok {
+				t.Errorf("expected %s to be inlined, got %s", name, names)
+			}
+		}
+		for _, name := range []string{"api.Owner", "api.Status", "api.Node"} {
+			if _, ok := swagger.Definitions[name]; !ok {
+				t.Errorf("expected %s to be kept, got %s", name, names)
+			}
+		}
+
+		pet := swagger.Paths.Paths["/pet"].Get.Responses.StatusCodeResponses[200].Schema
+		address := pet.Properties["address"]
+		if address.Ref.String() != "" || !address.Type.Contains("object") || address.Properties["street"].Type[0] != "string" {
+			t.Errorf("expected address to be inlined, got %+v", address)
+		}
+		for _, name := range []string{"owner", "status", "tree"} {
+			prop := pet.Properties[name]
+			if ref := prop.Ref.String(); !strings.HasPrefix(ref, "#/definitions/api.") {
+				t.Errorf("expected %s to stay a ref, got %q", name, ref)
+			}
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+
+	"github.com/griffnb/core-swag/internal/loader"
+	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+// Lint checks the annotations of the search directories for common mistakes
+// without building schemas: router annotations without a valid HTTP method,
+// types the registry does not know, path parameters missing from the router
+// path and security schemes that are not declared, on top of the warnings Parse
+// reports while parsing routes, such as routes declared twice. The problems are
+// returned as warnings sorted by file and line.
+func (s *Service) Lint(searchDirs []string, mainAPIFile string, parseDepth int) ([]Warning, error) {
+	ctx := context.Background()
+
+	if _, err := s.startParse(); err != nil {
+		return nil, err
+	}
+
+	loadResult, mainFilePath, err := s.load(searchDirs, mainAPIFile, parseDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.registerTypes(ctx, loadResult); err != nil {
+		return nil, err
+	}
+
+	if err := s.baseParser.ParseGeneralAPIInfo(mainFilePath); err != nil {
+		return nil, fmt.Errorf("failed to parse general API info: %w", err)
+	}
+	s.addInfoWarnings(mainFilePath)
+	if err := s.checkRequiredInfo(mainFilePath); err != nil {
+		return nil, err
+	}
+	s.routeParser.SetDefaultMimeTypes(s.swagger.Consumes, s.swagger.Produces)
+
+	s.lintRouters(loadResult.Files)
+
+	routes, _, err := s.parseRoutesParallel(ctx, loadResult.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lintRoutes(routes)
+
+	warnings := s.warnings.List()
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].File != warnings[j].File {
+			return warnings[i].File < warnings[j].File
+		}
+		return warnings[i].Line < warnings[j].Line
+	})
+
+	return warnings, nil
+}
+
+// lintRouters records a warning for every router annotation that declares no route.
+func (s *Service) lintRouters(files map[*ast.File]*loader.AstFileInfo) {
+	for astFile, fileInfo := range files {
+		if astFile == nil {
+			continue
+		}
+		for _, problem := range s.routeParser.InvalidRouters(astFile, fileInfo.FileSet) {
+			s.addWarning(Warning{
+				Category: WarningInvalidRouter,
+				Message:  problem.Message,
+				File:     fileInfo.Path,
+				Line:     problem.Line,
+			})
+		}
+	}
+}
+
+// lintRoutes records a warning for every unknown type, undeclared path parameter
+// and undeclared security scheme of routes.
+func (s *Service) lintRoutes(routes []*routedomain.Route) {
+	for _, r := range routes {
+		if r == nil {
+			continue
+		}
+		addWarning := func(category, format string, args ...interface{}) {
+			s.addWarning(Warning{
+				Category: category,
+				Message:  fmt.Sprintf("%s %s: ", r.Method, r.Path) + fmt.Sprintf(format, args...),
+				File:     r.FilePath,
+				Line:     r.LineNumber,
+			})
+		}
+
+		refs := make(map[string]RefInfo)
+		collectRefsFromRoute(r, refs, routeSource(r))
+		for _, name := range schemautil.SortedKeys(refs) {
+			if !s.isKnownType(name, refs[name]) {
+				addWarning(WarningUnknownType, "unknown type %s", name)
+			}
+		}
+
+		for _, param := range r.Parameters {
+			if param.In == "path" && !strings.Contains(r.Path, "{"+param.Name+"}") {
+				addWarning(WarningUnknownPathParam, "path parameter %q is not in the router path", param.Name)
+			}
+		}
+
+		for _, requirement := range r.Security {
+			for _, name := range schemautil.SortedKeys(requirement) {
+				if _, ok := s.swagger.SecurityDefinitions[name]; !ok {
+					addWarning(WarningUndeclaredSecurity, "security scheme %q is not declared", name)
+				}
+			}
+		}
+	}
+}
+
+// isKnownType reports whether the registry has the type of a route $ref. Generic
+// instantiations are checked by their generic type.
+func (s *Service) isKnownType(name string, info RefInfo) bool {
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+		if j := strings.Index(info.TypePath, "["); j >= 0 {
+			info.TypePath = info.TypePath[:j]
+		}
+	}
+
+	_, typeDef := s.resolveRef(name, info, make(map[string]bool))
+	return typeDef != nil
+}
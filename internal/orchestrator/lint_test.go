@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/lintapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Lint API
+// @version 1.0
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+func main() {}
+`,
+		"models/models.go": `package models
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+		"handlers/handlers.go": `package handlers
+
+import _ "example.com/lintapi/models"
+
+// @Success 200 {object} models.User
+// @Security ApiKeyAuth
+// @Router /users/{id} [get]
+func GetUser() {}
+
+// @Success 200 {object} models.Missing
+// @Router /missing [get]
+func GetMissing() {}
+
+// @Param id path string true "User ID"
+// @Router /users [post]
+func CreateUser() {}
+
+// @Router /users/{id} [get]
+func GetUserAgain() {}
+
+// @Security BasicAuth
+// @Router /private [get]
+func GetPrivate() {}
+
+// @Router /broken
+func Broken() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	warnings, err := New(&Config{}).Lint([]string{dir}, filepath.Join(dir, "main.go"), 0)
+	if err != nil {
+		t.Fatalf("lint failed: %v", err)
+	}
+
+	handlers := filepath.Join(dir, "handlers", "handlers.go")
+	want := []Warning{
+		{Category: WarningUnknownType, File: handlers, Line: 12, Message: "unknown type models.Missing"},
+		{Category: WarningUnknownPathParam, File: handlers, Line: 16, Message: `path parameter "id" is not in the router path`},
+		{Category: WarningDuplicateRoute, File: handlers, Line: 19, Message: "already declared by GetUser"},
+		{Category: WarningUndeclaredSecurity, File: handlers, Line: 23, Message: `security scheme "BasicAuth" is not declared`},
+		{Category: WarningInvalidRouter, File: handlers, Line: 25, Message: "@Router on Broken"},
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("expected %d warnings, got %d: %+v", len(want), len(warnings), warnings)
+	}
+	for i, w := range want {
+		got := warnings[i]
+		if got.Category != w.Category || got.File != w.File || got.Line != w.Line || !strings.Contains(got.Message, w.Message) {
+			t.Errorf("warning %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+}
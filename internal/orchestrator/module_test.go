@@ -0,0 +1,31 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeModule writes files, keyed by their path relative to the module root,
+// to a new temporary directory and returns it.
+func writeModule(tb testing.TB, files map[string]string) string {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	for name, content := range files {
+		writeFile(tb, filepath.Join(dir, name), content)
+	}
+	return dir
+}
+
+// writeFile writes content to path, creating its directory.
+func writeFile(tb testing.TB, path, content string) {
+	tb.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		tb.Fatal(err)
+	}
+}
@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"strings"
+
+	"github.com/griffnb/core-swag/internal/schema"
+)
+
+// namespaceDefinitionsByTag prefixes every definition that is only reachable from
+// operations sharing the same primary (first) tag with that tag, e.g. a definition
+// only used by billing operations becomes "billing.<name>". Definitions reachable
+// from several tags, untagged operations or shared parameters and responses keep
+// their names.
+func (s *Service) namespaceDefinitionsByTag() {
+	if len(s.swagger.Definitions) == 0 {
+		return
+	}
+
+	// tags maps each reachable definition to the primary tags reaching it; "" marks shared use
+	tags := make(map[string]map[string]bool)
+	mark := func(tag string, refs map[string]bool) {
+		for name := range s.reachableDefinitions(refs) {
+			if tags[name] == nil {
+				tags[name] = make(map[string]bool)
+			}
+			tags[name][tag] = true
+		}
+	}
+
+	if s.swagger.Paths != nil {
+		for _, pathItem := range s.swagger.Paths.Paths {
+			for _, param := range pathItem.Parameters {
+				mark("", schema.ReferencedDefinitions(param))
+			}
+			for _, op := range schema.PathItemOperations(pathItem) {
+				tag := ""
+				if len(op.Tags) > 0 {
+					tag = strings.TrimSpace(op.Tags[0])
+				}
+				mark(tag, schema.ReferencedDefinitions(op))
+			}
+		}
+	}
+
+	for _, param := range s.swagger.Parameters {
+		mark("", schema.ReferencedDefinitions(param))
+	}
+	for _, resp := range s.swagger.Responses {
+		mark("", schema.ReferencedDefinitions(resp))
+	}
+
+	renames := make(map[string]string)
+	for name, defTags := range tags {
+		if len(defTags) != 1 || defTags[""] {
+			continue
+		}
+		for tag := range defTags {
+			namespaced := tagNamespace(tag) + "." + name
+			if _, exists := s.swagger.Definitions[namespaced]; !exists {
+				renames[name] = namespaced
+			}
+		}
+	}
+
+	if s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Namespacing %d definitions by tag", len(renames))
+	}
+
+	schema.RenameDefinitions(s.swagger, renames)
+}
+
+// reachableDefinitions returns the existing definitions in refs plus everything they reference, transitively.
+func (s *Service) reachableDefinitions(refs map[string]bool) map[string]bool {
+	reachable := make(map[string]bool)
+
+	queue := make([]string, 0, len(refs))
+	for name := range refs {
+		queue = append(queue, name)
+	}
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		def, exists := s.swagger.Definitions[name]
+		if reachable[name] || !exists {
+			continue
+		}
+		reachable[name] = true
+
+		for ref := range schema.ReferencedDefinitions(def) {
+			queue = append(queue, ref)
+		}
+	}
+
+	return reachable
+}
+
+// tagNamespace turns a tag into a definition name prefix, replacing characters
+// that don't belong in a definition name.
+func tagNamespace(tag string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '/', '.', '#':
+			return '_'
+		}
+		return r
+	}, tag)
+}
@@ -0,0 +1,72 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+func taggedOperation(tag, ref string) *spec.Operation {
+	op := &spec.Operation{
+		OperationProps: spec.OperationProps{
+			Responses: &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						200: {ResponseProps: spec.ResponseProps{Schema: spec.RefSchema("#/definitions/" + ref)}},
+					},
+				},
+			},
+		},
+	}
+	if tag != "" {
+		op.Tags = []string{tag}
+	}
+	return op
+}
+
+func TestNamespaceDefinitionsByTag(t *testing.T) {
+	svc := newTestService()
+	svc.swagger.Definitions = spec.Definitions{
+		"api.Invoice": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: spec.SchemaProperties{
+					"line":  *spec.RefSchema("#/definitions/api.Line"),
+					"owner": *spec.RefSchema("#/definitions/api.User"),
+				},
+			},
+		},
+		"api.Line":   {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+		"api.User":   {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+		"api.Health": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+		"api.Orphan": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	svc.swagger.Paths = &spec.Paths{
+		Paths: map[string]spec.PathItem{
+			"/invoices": {PathItemProps: spec.PathItemProps{Get: taggedOperation("billing", "api.Invoice")}},
+			"/users":    {PathItemProps: spec.PathItemProps{Get: taggedOperation("users", "api.User")}},
+			"/health":   {PathItemProps: spec.PathItemProps{Get: taggedOperation("", "api.Health")}},
+		},
+	}
+
+	svc.namespaceDefinitionsByTag()
+
+	for _, name := range []string{"billing.api.Invoice", "billing.api.Line", "api.User", "api.Health", "api.Orphan"} {
+		if _, ok := svc.swagger.Definitions[name]; !ok {
+			t.Errorf("expected definition %s, got %v", name, schemautil.SortedKeys(svc.swagger.Definitions))
+		}
+	}
+	if len(svc.swagger.Definitions) != 5 {
+		t.Errorf("expected 5 definitions, got %v", schemautil.SortedKeys(svc.swagger.Definitions))
+	}
+
+	response := svc.swagger.Paths.Paths["/invoices"].Get.Responses.StatusCodeResponses[200]
+	if ref := response.Schema.Ref.String(); ref != "#/definitions/billing.api.Invoice" {
+		t.Errorf("expected operation ref to be rewritten, got %s", ref)
+	}
+	line := svc.swagger.Definitions["billing.api.Invoice"].Properties["line"]
+	if ref := line.Ref.String(); ref != "#/definitions/billing.api.Line" {
+		t.Errorf("expected nested ref to be rewritten, got %s", ref)
+	}
+}
@@ -0,0 +1,187 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/griffnb/core-swag/internal/schema"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+// NamingStrategy controls how definition names are derived from Go types.
+type NamingStrategy string
+
+const (
+	// NamingFull names definitions package.Type, using the full import path
+	// for types whose package.Type name is not unique. This is the default.
+	NamingFull NamingStrategy = "full"
+
+	// NamingPackage names definitions package.Type, keeping the full import
+	// path only where two generated definitions would share a name.
+	NamingPackage NamingStrategy = "package"
+
+	// NamingShort names definitions by type name only. When two packages
+	// define the same type, the later one (in name order) gets its package
+	// name as a suffix, e.g. account.User and admin.User become User and UserAdmin.
+	NamingShort NamingStrategy = "short"
+)
+
+// namingStrategy returns the configured naming strategy. UseStructName is an
+// alias for NamingPackage when no strategy is set.
+func (s *Service) namingStrategy() (NamingStrategy, error) {
+	switch s.config.NamingStrategy {
+	case "":
+		if s.config.UseStructName {
+			return NamingPackage, nil
+		}
+		return NamingFull, nil
+	case NamingFull, NamingPackage, NamingShort:
+		return s.config.NamingStrategy, nil
+	default:
+		return "", fmt.Errorf("unknown naming strategy %q, expected %s, %s or %s",
+			s.config.NamingStrategy, NamingFull, NamingPackage, NamingShort)
+	}
+}
+
+// applyNamingStrategy renames the generated definitions according to strategy
+// and rewrites every $ref to them.
+func (s *Service) applyNamingStrategy(strategy NamingStrategy) {
+	if strategy == NamingFull || len(s.swagger.Definitions) == 0 {
+		return
+	}
+
+	renames := definitionRenames(schemautil.SortedKeys(s.swagger.Definitions), s.packageNames(), strategy)
+
+	if s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Renaming %d definitions (naming strategy %s)", len(renames), strategy)
+	}
+
+	schema.RenameDefinitions(s.swagger, renames)
+}
+
+// packageNames maps the full-path qualifier of NotUnique definition names
+// (e.g. "github_com_org_repo_account") to the package name ("account").
+func (s *Service) packageNames() map[string]string {
+	names := make(map[string]string)
+	if s.registry == nil {
+		return names
+	}
+
+	for pkgPath, pkg := range s.registry.Packages() {
+		if pkg == nil || pkg.Name == "" {
+			continue
+		}
+		names[strings.TrimSuffix(makeFullPathDefName(pkgPath, ""), ".")] = pkg.Name
+	}
+
+	return names
+}
+
+// definitionName is a qualified definition name split into its parts:
+// "account.UserPublic-types_Post" has package "account", type "UserPublic"
+// and generic suffix "-types_Post".
+type definitionName struct {
+	original string
+	pkg      string
+	typeName string
+	generic  string
+	target   string
+}
+
+// definitionRenames computes the new name of each qualified definition under
+// strategy. Unqualified names (aliases, registered extra definitions) are kept
+// and take precedence over renamed definitions. Generic type arguments encoded
+// in a name are left as they are. Only changed names are returned.
+func definitionRenames(names []string, packageNames map[string]string, strategy NamingStrategy) map[string]string {
+	taken := make(map[string]bool, len(names))
+	groups := make(map[string][]definitionName)
+
+	for _, name := range names {
+		head, generic := name, ""
+		if idx := strings.Index(name, "-"); idx >= 0 {
+			head, generic = name[:idx], name[idx:]
+		}
+
+		qualifier, typeName, found := strings.Cut(head, ".")
+		if !found {
+			taken[name] = true
+			continue
+		}
+
+		def := definitionName{original: name, pkg: qualifier, typeName: typeName, generic: generic}
+		if pkg, ok := packageNames[qualifier]; ok {
+			def.pkg = pkg
+		}
+
+		if strategy == NamingShort {
+			def.target = typeName + generic
+		} else {
+			def.target = def.pkg + "." + typeName + generic
+		}
+
+		groups[def.target] = append(groups[def.target], def)
+	}
+
+	targets := schemautil.SortedKeys(groups)
+	renames := make(map[string]string)
+
+	for _, target := range targets {
+		group := groups[target]
+		// A definition that already has the target name keeps it, the rest go by name order
+		sort.SliceStable(group, func(i, j int) bool {
+			iKeeps, jKeeps := group[i].original == target, group[j].original == target
+			if iKeeps != jKeeps {
+				return iKeeps
+			}
+			return group[i].original < group[j].original
+		})
+
+		for i, def := range group {
+			name := def.target
+			if i > 0 || taken[name] {
+				name = disambiguateDefinitionName(def, strategy)
+			}
+			if taken[name] {
+				name = def.original
+			}
+
+			taken[name] = true
+			if name != def.original {
+				renames[def.original] = name
+			}
+		}
+	}
+
+	return renames
+}
+
+// disambiguateDefinitionName returns the name a definition falls back to when
+// its target name is already taken: the type name suffixed with the package
+// name (before any Public suffix) for short names, the original name otherwise.
+func disambiguateDefinitionName(def definitionName, strategy NamingStrategy) string {
+	if strategy != NamingShort {
+		return def.original
+	}
+
+	base, public := strings.CutSuffix(def.typeName, "Public")
+	if base == "" {
+		base, public = def.typeName, false
+	}
+
+	name := base + toPascalCase(def.pkg)
+	if public {
+		name += "Public"
+	}
+
+	return name + def.generic
+}
+
+// toPascalCase converts a package name like "billing_plan" to "BillingPlan".
+func toPascalCase(s string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
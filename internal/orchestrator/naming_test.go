@@ -0,0 +1,102 @@
+package orchestrator
+
+import (
+	"testing"
+)
+
+func TestNamingStrategy(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        Config
+		expected      NamingStrategy
+		expectedError bool
+	}{
+		{name: "defaults to full", config: Config{}, expected: NamingFull},
+		{name: "UseStructName is an alias for package", config: Config{UseStructName: true}, expected: NamingPackage},
+		{name: "explicit strategy wins", config: Config{UseStructName: true, NamingStrategy: NamingShort}, expected: NamingShort},
+		{name: "unknown strategy", config: Config{NamingStrategy: "camel"}, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &Service{config: &tt.config}
+			strategy, err := svc.namingStrategy()
+			if tt.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got strategy %q", strategy)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strategy != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, strategy)
+			}
+		})
+	}
+}
+
+func TestDefinitionRenames(t *testing.T) {
+	names := []string{
+		"Renamed",
+		"account.User",
+		"account.UserPublic",
+		"admin.User",
+		"admin.UserPublic",
+		"github_com_org_repo_billing_plan.Plan",
+		"api.Response-types_Post",
+		"api.GetPets.Response",
+		"model.Renamed",
+	}
+	packageNames := map[string]string{
+		"github_com_org_repo_billing_plan": "billing_plan",
+	}
+
+	t.Run("short", func(t *testing.T) {
+		renames := definitionRenames(names, packageNames, NamingShort)
+		expected := map[string]string{
+			"account.User":                          "User",
+			"account.UserPublic":                    "UserPublic",
+			"admin.User":                            "UserAdmin",
+			"admin.UserPublic":                      "UserAdminPublic",
+			"github_com_org_repo_billing_plan.Plan": "Plan",
+			"api.Response-types_Post":               "Response-types_Post",
+			"api.GetPets.Response":                  "GetPets.Response",
+			"model.Renamed":                         "RenamedModel",
+		}
+		assertRenames(t, expected, renames)
+	})
+
+	t.Run("package", func(t *testing.T) {
+		renames := definitionRenames(names, packageNames, NamingPackage)
+		expected := map[string]string{
+			"github_com_org_repo_billing_plan.Plan": "billing_plan.Plan",
+		}
+		assertRenames(t, expected, renames)
+	})
+
+	t.Run("package keeps full paths for conflicting types", func(t *testing.T) {
+		renames := definitionRenames([]string{
+			"model.Error",
+			"github_com_org_repo_model.Error",
+			"github_com_org_other_model.Error",
+		}, map[string]string{
+			"github_com_org_repo_model":  "model",
+			"github_com_org_other_model": "model",
+		}, NamingPackage)
+		assertRenames(t, map[string]string{}, renames)
+	})
+}
+
+func assertRenames(t *testing.T, expected, actual map[string]string) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Errorf("expected %d renames, got %d: %v", len(expected), len(actual), actual)
+	}
+	for from, to := range expected {
+		if actual[from] != to {
+			t.Errorf("expected %s to be renamed to %q, got %q", from, to, actual[from])
+		}
+	}
+}
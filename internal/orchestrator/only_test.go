@@ -0,0 +1,74 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParse_OnlyState(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/onlyapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Only API
+// @version 1.0
+func main() {}
+`,
+		"api/api.go": `package api
+
+type Audit struct {
+	CreatedBy string ` + "`json:\"created_by\"`" + `
+	// @Only user
+	Visibility string ` + "`json:\"visibility\"`" + `
+}
+
+type Account struct {
+	ID string ` + "`json:\"id\"`" + `
+	// Notes are internal remarks.
+	// @Only admin
+	Notes string ` + "`json:\"notes\"`" + `
+	Plan  string ` + "`json:\"plan\"`" + ` // @Only admin,user
+	// @Only admin user
+	Region string ` + "`json:\"region\"`" + `
+	// @Only admin
+	Audit
+}
+
+// @Success 200 {object} Account
+// @Router /accounts [get]
+func GetAccount() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	tests := []struct {
+		state string
+		want  string
+	}{
+		{state: "", want: "id"},
+		{state: "admin", want: "created_by,id,notes,plan,region"},
+		{state: "ADMIN", want: "created_by,id,notes,plan,region"},
+		{state: "user", want: "id,plan,region"},
+	}
+
+	for _, tt := range tests {
+		t.Run("state "+tt.state, func(t *testing.T) {
+			swagger, err := New(&Config{HostState: tt.state}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var names []string
+			for name := range swagger.Definitions["api.Account"].Properties {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if got := strings.Join(names, ","); got != tt.want {
+				t.Errorf("expected properties %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
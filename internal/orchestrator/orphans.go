@@ -0,0 +1,42 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/griffnb/core-swag/internal/schema"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+// ErrOrphanDefinition is returned in strict mode when a definition is neither reachable nor kept explicitly.
+var ErrOrphanDefinition = errors.New("orphan definition")
+
+// checkOrphanDefinitions reports definitions that can't be reached from any path,
+// shared parameter or shared response, directly or through other definitions.
+// Definitions listed in KeepDefinitions are exempt, as are the Public and base
+// variants of reachable definitions, which are always generated together.
+// Orphans fail in strict mode and are recorded as warnings otherwise.
+func (s *Service) checkOrphanDefinitions() error {
+	reachable := s.reachableDefinitions(schema.ReferencedDefinitions(s.swagger))
+
+	for _, name := range schemautil.SortedKeys(s.swagger.Definitions) {
+		if reachable[name] || reachable[name+"Public"] || reachable[strings.TrimSuffix(name, "Public")] {
+			continue
+		}
+		if _, keep := s.config.KeepDefinitions[name]; keep {
+			continue
+		}
+
+		msg := fmt.Sprintf("definition %s is not referenced by any path or definition", name)
+		if s.config.Strict {
+			return fmt.Errorf("%w: %s", ErrOrphanDefinition, msg)
+		}
+		s.addWarning(Warning{
+			Category: WarningOrphanDefinition,
+			Message:  msg,
+		})
+	}
+
+	return nil
+}
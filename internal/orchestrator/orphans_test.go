@@ -0,0 +1,73 @@
+package orchestrator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/spec"
+)
+
+func newOrphanSpec() *spec.Swagger {
+	swagger := newSwaggerSpec()
+	swagger.Definitions = spec.Definitions{
+		"api.User": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: spec.SchemaProperties{
+					"address": *spec.RefSchema("#/definitions/api.Address"),
+				},
+			},
+		},
+		"api.UserPublic": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+		"api.Address":    {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+		"api.Legacy":     {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+		"api.Unused":     {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	swagger.Paths = &spec.Paths{
+		Paths: map[string]spec.PathItem{
+			"/users": {PathItemProps: spec.PathItemProps{Get: taggedOperation("", "api.User")}},
+		},
+	}
+	return swagger
+}
+
+func TestCheckOrphanDefinitions(t *testing.T) {
+	t.Run("warns for unreachable definitions", func(t *testing.T) {
+		svc := newTestService()
+		svc.swagger = newOrphanSpec()
+
+		if err := svc.checkOrphanDefinitions(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		warnings := svc.Warnings()
+		if len(warnings) != 2 {
+			t.Fatalf("expected 2 warnings, got %d: %v", len(warnings), warnings)
+		}
+		if warnings[0].Category != WarningOrphanDefinition || warnings[0].Message != "definition api.Legacy is not referenced by any path or definition" {
+			t.Errorf("unexpected warning %+v", warnings[0])
+		}
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		svc := newTestService()
+		svc.config.Strict = true
+		svc.swagger = newOrphanSpec()
+
+		err := svc.checkOrphanDefinitions()
+		if !errors.Is(err, ErrOrphanDefinition) {
+			t.Fatalf("expected ErrOrphanDefinition, got %v", err)
+		}
+	})
+
+	t.Run("respects the allowlist", func(t *testing.T) {
+		svc := newTestService()
+		svc.config.Strict = true
+		svc.config.KeepDefinitions = map[string]struct{}{"api.Legacy": {}, "api.Unused": {}}
+		svc.swagger = newOrphanSpec()
+
+		if err := svc.checkOrphanDefinitions(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
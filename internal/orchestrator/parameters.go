@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"fmt"
+	goparser "go/parser"
+	"go/token"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/parser/route"
+	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
+)
+
+// parseSharedParameters parses the named @Parameter declarations of the main API
+// file, which routes reference with "@Param {ref} Name". The types they use are
+// added to referencedTypes so their definitions get built.
+func (s *Service) parseSharedParameters(mainFilePath string, referencedTypes map[string]RefInfo) (map[string]routedomain.Parameter, error) {
+	astFile, err := goparser.ParseFile(token.NewFileSet(), mainFilePath, nil, goparser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse shared parameters: %w", err)
+	}
+
+	params, err := s.routeParser.ParseNamedParameters(astFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse shared parameters: %w", err)
+	}
+
+	for name, param := range params {
+		collectRefsFromSchema(param.Schema, referencedTypes, "@Parameter "+name)
+	}
+
+	return params, nil
+}
+
+// addSharedParameters adds the shared parameters to #/parameters.
+func (s *Service) addSharedParameters(params map[string]routedomain.Parameter) {
+	if len(params) == 0 {
+		return
+	}
+
+	if s.swagger.Parameters == nil {
+		s.swagger.Parameters = make(map[string]spec.Parameter, len(params))
+	}
+	for name, param := range params {
+		s.swagger.Parameters[name] = route.ParameterToSpec(param)
+	}
+}
+
+// checkParameterRefs reports {ref} parameters of routes whose target is missing
+// from #/parameters. Missing targets fail in strict mode and are recorded as
+// warnings otherwise.
+func (s *Service) checkParameterRefs(routes []*routedomain.Route) error {
+	for _, r := range routes {
+		if r == nil {
+			continue
+		}
+
+		for _, param := range r.Parameters {
+			if param.Ref == "" {
+				continue
+			}
+			if _, exists := s.swagger.Parameters[param.Ref]; exists {
+				continue
+			}
+
+			msg := fmt.Sprintf("%s references #/parameters/%s, which was not declared", routeSource(r), param.Ref)
+			if s.config.Strict {
+				return fmt.Errorf("%w: %s", ErrDanglingRef, msg)
+			}
+			s.addWarning(Warning{
+				Category: WarningDanglingRef,
+				Message:  msg,
+				File:     r.FilePath,
+				Line:     r.LineNumber,
+			})
+		}
+	}
+
+	return nil
+}
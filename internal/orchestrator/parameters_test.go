@@ -0,0 +1,92 @@
+package orchestrator
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/griffnb/core-swag/internal/model"
+)
+
+func TestParse_SharedParameters(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/paramsapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Params API
+// @version 1.0
+// @Parameter PageParam query page int false "Page number" default(1)
+// @Parameter PerPageParam query per_page int false "Items per page" maximum(100)
+func main() {}
+`,
+		"api/api.go": `package api
+
+// @Param {ref} PageParam
+// @Param {ref} PerPageParam
+// @Success 200 {array} string
+// @Router /users [get]
+func ListUsers() {}
+
+// @Param {ref} PageParam
+// @Param {ref} PerPageParam
+// @Param q query string false "Search"
+// @Success 200 {array} string
+// @Router /pets [get]
+func ListPets() {}
+
+// @Param {ref} CursorParam
+// @Success 200 {array} string
+// @Router /stores [get]
+func ListStores() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	t.Run("declares shared parameters referenced by operations", func(t *testing.T) {
+		model.Cache().Reset()
+		svc := New(&Config{})
+		swagger, err := svc.Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		page, ok := swagger.Parameters["PageParam"]
+		if !ok || page.Name != "page" || page.In != "query" || page.Type != "integer" || page.Default != float64(1) {
+			t.Errorf("unexpected PageParam %+v", page)
+		}
+		if perPage := swagger.Parameters["PerPageParam"]; perPage.Name != "per_page" || perPage.Maximum == nil || *perPage.Maximum != 100 {
+			t.Errorf("unexpected PerPageParam %+v", perPage)
+		}
+
+		for _, path := range []string{"/users", "/pets"} {
+			params := swagger.Paths.Paths[path].Get.Parameters
+			if len(params) < 2 {
+				t.Fatalf("%s: expected the shared parameters, got %+v", path, params)
+			}
+			for i, want := range []string{"#/parameters/PageParam", "#/parameters/PerPageParam"} {
+				if got := params[i].Ref.String(); got != want {
+					t.Errorf("%s: expected parameter %d to reference %s, got %q", path, i, want, got)
+				}
+			}
+		}
+
+		var dangling []Warning
+		for _, warning := range svc.Warnings() {
+			if warning.Category == WarningDanglingRef {
+				dangling = append(dangling, warning)
+			}
+		}
+		if len(dangling) != 1 || dangling[0].Message != "GET /stores → ListStores (api.go:19) references #/parameters/CursorParam, which was not declared" {
+			t.Errorf("expected a warning for CursorParam, got %v", dangling)
+		}
+	})
+
+	t.Run("fails for undeclared parameters in strict mode", func(t *testing.T) {
+		model.Cache().Reset()
+		_, err := New(&Config{Strict: true}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if !errors.Is(err, ErrDanglingRef) {
+			t.Fatalf("expected ErrDanglingRef, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package orchestrator
+
+import (
+	"fmt"
+	goparser "go/parser"
+	"go/token"
+	"sort"
+
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/parser/route"
+	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
+)
+
+// parseSharedResponses parses the named @Response declarations of the main API
+// file, which routes reference with "@Failure 500 {ref} Name". The types they
+// use are added to referencedTypes so their definitions get built.
+func (s *Service) parseSharedResponses(mainFilePath string, referencedTypes map[string]RefInfo) (map[string]routedomain.Response, error) {
+	astFile, err := goparser.ParseFile(token.NewFileSet(), mainFilePath, nil, goparser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse shared responses: %w", err)
+	}
+
+	responses, err := s.routeParser.ParseNamedResponses(astFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse shared responses: %w", err)
+	}
+
+	for name, resp := range responses {
+		collectRefsFromSchema(resp.Schema, referencedTypes, "@Response "+name)
+	}
+
+	return responses, nil
+}
+
+// addSharedResponses adds the shared responses to #/responses.
+func (s *Service) addSharedResponses(responses map[string]routedomain.Response) {
+	if len(responses) == 0 {
+		return
+	}
+
+	if s.swagger.Responses == nil {
+		s.swagger.Responses = make(map[string]spec.Response, len(responses))
+	}
+	for name, resp := range responses {
+		s.swagger.Responses[name] = route.ResponseToSpec(resp)
+	}
+}
+
+// checkResponseRefs reports {ref} responses of routes whose target is missing
+// from #/responses. Missing targets fail in strict mode and are recorded as
+// warnings otherwise.
+func (s *Service) checkResponseRefs(routes []*routedomain.Route) error {
+	for _, r := range routes {
+		if r == nil {
+			continue
+		}
+
+		codes := make([]int, 0, len(r.Responses))
+		for code := range r.Responses {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+
+		for _, code := range codes {
+			name := r.Responses[code].Ref
+			if name == "" {
+				continue
+			}
+			if _, exists := s.swagger.Responses[name]; exists {
+				continue
+			}
+
+			msg := fmt.Sprintf("%s response %d references #/responses/%s, which was not declared", routeSource(r), code, name)
+			if s.config.Strict {
+				return fmt.Errorf("%w: %s", ErrDanglingRef, msg)
+			}
+			s.addWarning(Warning{
+				Category: WarningDanglingRef,
+				Message:  msg,
+				File:     r.FilePath,
+				Line:     r.LineNumber,
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package orchestrator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
+)
+
+func TestSharedResponses(t *testing.T) {
+	mainFile := filepath.Join(t.TempDir(), "main.go")
+	src := `package main
+
+// @title Test API
+// @Response DefaultError {object} string "Server error"
+func main() {}
+`
+	if err := os.WriteFile(mainFile, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := newTestService()
+	responses, err := svc.parseSharedResponses(mainFile, make(map[string]RefInfo))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc.addSharedResponses(responses)
+
+	resp, ok := svc.swagger.Responses["DefaultError"]
+	if !ok {
+		t.Fatalf("expected DefaultError in #/responses, got %v", svc.swagger.Responses)
+	}
+	if resp.Description != "Server error" {
+		t.Errorf("expected description %q, got %q", "Server error", resp.Description)
+	}
+}
+
+func TestCheckResponseRefs(t *testing.T) {
+	routes := []*routedomain.Route{
+		{
+			Method: "GET", Path: "/users", FilePath: "users.go", LineNumber: 12,
+			Responses: map[int]routedomain.Response{
+				400: {Ref: "BadRequest"},
+				500: {Ref: "DefaultError"},
+			},
+		},
+	}
+
+	newService := func() *Service {
+		svc := newTestService()
+		svc.swagger.Responses = map[string]spec.Response{
+			"DefaultError": {ResponseProps: spec.ResponseProps{Description: "Server error"}},
+		}
+		return svc
+	}
+
+	t.Run("warns for undeclared responses", func(t *testing.T) {
+		svc := newService()
+
+		if err := svc.checkResponseRefs(routes); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		warnings := svc.Warnings()
+		if len(warnings) != 1 {
+			t.Fatalf("expected 1 warning, got %d: %v", len(warnings), warnings)
+		}
+		if warnings[0].Category != WarningDanglingRef || warnings[0].File != "users.go" || warnings[0].Line != 12 {
+			t.Errorf("unexpected warning: %+v", warnings[0])
+		}
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		svc := newService()
+		svc.config.Strict = true
+
+		if err := svc.checkResponseRefs(routes); !errors.Is(err, ErrDanglingRef) {
+			t.Fatalf("expected ErrDanglingRef, got %v", err)
+		}
+	})
+}
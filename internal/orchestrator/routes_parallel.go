@@ -2,6 +2,7 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
 	"go/ast"
 	"runtime"
@@ -10,7 +11,6 @@ import (
 
 	"github.com/go-openapi/spec"
 	"github.com/griffnb/core-swag/internal/loader"
-	"github.com/griffnb/core-swag/internal/parser/route"
 	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
 	"golang.org/x/sync/errgroup"
 )
@@ -25,12 +25,16 @@ type fileRoutes struct {
 // bounded by the number of CPUs. Results are sorted by file path to ensure
 // deterministic output regardless of goroutine scheduling order.
 // Returns the accumulated routes, the total operation count, and any error.
-func (s *Service) parseRoutesParallel(files map[*ast.File]*loader.AstFileInfo) ([]*routedomain.Route, int, error) {
+// Files not started yet are skipped once ctx is done, returning ctx.Err().
+func (s *Service) parseRoutesParallel(ctx context.Context, files map[*ast.File]*loader.AstFileInfo) ([]*routedomain.Route, int, error) {
 	var (
 		mu       sync.Mutex
 		collected []fileRoutes
 	)
 
+	// Files are parsed concurrently, so the warnings they report are sorted afterwards
+	warningCount := s.warnings.Len()
+
 	var g errgroup.Group
 	g.SetLimit(runtime.NumCPU())
 
@@ -43,6 +47,9 @@ func (s *Service) parseRoutesParallel(files map[*ast.File]*loader.AstFileInfo) (
 		astFile, fileInfo := astFile, fileInfo
 
 		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			routes, err := s.routeParser.ParseRoutes(astFile, fileInfo.Path, fileInfo.FileSet)
 			if err != nil {
 				return fmt.Errorf("failed to parse routes from %s: %w", fileInfo.Path, err)
@@ -65,52 +72,71 @@ func (s *Service) parseRoutesParallel(files map[*ast.File]*loader.AstFileInfo) (
 	if err := g.Wait(); err != nil {
 		return nil, 0, err
 	}
+	s.warnings.SortFrom(warningCount)
 
 	// Sort by file path for deterministic output.
 	sort.Slice(collected, func(i, j int) bool {
 		return collected[i].filePath < collected[j].filePath
 	})
 
-	// Merge sorted results into swagger.Paths sequentially.
+	// Register sorted results into swagger.Paths sequentially.
 	var allRoutes []*routedomain.Route
-	routeCount := 0
-
 	for _, fr := range collected {
 		allRoutes = append(allRoutes, fr.routes...)
+	}
 
-		for _, r := range fr.routes {
-			operation := route.RouteToSpecOperation(r)
-			if operation == nil {
-				continue
-			}
+	s.ensureSwaggerPaths()
+	if err := s.routeParser.RegisterRoutes(s.swagger, allRoutes, s.config.Strict); err != nil {
+		return nil, 0, err
+	}
 
-			s.ensureSwaggerPaths()
-
-			pathItem := s.swagger.Paths.Paths[r.Path]
-
-			switch r.Method {
-			case "GET":
-				pathItem.Get = operation
-			case "POST":
-				pathItem.Post = operation
-			case "PUT":
-				pathItem.Put = operation
-			case "DELETE":
-				pathItem.Delete = operation
-			case "PATCH":
-				pathItem.Patch = operation
-			case "OPTIONS":
-				pathItem.Options = operation
-			case "HEAD":
-				pathItem.Head = operation
-			}
+	s.warnDuplicateOperationIDs(allRoutes)
+
+	return allRoutes, len(allRoutes), nil
+}
 
-			s.swagger.Paths.Paths[r.Path] = pathItem
-			routeCount++
+// duplicateOperationIDs returns each operationId used by more than one route,
+// mapped to the routes that use it in parse order.
+func duplicateOperationIDs(routes []*routedomain.Route) map[string][]*routedomain.Route {
+	seen := make(map[string][]*routedomain.Route)
+	for _, r := range routes {
+		if r == nil || r.OperationID == "" {
+			continue
 		}
+		seen[r.OperationID] = append(seen[r.OperationID], r)
 	}
 
-	return allRoutes, routeCount, nil
+	duplicates := make(map[string][]*routedomain.Route)
+	for id, users := range seen {
+		if len(users) > 1 {
+			duplicates[id] = users
+		}
+	}
+	return duplicates
+}
+
+// warnDuplicateOperationIDs records a warning for every route that reuses an
+// operationId already taken by an earlier route.
+func (s *Service) warnDuplicateOperationIDs(routes []*routedomain.Route) {
+	duplicates := duplicateOperationIDs(routes)
+	ids := make([]string, 0, len(duplicates))
+	for id := range duplicates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		first := duplicates[id][0]
+		for _, r := range duplicates[id][1:] {
+			s.addWarning(Warning{
+				Category: WarningDuplicateOperationID,
+				Message: fmt.Sprintf("duplicate operationId %q on %s %s, already used by %s %s",
+					id, r.Method, r.Path, first.Method, first.Path),
+				File: r.FilePath,
+				Line: r.LineNumber,
+			})
+		}
+	}
 }
 
 // ensureSwaggerPaths initializes the swagger Paths map if it has not been created yet.
@@ -1,6 +1,8 @@
 package orchestrator
 
 import (
+	"context"
+	"errors"
 	"go/ast"
 	"go/parser"
 	"go/token"
@@ -9,8 +11,10 @@ import (
 	"testing"
 
 	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/console"
 	"github.com/griffnb/core-swag/internal/loader"
 	"github.com/griffnb/core-swag/internal/parser/route"
+	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
 )
 
 // newSwaggerSpec creates a minimal swagger spec for testing, mirroring
@@ -49,6 +53,7 @@ func newTestService() *Service {
 		routeParser: rp,
 		config:      &Config{},
 		swagger:     newSwaggerSpec(),
+		warnings:    console.NewDiagnostics(),
 	}
 }
 
@@ -56,7 +61,7 @@ func TestParseRoutesParallel_EmptyFiles(t *testing.T) {
 	svc := newTestService()
 	files := make(map[*ast.File]*loader.AstFileInfo)
 
-	routes, count, err := svc.parseRoutesParallel(files)
+	routes, count, err := svc.parseRoutesParallel(context.Background(), files)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -68,6 +73,31 @@ func TestParseRoutesParallel_EmptyFiles(t *testing.T) {
 	}
 }
 
+func TestParseRoutesParallel_Canceled(t *testing.T) {
+	dir := t.TempDir()
+	af, fset, fp := makeASTFile(t, dir, "handler.go", `package api
+
+// @router /users [get]
+func ListUsers() {}
+`)
+
+	svc := newTestService()
+	files := map[*ast.File]*loader.AstFileInfo{
+		af: {Path: fp, FileSet: fset},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	routes, _, err := svc.parseRoutesParallel(ctx, files)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(routes) != 0 {
+		t.Errorf("expected no routes, got %d", len(routes))
+	}
+}
+
 func TestParseRoutesParallel_SingleFile(t *testing.T) {
 	dir := t.TempDir()
 	src := `package api
@@ -89,7 +119,7 @@ func CreateUser() {}
 		af: {Path: fp, FileSet: fset},
 	}
 
-	routes, count, err := svc.parseRoutesParallel(files)
+	routes, count, err := svc.parseRoutesParallel(context.Background(), files)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -146,7 +176,7 @@ func MHandler() {}
 	// Run multiple times to verify determinism despite map ordering.
 	for i := 0; i < 10; i++ {
 		svc2 := newTestService()
-		routes, _, err := svc2.parseRoutesParallel(files)
+		routes, _, err := svc2.parseRoutesParallel(context.Background(), files)
 		if err != nil {
 			t.Fatalf("iteration %d: unexpected error: %v", i, err)
 		}
@@ -196,7 +226,7 @@ func Delete() {}
 		af: {Path: fp, FileSet: fset},
 	}
 
-	routes, count, err := svc.parseRoutesParallel(files)
+	routes, count, err := svc.parseRoutesParallel(context.Background(), files)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -252,7 +282,7 @@ func GetUsers() {}
 		afB: {Path: fpB, FileSet: fsetB},
 	}
 
-	routes, count, err := svc.parseRoutesParallel(files)
+	routes, count, err := svc.parseRoutesParallel(context.Background(), files)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -329,7 +359,7 @@ func HeadHandler() {}
 		af: {Path: fp, FileSet: fset},
 	}
 
-	_, count, err := svc.parseRoutesParallel(files)
+	_, count, err := svc.parseRoutesParallel(context.Background(), files)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -387,7 +417,7 @@ func Helper() {}
 		afNoRoutes: {Path: fpNoRoutes, FileSet: fsetNoRoutes},
 	}
 
-	routes, count, err := svc.parseRoutesParallel(files)
+	routes, count, err := svc.parseRoutesParallel(context.Background(), files)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -407,7 +437,7 @@ func TestParseRoutesParallel_NilASTGuard(t *testing.T) {
 		nil: {Path: "/nonexistent/bad.go", FileSet: token.NewFileSet()},
 	}
 
-	routes, count, err := svc.parseRoutesParallel(files)
+	routes, count, err := svc.parseRoutesParallel(context.Background(), files)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -441,7 +471,7 @@ func Handler` + name + `() {}
 
 	svc := newTestService()
 
-	routes, count, err := svc.parseRoutesParallel(files)
+	routes, count, err := svc.parseRoutesParallel(context.Background(), files)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -457,3 +487,46 @@ func Handler` + name + `() {}
 		t.Errorf("expected 20 swagger paths, got %d", len(svc.swagger.Paths.Paths))
 	}
 }
+
+func TestDuplicateOperationIDs(t *testing.T) {
+	routes := []*routedomain.Route{
+		{Method: "GET", Path: "/users", OperationID: "listUsers"},
+		{Method: "GET", Path: "/admin/users", OperationID: "listUsers"},
+		{Method: "GET", Path: "/accounts", OperationID: "listAccounts"},
+		{Method: "POST", Path: "/accounts", OperationID: ""},
+		{Method: "PUT", Path: "/accounts", OperationID: ""},
+		nil,
+	}
+
+	duplicates := duplicateOperationIDs(routes)
+	if len(duplicates) != 1 {
+		t.Fatalf("expected 1 duplicate operationId, got %d: %v", len(duplicates), duplicates)
+	}
+	users := duplicates["listUsers"]
+	if len(users) != 2 || users[0].Path != "/users" || users[1].Path != "/admin/users" {
+		t.Errorf("unexpected routes for listUsers: %v", users)
+	}
+}
+
+func TestWarnDuplicateOperationIDs(t *testing.T) {
+	svc := newTestService()
+	svc.warnDuplicateOperationIDs([]*routedomain.Route{
+		{Method: "GET", Path: "/users", OperationID: "listUsers", FilePath: "users.go", LineNumber: 10},
+		{Method: "GET", Path: "/admin/users", OperationID: "listUsers", FilePath: "admin.go", LineNumber: 20},
+	})
+
+	warnings := svc.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d", len(warnings))
+	}
+	if warnings[0].Category != WarningDuplicateOperationID {
+		t.Errorf("unexpected category %q", warnings[0].Category)
+	}
+	if warnings[0].File != "admin.go" || warnings[0].Line != 20 {
+		t.Errorf("expected warning at admin.go:20, got %s:%d", warnings[0].File, warnings[0].Line)
+	}
+
+	if warnings[0].String() != "warning: admin.go:20: "+warnings[0].Message {
+		t.Errorf("unexpected warning output %q", warnings[0].String())
+	}
+}
@@ -2,6 +2,8 @@
 package orchestrator
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
@@ -35,13 +37,36 @@ type structRefResult struct {
 // actually referenced by route annotations. Struct types are built concurrently
 // via BuildAllSchemas (which is internally thread-safe). Non-struct types
 // (enums, aliases) are built sequentially via the SchemaBuilder.
-func (s *Service) buildDemandDrivenSchemas(referencedTypes map[string]RefInfo) error {
+func (s *Service) buildDemandDrivenSchemas(ctx context.Context, referencedTypes map[string]RefInfo) error {
 	if s.swagger.Definitions == nil {
 		s.swagger.Definitions = make(spec.Definitions)
 	}
 
 	// Phase 1: Resolve refs and partition into struct vs non-struct work.
-	// Registry lookups are read-only so this is safe to do sequentially.
+	structWork, nonStructRefs := s.resolveWork(referencedTypes)
+
+	// Phase 1.5: Pre-warm packages with Syntax in a single batched call.
+	s.preWarm(structWork)
+
+	// Phase 2: Build struct schemas concurrently.
+	// BuildAllSchemas creates a fresh CoreStructParser per call and only
+	// touches mutex-protected global caches, so it is safe to parallelize.
+	results, err := s.buildStructSchemasConcurrent(ctx, s.structParser, structWork)
+	if err != nil {
+		return err
+	}
+
+	// Phase 3: Merge struct results into swagger definitions sequentially.
+	mergeStructResults(s.swagger.Definitions, results)
+
+	// Phases 4 and 5: Build non-struct schemas.
+	return s.buildNonStructSchemas(ctx, nonStructRefs)
+}
+
+// resolveWork resolves the referenced types and partitions them into struct and
+// non-struct work, both sorted. Registry lookups are read-only so this is safe
+// to do sequentially.
+func (s *Service) resolveWork(referencedTypes map[string]RefInfo) ([]structRefWork, []resolvedNonStructRef) {
 	processed := make(map[string]bool)
 	var structWork []structRefWork
 	var nonStructRefs []resolvedNonStructRef
@@ -60,7 +85,7 @@ func (s *Service) buildDemandDrivenSchemas(referencedTypes map[string]RefInfo) e
 			structWork = append(structWork, structRefWork{
 				baseName:      baseName,
 				pkgPath:       typeDef.PkgPath,
-				typeName:      typeDef.Name(),
+				typeName:      typeDef.ScopedName(),
 				goPackageName: goPackageName,
 			})
 		} else {
@@ -84,52 +109,60 @@ func (s *Service) buildDemandDrivenSchemas(referencedTypes map[string]RefInfo) e
 		return nonStructRefs[i].baseName < nonStructRefs[j].baseName
 	})
 
-	// Phase 1.5: Pre-warm packages with Syntax in a single batched call.
-	// This replaces N sequential `go list` subprocesses with one batched call.
-	if err := preWarmPackages(structWork, s.config.Debug); err != nil {
+	return structWork, nonStructRefs
+}
+
+// preWarm loads the packages of the struct work in a single batched call.
+// This replaces N sequential `go list` subprocesses with one batched call.
+func (s *Service) preWarm(structWork []structRefWork) {
+	if err := preWarmPackages(structWork, s.config.BuildTags, s.config.Debug); err != nil {
 		// Non-fatal: concurrent builds fall back to individual loads
 		// (deduplicated by singleflight).
 		if s.config.Debug != nil {
 			s.config.Debug.Printf("Orchestrator: preWarmPackages failed (non-fatal): %v", err)
 		}
 	}
+}
 
-	// Phase 2: Build struct schemas concurrently.
-	// BuildAllSchemas creates a fresh CoreStructParser per call and only
-	// touches mutex-protected global caches, so it is safe to parallelize.
-	results, err := s.buildStructSchemasConcurrent(structWork)
-	if err != nil {
-		return err
-	}
-
-	// Phase 3: Merge struct results into swagger definitions sequentially.
-	// Sort results by base name so the merge order is deterministic
-	// regardless of goroutine completion order.
+// mergeStructResults merges the schemas of concurrent builds into definitions.
+// Results are sorted by base name so the merge order is deterministic
+// regardless of goroutine completion order.
+func mergeStructResults(definitions spec.Definitions, results []structRefResult) {
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].base < results[j].base
 	})
-	// When multiple concurrent builds produce schemas for the same name
-	// (e.g., through recursive nested type resolution), prefer the schema
-	// with more properties — empty schemas from failed package resolution
-	// should not shadow proper schemas from the type's own build.
 	for _, r := range results {
 		for name, schema := range r.schemas {
-			existing, exists := s.swagger.Definitions[name]
-			if !exists {
-				s.swagger.Definitions[name] = *schema
-			} else if len(existing.Properties) == 0 && len(schema.Properties) > 0 {
-				s.swagger.Definitions[name] = *schema
-			}
+			mergeStructSchema(definitions, name, *schema)
 		}
 	}
+}
+
+// mergeStructSchema adds schema to definitions. When multiple builds produce
+// schemas for the same name (e.g., through recursive nested type resolution),
+// the schema with more properties is preferred — empty schemas from failed
+// package resolution should not shadow proper schemas from the type's own build.
+func mergeStructSchema(definitions spec.Definitions, name string, schema spec.Schema) {
+	existing, exists := definitions[name]
+	if !exists {
+		definitions[name] = schema
+	} else if len(existing.Properties) == 0 && len(schema.Properties) > 0 {
+		definitions[name] = schema
+	}
+}
 
-	// Phase 4: Build non-struct schemas sequentially.
+// buildNonStructSchemas builds the non-struct schemas and syncs them into the
+// swagger definitions.
+func (s *Service) buildNonStructSchemas(ctx context.Context, nonStructRefs []resolvedNonStructRef) error {
 	// SchemaBuilder has no internal synchronization so these cannot be parallelized.
 	for _, ref := range nonStructRefs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		s.buildNonStructSchema(ref)
 	}
 
-	// Phase 5: Sync any non-struct schemas built via SchemaBuilder.
+	// Sync any non-struct schemas built via SchemaBuilder.
 	for name, schema := range s.schemaBuilder.Definitions() {
 		if _, exists := s.swagger.Definitions[name]; !exists {
 			s.swagger.Definitions[name] = schema
@@ -139,9 +172,10 @@ func (s *Service) buildDemandDrivenSchemas(referencedTypes map[string]RefInfo) e
 	return nil
 }
 
-// buildStructSchemasConcurrent runs BuildAllSchemas for each struct type in
+// buildStructSchemasConcurrent builds the schemas of each struct type with parser in
 // parallel, bounded by NumCPU. Results are collected under a mutex and returned.
-func (s *Service) buildStructSchemasConcurrent(work []structRefWork) ([]structRefResult, error) {
+// Types not started yet are skipped once ctx is done.
+func (s *Service) buildStructSchemasConcurrent(ctx context.Context, parser *model.CoreStructParser, work []structRefWork) ([]structRefResult, error) {
 	var (
 		mu      sync.Mutex
 		results []structRefResult
@@ -158,7 +192,13 @@ func (s *Service) buildStructSchemasConcurrent(work []structRefWork) ([]structRe
 	for _, w := range work {
 		w := w
 		g.Go(func() error {
-			schemas, err := model.BuildAllSchemasWithCache("", w.pkgPath, w.typeName, sharedCache, w.goPackageName)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			schemas, err := parser.BuildAllSchemasWithCache("", w.pkgPath, w.typeName, sharedCache, w.goPackageName)
+			if errors.Is(err, model.ErrTypeDepthExceeded) || errors.Is(err, model.ErrEmptyEnum) {
+				return err
+			}
 			if err != nil {
 				if s.config.Debug != nil {
 					s.config.Debug.Printf("Orchestrator: BuildAllSchemas FAILED for %s (pkg=%s): %v",
@@ -182,6 +222,9 @@ func (s *Service) buildStructSchemasConcurrent(work []structRefWork) ([]structRe
 	}
 
 	if err := g.Wait(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, fmt.Errorf("concurrent schema build: %w", err)
 	}
 
@@ -258,7 +301,7 @@ func (s *Service) buildNonStructSchema(ref resolvedNonStructRef) {
 // preWarmPackages loads all unique package paths from the work slice in a single
 // batched packages.Load call. This triggers one `go list` invocation that
 // resolves everything, dramatically faster than N individual calls.
-func preWarmPackages(work []structRefWork, debug Debugger) error {
+func preWarmPackages(work []structRefWork, buildTags []string, debug Debugger) error {
 	// Collect unique pkgPaths that aren't already cached with Syntax.
 	seen := make(map[string]bool, len(work))
 	var paths []string
@@ -287,7 +330,8 @@ func preWarmPackages(work []structRefWork, debug Debugger) error {
 	cfg := &packages.Config{
 		Mode: packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo |
 			packages.NeedName | packages.NeedImports | packages.NeedDeps,
-		Fset: token.NewFileSet(),
+		Fset:       token.NewFileSet(),
+		BuildFlags: model.BuildTagFlags(buildTags),
 	}
 	pkgs, err := packages.Load(cfg, paths...)
 	if err != nil {
@@ -0,0 +1,87 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParse_SecurityDefinitions(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/securityapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Security API
+// @version 1.0
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Key issued in the dashboard
+// @securityDefinitions.oauth2.accessCode OAuth2
+// @tokenUrl https://example.com/oauth/token
+// @authorizationUrl https://example.com/oauth/authorize
+// @scope.read Read access
+// @scope.write Write access
+// @host api.example.com
+// @BasePath /v1
+func main() {}
+`,
+		"api/api.go": `package api
+
+// @Security ApiKeyAuth
+// @Security OAuth2[read, write]
+// @Success 200 {string} string
+// @Router /things [get]
+func GetThings() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	swagger, err := New(&Config{}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	apiKey := swagger.SecurityDefinitions["ApiKeyAuth"]
+	if apiKey == nil {
+		t.Fatal("expected the ApiKeyAuth security definition")
+	}
+	if apiKey.Type != "apiKey" || apiKey.In != "header" || apiKey.Name != "X-API-Key" {
+		t.Errorf("unexpected ApiKeyAuth definition %+v", apiKey.SecuritySchemeProps)
+	}
+	if apiKey.Description != "Key issued in the dashboard" {
+		t.Errorf("unexpected ApiKeyAuth description %q", apiKey.Description)
+	}
+
+	oauth2 := swagger.SecurityDefinitions["OAuth2"]
+	if oauth2 == nil {
+		t.Fatal("expected the OAuth2 security definition")
+	}
+	if oauth2.Type != "oauth2" || oauth2.Flow != "accessCode" {
+		t.Errorf("unexpected OAuth2 definition %+v", oauth2.SecuritySchemeProps)
+	}
+	if oauth2.TokenURL != "https://example.com/oauth/token" || oauth2.AuthorizationURL != "https://example.com/oauth/authorize" {
+		t.Errorf("unexpected OAuth2 urls %q and %q", oauth2.TokenURL, oauth2.AuthorizationURL)
+	}
+	if len(oauth2.Scopes) != 2 || oauth2.Scopes["read"] != "Read access" || oauth2.Scopes["write"] != "Write access" {
+		t.Errorf("unexpected OAuth2 scopes %v", oauth2.Scopes)
+	}
+
+	// The general info after the definitions is still parsed
+	if swagger.Host != "api.example.com" || swagger.BasePath != "/v1" {
+		t.Errorf("expected host and basePath after the security definitions, got %q and %q", swagger.Host, swagger.BasePath)
+	}
+
+	// Operation security refers to the declared schemes
+	security := swagger.Paths.Paths["/things"].Get.Security
+	if len(security) != 2 {
+		t.Fatalf("expected 2 security requirements, got %v", security)
+	}
+	for _, requirement := range security {
+		for name := range requirement {
+			if swagger.SecurityDefinitions[name] == nil {
+				t.Errorf("operation security %s has no security definition", name)
+			}
+		}
+	}
+}
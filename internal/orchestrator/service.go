@@ -3,17 +3,21 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"runtime"
 
 	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/console"
 	"github.com/griffnb/core-swag/internal/loader"
 	"github.com/griffnb/core-swag/internal/model"
 	"github.com/griffnb/core-swag/internal/parser/base"
 	"github.com/griffnb/core-swag/internal/parser/route"
 	"github.com/griffnb/core-swag/internal/registry"
 	"github.com/griffnb/core-swag/internal/schema"
+	"github.com/griffnb/core-swag/internal/typeregistry"
+	"golang.org/x/tools/go/packages"
 )
 
 // Service coordinates all parsing services to generate OpenAPI documentation.
@@ -23,8 +27,14 @@ type Service struct {
 	schemaBuilder *schema.BuilderService
 	baseParser    *base.Service
 	routeParser   *route.Service
+	structParser  *model.CoreStructParser
 	swagger       *spec.Swagger
 	config        *Config
+	warnings      *console.Diagnostics
+	state         *BuildState
+
+	// extraDefinitions are registered through AddDefinition and merged after schema building
+	extraDefinitions map[string]spec.Schema
 }
 
 // Config holds orchestrator configuration options.
@@ -46,9 +56,42 @@ type Config struct {
 	HostState               string
 	ParseFuncBody           bool
 	UseStructName           bool
+	NamingStrategy          NamingStrategy
+	NamespaceByTag          bool
+	KeepDefinitions         map[string]struct{}
 	Overrides               map[string]string
 	Tags                    map[string]struct{}
+	EmitNullable            bool
+	PointersOptional        bool
+	InlineEnums             bool
+	DurationAsInt           bool
+	EmitFieldOrder          bool
+	EmitOmitEmpty           bool
+	BuildTags               []string
+	StrictEnums             bool
 	Debug                   Debugger
+
+	// PrimitiveTypes maps further Go types to primitive schemas, e.g. the primitive overrides of the overrides file
+	PrimitiveTypes map[string]typeregistry.TypeEntry
+
+	// MaxTypeDepth bounds how deeply nested and embedded types are resolved, zero uses model.DefaultMaxTypeDepth
+	MaxTypeDepth int
+
+	// DedupeIdenticalDefinitions merges definitions of the same type from different packages whose schemas are equal
+	DedupeIdenticalDefinitions bool
+
+	// InlineSingleUseDefinitions replaces the only $ref to an object definition with its schema and removes the definition
+	InlineSingleUseDefinitions bool
+
+	// Incremental records a BuildState and reuses the definitions of PreviousState when their inputs are unchanged
+	Incremental   bool
+	PreviousState *BuildState
+	// StateInputs are other files the definitions depend on, e.g. the overrides file
+	StateInputs []string
+
+	// DefinitionHook, when set, is called with every definition before the naming strategy is applied,
+	// including Public variants and definitions registered through AddDefinition
+	DefinitionHook func(name string, schema *spec.Schema)
 }
 
 // Debugger is the interface for debug logging.
@@ -98,6 +141,7 @@ func New(config *Config) *Service {
 		loader.WithParseExtension(config.ParseExtension),
 		loader.WithGoList(config.ParseGoList),
 		loader.WithGoPackages(config.ParseGoPackages),
+		loader.WithBuildTags(config.BuildTags),
 		loader.WithDebugger(config.Debug),
 	)
 
@@ -114,8 +158,33 @@ func New(config *Config) *Service {
 	schemaBuilder.SetPropNamingStrategy(config.PropNamingStrategy)
 	schemaBuilder.SetTypeResolver(registryService) // Enable type alias resolution
 
+	// Type mappings of this service, the built-ins plus the configured primitive types
+	types := typeregistry.New()
+	for typeName, entry := range config.PrimitiveTypes {
+		types.Register(typeName, entry)
+	}
+	types.SetDurationAsInt(config.DurationAsInt)
+
+	// Route parsing and schema building report to the same collector as the orchestrator
+	warnings := console.NewDiagnostics()
+
 	// Create and configure CoreStructParser for proper field resolution
 	coreStructParser := &model.CoreStructParser{}
+	coreStructParser.SetOptions(model.SchemaOptions{
+		RequiredByDefault:  config.RequiredByDefault,
+		EmitNullable:       config.EmitNullable,
+		PointersOptional:   config.PointersOptional,
+		InlineEnums:        config.InlineEnums,
+		PropNamingStrategy: config.PropNamingStrategy,
+		EmitFieldOrder:     config.EmitFieldOrder,
+		EmitOmitEmpty:      config.EmitOmitEmpty,
+		State:              config.HostState,
+		StrictEnums:        config.StrictEnums,
+		MaxTypeDepth:       config.MaxTypeDepth,
+		BuildTags:          config.BuildTags,
+		Types:              types,
+	})
+	coreStructParser.SetWarnings(warnings)
 	schemaBuilder.SetStructParser(coreStructParser)
 
 	// Create enum lookup using CoreStructParser
@@ -158,8 +227,12 @@ func New(config *Config) *Service {
 	if config.MarkdownFileDir != "" {
 		routeParser.SetMarkdownFileDir(config.MarkdownFileDir)
 	}
+	routeParser.SetStrict(config.Strict)
+	routeParser.SetPropNamingStrategy(config.PropNamingStrategy)
+	routeParser.SetTypes(types)
 	// Inject registry for @NoPublic annotation support
 	routeParser.SetRegistry(registryService)
+	routeParser.SetWarnings(warnings)
 
 	return &Service{
 		loader:        loaderService,
@@ -167,18 +240,42 @@ func New(config *Config) *Service {
 		schemaBuilder: schemaBuilder,
 		baseParser:    baseParser,
 		routeParser:   routeParser,
+		structParser:  coreStructParser,
 		swagger:       swagger,
 		config:        config,
+		warnings:      warnings,
 	}
 }
 
 // Parse generates OpenAPI documentation from the given search directories and main API file.
 // This is the main entry point that coordinates all services.
 func (s *Service) Parse(searchDirs []string, mainAPIFile string, parseDepth int) (*spec.Swagger, error) {
+	return s.ParseContext(context.Background(), searchDirs, mainAPIFile, parseDepth)
+}
+
+// ParseContext is Parse with cancellation: once ctx is done, it stops between files
+// and packages and returns ctx.Err(). Loading the search directories is not interrupted.
+func (s *Service) ParseContext(ctx context.Context, searchDirs []string, mainAPIFile string, parseDepth int) (*spec.Swagger, error) {
+	namingStrategy, strategyErr := s.startParse()
+	if strategyErr != nil {
+		return nil, strategyErr
+	}
+
 	if s.config.Debug != nil {
 		s.config.Debug.Printf("Orchestrator: Starting parse with %d search dirs", len(searchDirs))
 	}
 
+	loadResult, mainFilePath, err := s.load(searchDirs, mainAPIFile, parseDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parseLoaded(ctx, loadResult, mainFilePath, namingStrategy)
+}
+
+// load loads the search directories, and their dependencies up to parseDepth, and
+// resolves the path of the main API file.
+func (s *Service) load(searchDirs []string, mainAPIFile string, parseDepth int) (*loader.LoadResult, string, error) {
 	// Step 1: Load packages and files
 	if s.config.Debug != nil {
 		s.config.Debug.Printf("Orchestrator: Step 1 - Loading packages")
@@ -191,20 +288,20 @@ func (s *Service) Parse(searchDirs []string, mainAPIFile string, parseDepth int)
 		// Use go/packages API (most robust)
 		loadResult, err = s.loader.LoadWithGoPackages(searchDirs, mainAPIFile)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load packages with go/packages: %w", err)
+			return nil, "", fmt.Errorf("failed to load packages with go/packages: %w", err)
 		}
 	} else {
 		// Use directory walking
 		loadResult, err = s.loader.LoadSearchDirs(searchDirs)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load search directories: %w", err)
+			return nil, "", fmt.Errorf("failed to load search directories: %w", err)
 		}
 
 		// Load dependencies if needed
 		if parseDepth > 0 && s.config.ParseDependency != loader.ParseNone {
 			depResult, err := s.loader.LoadDependencies(searchDirs, parseDepth)
 			if err != nil {
-				return nil, fmt.Errorf("failed to load dependencies: %w", err)
+				return nil, "", fmt.Errorf("failed to load dependencies: %w", err)
 			}
 			// Merge results
 			for astFile, info := range depResult.Files {
@@ -213,57 +310,74 @@ func (s *Service) Parse(searchDirs []string, mainAPIFile string, parseDepth int)
 		}
 	}
 
-	if s.config.Debug != nil {
-		s.config.Debug.Printf("Orchestrator: Loaded %d files", len(loadResult.Files))
-	}
-
-	// Step 1b: Seed downstream caches from loaded packages to eliminate redundant packages.Load() calls
-	if loadResult.Packages != nil {
-		if s.config.Debug != nil {
-			s.config.Debug.Printf("Orchestrator: Step 1b - Seeding package caches from %d top-level packages", len(loadResult.Packages))
+	// The mainAPIFile parameter can be:
+	// 1. Relative to searchDir (e.g., "main.go" or "./main.go")
+	// 2. Absolute path
+	// 3. Relative to CWD
+	// We need to find it relative to searchDir if it's just a filename
+	mainFilePath := mainAPIFile
+	if !filepath.IsAbs(mainAPIFile) {
+		// Check if it's just a filename (no directory component)
+		if filepath.Base(mainAPIFile) == mainAPIFile || filepath.Dir(mainAPIFile) == "." {
+			// It's relative to searchDir - join with searchDir
+			if len(searchDirs) > 0 {
+				mainFilePath = filepath.Join(searchDirs[0], mainAPIFile)
+			}
 		}
-		model.SeedGlobalPackageCache(loadResult.Packages)
-		model.SeedEnumPackageCache(loadResult.Packages)
+		// Otherwise, it's already a path relative to CWD, use as-is
 	}
 
-	// Step 2: Register types with registry
-	if s.config.Debug != nil {
-		s.config.Debug.Printf("Orchestrator: Step 2 - Registering types")
-	}
+	return loadResult, mainFilePath, nil
+}
 
-	// Collect files into registry
-	for astFile, fileInfo := range loadResult.Files {
-		err = s.registry.CollectAstFile(
-			fileInfo.FileSet,
-			fileInfo.PackagePath,
-			fileInfo.Path,
-			astFile,
-			fileInfo.ParseFlag,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to collect AST file %s: %w", fileInfo.Path, err)
-		}
+// ParseFromPackages generates OpenAPI documentation from packages the caller already
+// loaded with golang.org/x/tools/go/packages, skipping the loader. mainAPIFile is an
+// absolute path or relative to the working directory. See loader.Service.LoadFromPackages
+// for the load modes the packages need.
+func (s *Service) ParseFromPackages(pkgs []*packages.Package, mainAPIFile string) (*spec.Swagger, error) {
+	return s.ParseFromPackagesContext(context.Background(), pkgs, mainAPIFile)
+}
+
+// ParseFromPackagesContext is ParseFromPackages with cancellation, see ParseContext.
+func (s *Service) ParseFromPackagesContext(ctx context.Context, pkgs []*packages.Package, mainAPIFile string) (*spec.Swagger, error) {
+	namingStrategy, err := s.startParse()
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse types in registry
 	if s.config.Debug != nil {
-		s.config.Debug.Printf("Orchestrator: Parsing types in registry")
+		s.config.Debug.Printf("Orchestrator: Starting parse with %d pre-loaded packages", len(pkgs))
 	}
-	schemas, err := s.registry.ParseTypes()
+
+	loadResult, err := s.loader.LoadFromPackages(pkgs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse types: %w", err)
-	}
-	if s.config.Debug != nil {
-		s.config.Debug.Printf("Orchestrator: Parsed %d schemas from registry", len(schemas))
+		return nil, fmt.Errorf("failed to load packages: %w", err)
 	}
 
-	// Set global name resolver so CoreStructParser produces short $ref names for
-	// unique types and full-path names for NotUnique types. Must happen after
-	// ParseTypes() which sets the NotUnique flags.
-	model.SetGlobalNameResolver(newRegistryNameResolver(s.registry))
+	return s.parseLoaded(ctx, loadResult, mainAPIFile, namingStrategy)
+}
+
+// startParse resets the results of a previous Parse call and validates the naming
+// strategy before anything is loaded.
+func (s *Service) startParse() (NamingStrategy, error) {
+	s.warnings.Reset()
+	s.state = nil
+
+	return s.namingStrategy()
+}
+
+// parseLoaded generates the documentation from the loaded files.
+func (s *Service) parseLoaded(ctx context.Context, loadResult *loader.LoadResult, mainFilePath string, namingStrategy NamingStrategy) (*spec.Swagger, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	if s.config.Debug != nil {
-		s.config.Debug.Printf("Orchestrator: Registry has %d unique definitions", len(s.registry.UniqueDefinitions()))
+		s.config.Debug.Printf("Orchestrator: Loaded %d files", len(loadResult.Files))
+	}
+
+	if err := s.registerTypes(ctx, loadResult); err != nil {
+		return nil, err
 	}
 
 	// Step 3: Parse general API info from main file
@@ -271,34 +385,22 @@ func (s *Service) Parse(searchDirs []string, mainAPIFile string, parseDepth int)
 		s.config.Debug.Printf("Orchestrator: Step 3 - Parsing general API info")
 	}
 
-	// The mainAPIFile parameter can be:
-	// 1. Relative to searchDir (e.g., "main.go" or "./main.go")
-	// 2. Absolute path
-	// 3. Relative to CWD
-	// We need to find it relative to searchDir if it's just a filename
-	mainFilePath := mainAPIFile
-	if !filepath.IsAbs(mainAPIFile) {
-		// Check if it's just a filename (no directory component)
-		if filepath.Base(mainAPIFile) == mainAPIFile || filepath.Dir(mainAPIFile) == "." {
-			// It's relative to searchDir - join with searchDir
-			if len(searchDirs) > 0 {
-				mainFilePath = filepath.Join(searchDirs[0], mainAPIFile)
-			}
-		}
-		// Otherwise, it's already a path relative to CWD, use as-is
-	}
-
-	err = s.baseParser.ParseGeneralAPIInfo(mainFilePath)
+	err := s.baseParser.ParseGeneralAPIInfo(mainFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse general API info: %w", err)
 	}
+	s.addInfoWarnings(mainFilePath)
+	if err := s.checkRequiredInfo(mainFilePath); err != nil {
+		return nil, err
+	}
+	s.routeParser.SetDefaultMimeTypes(s.swagger.Consumes, s.swagger.Produces)
 
 	// Step 4: Parse routes from all files (parallel)
 	if s.config.Debug != nil {
 		s.config.Debug.Printf("Orchestrator: Step 4 - Parsing routes (parallel, limit=%d)", runtime.NumCPU())
 	}
 
-	allRoutes, routeCount, err := s.parseRoutesParallel(loadResult.Files)
+	allRoutes, routeCount, err := s.parseRoutesParallel(ctx, loadResult.Files)
 	if err != nil {
 		return nil, err
 	}
@@ -311,13 +413,24 @@ func (s *Service) Parse(searchDirs []string, mainAPIFile string, parseDepth int)
 	// Only build schemas for types referenced by routes, not all 60K+ registry types.
 	// BuildAllSchemas handles Public variants and transitive nested dependencies.
 	referencedTypes := CollectReferencedTypes(allRoutes)
+	sharedResponses, err := s.parseSharedResponses(mainFilePath, referencedTypes)
+	if err != nil {
+		return nil, err
+	}
+	sharedParameters, err := s.parseSharedParameters(mainFilePath, referencedTypes)
+	if err != nil {
+		return nil, err
+	}
 	if s.config.Debug != nil {
 		s.config.Debug.Printf("Orchestrator: Step 5 - Building schemas (demand-driven, %d route-referenced types)",
 			len(referencedTypes))
 	}
 
-	err = s.buildDemandDrivenSchemas(referencedTypes)
+	err = s.buildDefinitions(ctx, referencedTypes)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, fmt.Errorf("failed to build demand-driven schemas: %w", err)
 	}
 
@@ -325,6 +438,47 @@ func (s *Service) Parse(searchDirs []string, mainAPIFile string, parseDepth int)
 		s.config.Debug.Printf("Orchestrator: Built %d schema definitions", len(s.swagger.Definitions))
 	}
 
+	s.addSharedResponses(sharedResponses)
+	s.addSharedParameters(sharedParameters)
+
+	s.dropIgnoredDefinitions()
+
+	if err := s.mergeExtraDefinitions(); err != nil {
+		return nil, err
+	}
+
+	s.runDefinitionHook()
+
+	if s.config.DedupeIdenticalDefinitions {
+		s.dedupeIdenticalDefinitions()
+	}
+
+	if s.config.InlineSingleUseDefinitions {
+		s.inlineSingleUseDefinitions()
+	}
+
+	s.applyNamingStrategy(namingStrategy)
+
+	if s.config.NamespaceByTag {
+		s.namespaceDefinitionsByTag()
+	}
+
+	if err := s.checkDanglingRefs(allRoutes); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkResponseRefs(allRoutes); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkParameterRefs(allRoutes); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkOrphanDefinitions(); err != nil {
+		return nil, err
+	}
+
 	if s.config.Debug != nil {
 		hits, misses := model.GlobalCacheStats()
 		s.config.Debug.Printf("Orchestrator: Package cache hits=%d misses=%d", hits, misses)
@@ -341,6 +495,64 @@ func (s *Service) Parse(searchDirs []string, mainAPIFile string, parseDepth int)
 	return s.swagger, nil
 }
 
+// registerTypes collects the types of the loaded files into the registry and
+// configures the model package for them.
+func (s *Service) registerTypes(ctx context.Context, loadResult *loader.LoadResult) error {
+	// Step 1b: Seed downstream caches from loaded packages to eliminate redundant packages.Load() calls
+	if loadResult.Packages != nil {
+		if s.config.Debug != nil {
+			s.config.Debug.Printf("Orchestrator: Step 1b - Seeding package caches from %d top-level packages", len(loadResult.Packages))
+		}
+		model.SeedGlobalPackageCache(loadResult.Packages)
+		model.SeedEnumPackageCache(loadResult.Packages)
+	}
+
+	// Step 2: Register types with registry
+	if s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Step 2 - Registering types")
+	}
+
+	// Collect files into registry
+	for astFile, fileInfo := range loadResult.Files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := s.registry.CollectAstFile(
+			fileInfo.FileSet,
+			fileInfo.PackagePath,
+			fileInfo.Path,
+			astFile,
+			fileInfo.ParseFlag,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to collect AST file %s: %w", fileInfo.Path, err)
+		}
+	}
+
+	// Parse types in registry
+	if s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Parsing types in registry")
+	}
+	schemas, err := s.registry.ParseTypes()
+	if err != nil {
+		return fmt.Errorf("failed to parse types: %w", err)
+	}
+	if s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Parsed %d schemas from registry", len(schemas))
+	}
+
+	// Set global name resolver so CoreStructParser produces short $ref names for
+	// unique types and full-path names for NotUnique types. Must happen after
+	// ParseTypes() which sets the NotUnique flags.
+	model.SetGlobalNameResolver(newRegistryNameResolver(s.registry))
+
+	if s.config.Debug != nil {
+		s.config.Debug.Printf("Orchestrator: Registry has %d unique definitions", len(s.registry.UniqueDefinitions()))
+	}
+
+	return nil
+}
+
 // GetSwagger returns the swagger specification.
 // This provides access to the swagger spec for backward compatibility.
 func (s *Service) GetSwagger() *spec.Swagger {
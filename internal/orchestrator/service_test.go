@@ -1,11 +1,16 @@
 package orchestrator
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/griffnb/core-swag/internal/loader"
+	"golang.org/x/tools/go/packages"
 )
 
 func TestNew(t *testing.T) {
@@ -155,6 +160,114 @@ func main() {}
 	})
 }
 
+func TestService_ParseFromPackages(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/preloaded\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Preloaded API
+// @version 1.0
+func main() {}
+`,
+		"api/api.go": `package api
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// @Success 200 {object} User
+// @Router /users [get]
+func GetUser() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	mode := packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports |
+		packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo
+
+	t.Run("parses pre-loaded packages like Parse", func(t *testing.T) {
+		pkgs, err := packages.Load(&packages.Config{Mode: mode, Dir: dir}, "./...")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		swagger, err := New(&Config{}).ParseFromPackages(pkgs, filepath.Join(dir, "main.go"))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if swagger.Info == nil || swagger.Info.Title != "Preloaded API" {
+			t.Errorf("expected title 'Preloaded API', got: %+v", swagger.Info)
+		}
+		if _, ok := swagger.Paths.Paths["/users"]; !ok {
+			t.Errorf("expected /users path, got: %v", swagger.Paths.Paths)
+		}
+
+		expected, err := New(&Config{ParseGoPackages: true}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := json.Marshal(swagger)
+		want, _ := json.Marshal(expected)
+		if string(got) != string(want) {
+			t.Errorf("expected the same spec as Parse\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("rejects packages loaded without syntax", func(t *testing.T) {
+		pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles, Dir: dir}, "./...")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := New(&Config{}).ParseFromPackages(pkgs, filepath.Join(dir, "main.go")); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestService_ParseContext(t *testing.T) {
+	testDir := t.TempDir()
+	mainFile := filepath.Join(testDir, "main.go")
+	mainContent := `package main
+
+// @title Test API
+// @version 1.0
+
+// @router /health [get]
+func main() {}
+`
+	if err := os.WriteFile(mainFile, []byte(mainContent), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("returns the context error once canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		swagger, err := New(&Config{}).ParseContext(ctx, []string{testDir}, mainFile, 0)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got: %v", err)
+		}
+		if swagger != nil {
+			t.Error("expected no swagger")
+		}
+	})
+
+	t.Run("parses with a live context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		swagger, err := New(&Config{}).ParseContext(ctx, []string{testDir}, mainFile, 0)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if _, ok := swagger.Paths.Paths["/health"]; !ok {
+			t.Errorf("expected /health path, got: %v", swagger.Paths.Paths)
+		}
+	})
+}
+
 func TestService_GetSwagger(t *testing.T) {
 	t.Run("returns swagger spec", func(t *testing.T) {
 		// Arrange
@@ -0,0 +1,76 @@
+package orchestrator
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/griffnb/core-swag/internal/model"
+)
+
+func TestParse_StrictEnums(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/enumapi\n\ngo 1.24\n",
+		"main.go": `package main
+
+// @title Enum API
+// @version 1.0
+func main() {}
+`,
+		"lib/model/fields/fields.go": `package fields
+
+type IntConstantField[T any] struct{ value T }
+`,
+		"constants/constants.go": `package constants
+
+type Role int
+
+const (
+	RoleAdmin Role = 1
+	RoleUser  Role = 2
+)
+
+// Status has no constants in the parsed packages
+type Status int
+`,
+		"api/api.go": `package api
+
+import (
+	"example.com/enumapi/constants"
+	"example.com/enumapi/lib/model/fields"
+)
+
+type Account struct {
+	Role   *fields.IntConstantField[constants.Role]   ` + "`json:\"role\"`" + `
+	Status *fields.IntConstantField[constants.Status] ` + "`json:\"status\"`" + `
+}
+
+// @Success 200 {object} Account
+// @Router /accounts [get]
+func GetAccount() {}
+`,
+	}
+	dir := writeModule(t, files)
+	t.Chdir(dir)
+
+	t.Run("falls back silently by default", func(t *testing.T) {
+		swagger, err := New(&Config{}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(swagger.Definitions["constants.Role"].Enum) != 2 {
+			t.Errorf("expected constants.Role enum values, got %+v", swagger.Definitions["constants.Role"])
+		}
+	})
+
+	t.Run("fails in strict enum mode", func(t *testing.T) {
+		_, err := New(&Config{StrictEnums: true}).Parse([]string{dir}, filepath.Join(dir, "main.go"), 0)
+		if !errors.Is(err, model.ErrEmptyEnum) {
+			t.Fatalf("expected ErrEmptyEnum, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "field Status") || !strings.Contains(err.Error(), "example.com/enumapi/constants.Status") {
+			t.Errorf("expected the field and type in the error, got %v", err)
+		}
+	})
+}
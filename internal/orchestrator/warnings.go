@@ -0,0 +1,50 @@
+package orchestrator
+
+import (
+	"github.com/griffnb/core-swag/internal/console"
+	"github.com/griffnb/core-swag/internal/parser/route"
+)
+
+// Warning categories reported by the orchestrator.
+const (
+	WarningDuplicateOperationID = "duplicate-operation-id"
+	WarningUnknownSecurityScope = route.WarningUnknownSecurityScope
+	WarningDuplicateRoute       = route.WarningDuplicateRoute
+	WarningDefinitionConflict   = "definition-conflict"
+	WarningDanglingRef          = "dangling-ref"
+	WarningOrphanDefinition     = "orphan-definition"
+	WarningInvalidInfo          = "invalid-info"
+	WarningMissingInfo          = "missing-info"
+
+	// Reported by Lint only
+	WarningInvalidRouter      = "invalid-router"
+	WarningUnknownType        = "unknown-type"
+	WarningUnknownPathParam   = "unknown-path-param"
+	WarningUndeclaredSecurity = "undeclared-security"
+)
+
+// Warning is a non-fatal problem detected while generating the spec.
+// File and Line point at the source location that caused it, when known.
+// Orchestrator, route parsing and schema building all record theirs in one
+// collector.
+type Warning = console.Diagnostic
+
+// Warnings returns all warnings collected during the last Parse call, including
+// those of route parsing and schema building, in the order they were found.
+func (s *Service) Warnings() []Warning {
+	return s.warnings.List()
+}
+
+// addWarning records a warning and logs it through the debugger when one is configured.
+func (s *Service) addWarning(warning Warning) {
+	s.warnings.Add(warning)
+
+	if s.config.Debug == nil {
+		return
+	}
+	if warning.File != "" {
+		s.config.Debug.Printf("Warning: %s:%d: %s", warning.File, warning.Line, warning.Message)
+		return
+	}
+	s.config.Debug.Printf("Warning: %s", warning.Message)
+}
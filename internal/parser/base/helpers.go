@@ -2,6 +2,8 @@ package base
 
 import (
 	"fmt"
+	"go/ast"
+	"go/token"
 	"strings"
 )
 
@@ -21,6 +23,17 @@ func isGeneralAPIComment(comments []string) bool {
 	return true
 }
 
+// attributeLine returns the file line of the first comment in group that starts with attribute, or 0
+func attributeLine(fileSet *token.FileSet, group *ast.CommentGroup, attribute string) int {
+	for _, comment := range group.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if text != "" && strings.EqualFold(FieldsByAnySpace(text, 2)[0], attribute) {
+			return fileSet.Position(comment.Pos()).Line
+		}
+	}
+	return 0
+}
+
 // parseMimeTypeList parses comma-separated MIME types and their aliases
 func parseMimeTypeList(commentLine string, mimeTypes *[]string) error {
 	for _, typeName := range strings.Split(commentLine, ",") {
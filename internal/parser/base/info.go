@@ -2,11 +2,11 @@ package base
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"net/mail"
+	"net/url"
 
 	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/domain"
 )
 
 // setSwaggerInfo sets various swagger info fields based on the attribute
@@ -21,53 +21,65 @@ func (s *Service) setSwaggerInfo(attribute, value string) {
 	case "@description":
 		s.swagger.Info.Description = value
 	case "@contact.name":
-		s.swagger.Info.Contact.Name = value
+		s.contact().Name = value
 	case "@contact.email":
-		s.swagger.Info.Contact.Email = value
+		if err := validateEmail(value); err != nil {
+			s.addProblem(attribute, err)
+			return
+		}
+		s.contact().Email = value
 	case "@contact.url":
-		s.swagger.Info.Contact.URL = value
+		if err := validateURL(value); err != nil {
+			s.addProblem(attribute, err)
+			return
+		}
+		s.contact().URL = value
 	case "@license.name":
 		s.swagger.Info.License = initIfEmpty(s.swagger.Info.License)
 		s.swagger.Info.License.Name = value
 	case "@license.url":
+		if err := validateURL(value); err != nil {
+			s.addProblem(attribute, err)
+			return
+		}
 		s.swagger.Info.License = initIfEmpty(s.swagger.Info.License)
 		s.swagger.Info.License.URL = value
 	}
 }
 
-// getMarkdownForTag reads markdown content for a given tag name
-func (s *Service) getMarkdownForTag(tagName string) ([]byte, error) {
-	if tagName == "" {
-		return make([]byte, 0), nil
+// contact returns the swagger contact info, creating it on first use
+func (s *Service) contact() *spec.ContactInfo {
+	if s.swagger.Info.Contact == nil {
+		s.swagger.Info.Contact = new(spec.ContactInfo)
 	}
+	return s.swagger.Info.Contact
+}
 
-	dirEntries, err := os.ReadDir(s.markdownFileDir)
-	if err != nil {
-		return nil, err
+// validateEmail checks that value is a bare email address like support@example.com
+func validateEmail(value string) error {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" || address.Address != value {
+		return fmt.Errorf("%q is not a valid email address", value)
 	}
+	return nil
+}
 
-	for _, entry := range dirEntries {
-		if entry.IsDir() {
-			continue
-		}
-
-		fileName := entry.Name()
-		expectedFileName := tagName
-		if !strings.HasSuffix(tagName, ".md") {
-			expectedFileName = tagName + ".md"
-		}
+// validateURL checks that value is an absolute URL with a scheme and host
+func validateURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("%q is not a valid absolute URL", value)
+	}
+	return nil
+}
 
-		if fileName == expectedFileName {
-			fullPath := filepath.Join(s.markdownFileDir, fileName)
-			commentInfo, err := os.ReadFile(fullPath)
-			if err != nil {
-				return nil, fmt.Errorf("Failed to read markdown file %s error: %s ", fullPath, err)
-			}
-			return commentInfo, nil
-		}
+// getMarkdownForTag reads markdown content for a given tag name
+func (s *Service) getMarkdownForTag(tagName string) ([]byte, error) {
+	if tagName == "" {
+		return make([]byte, 0), nil
 	}
 
-	return nil, fmt.Errorf("Unable to find markdown file for tag %s in the given directory", tagName)
+	return domain.ReadMarkdownFile(s.markdownFileDir, tagName)
 }
 
 // initIfEmpty initializes a license if it's nil
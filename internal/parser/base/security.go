@@ -76,9 +76,11 @@ loopline:
 				description += "\n"
 			}
 			description += value
+			continue
 		}
 
-		if strings.Index(securityAttr, "@securitydefinitions.") == 0 {
+		// Any other annotation, like the next definition or @host, ends this one
+		if strings.HasPrefix(securityAttr, "@") {
 			*index--
 			break
 		}
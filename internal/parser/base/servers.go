@@ -0,0 +1,66 @@
+package base
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// serversExtension holds the server list: the @Server annotations, or else the
+// URLs of host, basePath and schemes. Swagger 2.0 describes a single host, so the
+// list is kept as an extension next to host, basePath and schemes.
+const serversExtension = "x-servers"
+
+// serverPattern matches: https://{env}.example.com/v1 "Production"
+var serverPattern = regexp.MustCompile(`^(\S+)(?:\s+"([^"]*)")?\s*$`)
+
+// parseServer appends an @Server annotation to the servers extension. URLs are
+// kept verbatim, so they may be relative or hold {variables}.
+func (s *Service) parseServer(value string) error {
+	matches := serverPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return fmt.Errorf("@server needs a url and an optional quoted description: %q", value)
+	}
+
+	server := map[string]interface{}{"url": matches[1]}
+	if matches[2] != "" {
+		server["description"] = matches[2]
+	}
+
+	var servers []interface{}
+	if s.explicitServers {
+		servers, _ = s.swagger.Extensions[serversExtension].([]interface{})
+	}
+	s.explicitServers = true
+	s.swagger.AddExtension(serversExtension, append(servers, server))
+
+	return nil
+}
+
+// setDefaultServers derives the server list from host, basePath and schemes when
+// no @Server is annotated: one URL per scheme, or a scheme-relative URL without
+// schemes, as OpenAPI 3 converters do. A basePath alone is a relative URL.
+func (s *Service) setDefaultServers() {
+	if s.explicitServers || (s.swagger.Host == "" && s.swagger.BasePath == "") {
+		return
+	}
+
+	basePath := strings.TrimSuffix(s.swagger.BasePath, "/")
+	if s.swagger.Host == "" {
+		s.swagger.AddExtension(serversExtension, []interface{}{
+			map[string]interface{}{"url": s.swagger.BasePath},
+		})
+		return
+	}
+
+	var servers []interface{}
+	for _, scheme := range s.swagger.Schemes {
+		if scheme = strings.TrimSpace(scheme); scheme != "" {
+			servers = append(servers, map[string]interface{}{"url": scheme + "://" + s.swagger.Host + basePath})
+		}
+	}
+	if len(servers) == 0 {
+		servers = append(servers, map[string]interface{}{"url": "//" + s.swagger.Host + basePath})
+	}
+	s.swagger.AddExtension(serversExtension, servers)
+}
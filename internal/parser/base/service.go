@@ -36,11 +36,22 @@ type Debugger interface {
 	Printf(format string, v ...interface{})
 }
 
+// Problem is a general API annotation whose value was rejected and left out of the spec.
+// Line is the line of the annotation in the main API file, set by ParseGeneralAPIInfo.
+type Problem struct {
+	Attribute string
+	Message   string
+	Line      int
+}
+
 // Service handles parsing of general API information from comments
 type Service struct {
 	swagger         *spec.Swagger
 	markdownFileDir string
 	debug           Debugger
+	problems        []Problem
+	// explicitServers is set once an @Server annotation was parsed
+	explicitServers bool
 }
 
 // NewService creates a new base parser service
@@ -60,6 +71,19 @@ func (s *Service) SetDebugger(debug Debugger) {
 	s.debug = debug
 }
 
+// Problems returns the annotations rejected since the last ParseGeneralAPIInfo call, in the order they were found.
+func (s *Service) Problems() []Problem {
+	return s.problems
+}
+
+// addProblem records a rejected annotation value
+func (s *Service) addProblem(attribute string, err error) {
+	s.problems = append(s.problems, Problem{
+		Attribute: attribute,
+		Message:   fmt.Sprintf("%s: %v", attribute, err),
+	})
+}
+
 // ParseGeneralInfo parses general API info from comment lines
 func (s *Service) ParseGeneralInfo(comments []string) error {
 	previousAttribute := ""
@@ -113,6 +137,11 @@ func (s *Service) ParseGeneralInfo(comments []string) error {
 		case "@schemes":
 			s.swagger.Schemes = strings.Split(value, " ")
 
+		case "@server":
+			if err := s.parseServer(value); err != nil {
+				return err
+			}
+
 		case "@tag.name":
 			s.swagger.Tags = append(s.swagger.Tags, spec.Tag{
 				TagProps: spec.TagProps{
@@ -188,17 +217,21 @@ func (s *Service) ParseGeneralInfo(comments []string) error {
 		previousAttribute = attribute
 	}
 
+	s.setDefaultServers()
+
 	return nil
 }
 
 // ParseGeneralAPIInfo parses general api info for given mainAPIFile path
 func (s *Service) ParseGeneralAPIInfo(mainAPIFile string) error {
-	fileTree, err := parser.ParseFile(token.NewFileSet(), mainAPIFile, nil, parser.ParseComments)
+	fileSet := token.NewFileSet()
+	fileTree, err := parser.ParseFile(fileSet, mainAPIFile, nil, parser.ParseComments)
 	if err != nil {
 		return fmt.Errorf("cannot parse source files %s: %s", mainAPIFile, err)
 	}
 
 	s.swagger.Swagger = "2.0"
+	s.problems = nil
 
 	for _, comment := range fileTree.Comments {
 		comments := strings.Split(comment.Text(), "\n")
@@ -206,10 +239,15 @@ func (s *Service) ParseGeneralAPIInfo(mainAPIFile string) error {
 			continue
 		}
 
+		found := len(s.problems)
 		err = s.ParseGeneralInfo(comments)
 		if err != nil {
 			return err
 		}
+
+		for i := found; i < len(s.problems); i++ {
+			s.problems[i].Line = attributeLine(fileSet, comment, s.problems[i].Attribute)
+		}
 	}
 
 	return nil
@@ -1,6 +1,8 @@
 package base
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-openapi/spec"
@@ -94,6 +96,46 @@ func TestParseContactInfo(t *testing.T) {
 		assert.Equal(t, "API Support", swagger.Info.Contact.Name)
 		assert.Equal(t, "support@example.com", swagger.Info.Contact.Email)
 		assert.Equal(t, "http://www.example.com/support", swagger.Info.Contact.URL)
+		assert.Empty(t, service.Problems())
+	})
+
+	t.Run("reject malformed email and url", func(t *testing.T) {
+		swagger := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Info: &spec.Info{},
+			},
+		}
+		service := NewService(swagger)
+
+		comments := []string{
+			"@contact.name API Support",
+			"@contact.email support(at)example.com",
+			"@contact.url www.example.com/support",
+		}
+
+		err := service.ParseGeneralInfo(comments)
+		assert.NoError(t, err)
+		assert.Equal(t, "API Support", swagger.Info.Contact.Name)
+		assert.Empty(t, swagger.Info.Contact.Email)
+		assert.Empty(t, swagger.Info.Contact.URL)
+		assert.Equal(t, []Problem{
+			{Attribute: "@contact.email", Message: `@contact.email: "support(at)example.com" is not a valid email address`},
+			{Attribute: "@contact.url", Message: `@contact.url: "www.example.com/support" is not a valid absolute URL`},
+		}, service.Problems())
+	})
+
+	t.Run("reject email with display name", func(t *testing.T) {
+		swagger := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Info: &spec.Info{},
+			},
+		}
+		service := NewService(swagger)
+
+		err := service.ParseGeneralInfo([]string{"@contact.email API Support <support@example.com>"})
+		assert.NoError(t, err)
+		assert.Nil(t, swagger.Info.Contact)
+		assert.Len(t, service.Problems(), 1)
 	})
 }
 
@@ -118,9 +160,62 @@ func TestParseLicenseInfo(t *testing.T) {
 		assert.NotNil(t, swagger.Info.License)
 		assert.Equal(t, "Apache 2.0", swagger.Info.License.Name)
 		assert.Equal(t, "http://www.apache.org/licenses/LICENSE-2.0.html", swagger.Info.License.URL)
+		assert.Empty(t, service.Problems())
+	})
+
+	t.Run("reject malformed license url", func(t *testing.T) {
+		swagger := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Info: &spec.Info{},
+			},
+		}
+		service := NewService(swagger)
+
+		comments := []string{
+			"@license.name Apache 2.0",
+			"@license.url apache.org/licenses",
+		}
+
+		err := service.ParseGeneralInfo(comments)
+		assert.NoError(t, err)
+		assert.Equal(t, "Apache 2.0", swagger.Info.License.Name)
+		assert.Empty(t, swagger.Info.License.URL)
+		assert.Equal(t, []Problem{
+			{Attribute: "@license.url", Message: `@license.url: "apache.org/licenses" is not a valid absolute URL`},
+		}, service.Problems())
 	})
 }
 
+func TestParseGeneralAPIInfoProblems(t *testing.T) {
+	t.Parallel()
+
+	mainFile := filepath.Join(t.TempDir(), "main.go")
+	src := `package main
+
+// @title Contact API
+// @version 1.0
+// @contact.name API Support
+// @contact.email not-an-email
+// @license.url https://www.apache.org/licenses/LICENSE-2.0.html
+func main() {}
+`
+	assert.NoError(t, os.WriteFile(mainFile, []byte(src), 0o644))
+
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Info: &spec.Info{},
+		},
+	}
+	service := NewService(swagger)
+
+	err := service.ParseGeneralAPIInfo(mainFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://www.apache.org/licenses/LICENSE-2.0.html", swagger.Info.License.URL)
+	assert.Equal(t, []Problem{
+		{Attribute: "@contact.email", Message: `@contact.email: "not-an-email" is not a valid email address`, Line: 6},
+	}, service.Problems())
+}
+
 func TestParseTagInfo(t *testing.T) {
 	t.Parallel()
 
@@ -393,4 +488,99 @@ func TestParseSecurityDefinitions(t *testing.T) {
 		assert.Equal(t, "https://example.com/oauth/token", swagger.SecurityDefinitions["OAuth2AccessCode"].TokenURL)
 		assert.Equal(t, "https://example.com/oauth/authorize", swagger.SecurityDefinitions["OAuth2AccessCode"].AuthorizationURL)
 	})
+
+	t.Run("stops at the next general annotation", func(t *testing.T) {
+		swagger := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Info:                &spec.Info{},
+				SecurityDefinitions: make(map[string]*spec.SecurityScheme),
+			},
+		}
+		service := NewService(swagger)
+
+		comments := []string{
+			"@securitydefinitions.apikey ApiKeyAuth",
+			"@in header",
+			"@name X-API-Key",
+			"@host api.example.com",
+			"@securitydefinitions.basic BasicAuth",
+		}
+
+		err := service.ParseGeneralInfo(comments)
+		assert.NoError(t, err)
+		assert.Equal(t, "api.example.com", swagger.Host)
+		assert.Equal(t, "X-API-Key", swagger.SecurityDefinitions["ApiKeyAuth"].Name)
+		assert.NotNil(t, swagger.SecurityDefinitions["BasicAuth"])
+	})
+}
+
+func TestParseGeneralInfo_Servers(t *testing.T) {
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Info: &spec.Info{},
+		},
+	}
+	service := NewService(swagger)
+
+	comments := []string{
+		`@Server https://api.example.com/v1 "Production"`,
+		"@Server https://{env}.example.com/v1",
+		`@Server /v1 "Same origin"`,
+	}
+
+	err := service.ParseGeneralInfo(comments)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"url": "https://api.example.com/v1", "description": "Production"},
+		map[string]interface{}{"url": "https://{env}.example.com/v1"},
+		map[string]interface{}{"url": "/v1", "description": "Same origin"},
+	}, swagger.Extensions[serversExtension])
+
+	err = service.ParseGeneralInfo([]string{`@Server https://api.example.com "unterminated`})
+	assert.Error(t, err)
+}
+
+func TestParseGeneralInfo_DefaultServers(t *testing.T) {
+	t.Run("should map each scheme with host and basePath to a server", func(t *testing.T) {
+		swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Info: &spec.Info{}}}
+		err := NewService(swagger).ParseGeneralInfo([]string{
+			"@host api.example.com",
+			"@BasePath /v1/",
+			"@schemes https http",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"url": "https://api.example.com/v1"},
+			map[string]interface{}{"url": "http://api.example.com/v1"},
+		}, swagger.Extensions[serversExtension])
+	})
+
+	t.Run("should use a scheme-relative url without schemes", func(t *testing.T) {
+		swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Info: &spec.Info{}}}
+		err := NewService(swagger).ParseGeneralInfo([]string{"@host api.example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"url": "//api.example.com"},
+		}, swagger.Extensions[serversExtension])
+	})
+
+	t.Run("should prefer @Server annotations", func(t *testing.T) {
+		swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Info: &spec.Info{}}}
+		err := NewService(swagger).ParseGeneralInfo([]string{
+			"@host api.example.com",
+			"@schemes https",
+			"@Server https://staging.example.com",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"url": "https://staging.example.com"},
+		}, swagger.Extensions[serversExtension])
+	})
+
+	t.Run("should leave the servers out without host and basePath", func(t *testing.T) {
+		swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Info: &spec.Info{}}}
+		err := NewService(swagger).ParseGeneralInfo([]string{"@schemes https"})
+		assert.NoError(t, err)
+		assert.NotContains(t, swagger.Extensions, serversExtension)
+	})
 }
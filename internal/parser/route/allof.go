@@ -10,6 +10,7 @@ import (
 )
 
 // buildAllOfResponseSchema handles combined type syntax like Response{data=Account}
+// for responses and body parameters.
 // It uses Phase 1.3 AllOf composition functions.
 // file is used for import resolution to produce fully qualified TypePath values.
 func (s *Service) buildAllOfResponseSchema(dataType, packageName string, isPublic bool, file *ast.File) *domain.Schema {
@@ -157,7 +158,7 @@ func (s *Service) resolveOverrideTypeSchema(fieldType, packageName string, isPub
 	if isPrimitiveType(fieldType) {
 		return spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{convertTypeToSchemaType(fieldType)},
+				Type: []string{convertTypeToSchemaType(s.types, fieldType)},
 			},
 		}
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-openapi/spec"
 	"github.com/griffnb/core-swag/internal/parser/route/domain"
+	"github.com/griffnb/core-swag/internal/schema"
 )
 
 // RouteToSpecOperation converts a domain.Route to a spec.Operation
@@ -56,7 +57,12 @@ func RouteToSpecOperation(route *domain.Route) *spec.Operation {
 	}
 
 	for code, resp := range route.Responses {
-		responses.StatusCodeResponses[code] = ResponseToSpec(resp)
+		specResp := ResponseToSpec(resp)
+		if code == domain.DefaultResponseCode {
+			responses.Default = &specResp
+			continue
+		}
+		responses.StatusCodeResponses[code] = specResp
 	}
 
 	operation.Responses = responses
@@ -71,6 +77,10 @@ func RouteToSpecOperation(route *domain.Route) *spec.Operation {
 
 // ParameterToSpec converts a domain.Parameter to spec.Parameter
 func ParameterToSpec(param domain.Parameter) spec.Parameter {
+	if param.Ref != "" {
+		return *spec.ParamRef("#/parameters/" + param.Ref)
+	}
+
 	// Debug logging for infinity detection
 	if param.Maximum != nil && (math.IsInf(*param.Maximum, 0) || math.IsNaN(*param.Maximum)) {
 		log.Printf("INFINITY DETECTED: Parameter %s (in:%s) has invalid maximum: %v", param.Name, param.In, *param.Maximum)
@@ -88,13 +98,28 @@ func ParameterToSpec(param domain.Parameter) spec.Parameter {
 		},
 	}
 
+	// Swagger 2.0 has no serialization styles, so they are kept as extensions
+	if param.Style != "" {
+		specParam.AddExtension("x-style", param.Style)
+	}
+	if param.Explode != nil {
+		specParam.AddExtension("x-explode", *param.Explode)
+	}
+
+	// Swagger 2.0 allows a schema on body parameters only, so the schema(Type) of
+	// others is kept as an extension next to their type
+	if param.Schema != nil && param.In != "body" {
+		specParam.AddExtension(schema.ParamSchemaExtension, SchemaToSpec(param.Schema))
+	}
+
 	// Handle schema for body parameters
-	if param.Schema != nil {
+	if param.Schema != nil && param.In == "body" {
 		specParam.Schema = SchemaToSpec(param.Schema)
 	} else {
 		// For non-body parameters, set type directly
 		specParam.Type = param.Type
 		specParam.Format = param.Format
+		specParam.CollectionFormat = param.CollectionFormat
 
 		if param.Items != nil {
 			specParam.Items = &spec.Items{
@@ -116,6 +141,10 @@ func ParameterToSpec(param domain.Parameter) spec.Parameter {
 			}
 		}
 
+		if param.Example != nil {
+			specParam.Example = param.Example
+		}
+
 		if len(param.Enum) > 0 {
 			specParam.Enum = sanitizeEnumValues(param.Name, param.Enum)
 		}
@@ -136,6 +165,14 @@ func ParameterToSpec(param domain.Parameter) spec.Parameter {
 			}
 		}
 
+		if param.MultipleOf != nil {
+			if math.IsInf(*param.MultipleOf, 0) || math.IsNaN(*param.MultipleOf) {
+				log.Printf("WARNING: Parameter %s has infinite/NaN multipleOf value: %v", param.Name, *param.MultipleOf)
+			} else {
+				specParam.MultipleOf = param.MultipleOf
+			}
+		}
+
 		if param.MinLength != nil {
 			val := int64(*param.MinLength)
 			specParam.MinLength = &val
@@ -152,6 +189,10 @@ func ParameterToSpec(param domain.Parameter) spec.Parameter {
 
 // ResponseToSpec converts a domain.Response to spec.Response
 func ResponseToSpec(resp domain.Response) spec.Response {
+	if resp.Ref != "" {
+		return *spec.ResponseRef("#/responses/" + resp.Ref)
+	}
+
 	specResp := spec.Response{
 		ResponseProps: spec.ResponseProps{
 			Description: resp.Description,
@@ -174,12 +215,17 @@ func ResponseToSpec(resp domain.Response) spec.Response {
 		}
 	}
 
+	// Swagger 2.0 has no per-response content types, so the override is an extension
+	if len(resp.Produces) > 0 {
+		specResp.Extensions["x-produces"] = resp.Produces
+	}
+
 	return specResp
 }
 
 // HeaderToSpec converts a domain.Header to spec.Header
 func HeaderToSpec(header domain.Header) spec.Header {
-	return spec.Header{
+	specHeader := spec.Header{
 		SimpleSchema: spec.SimpleSchema{
 			Type:   header.Type,
 			Format: header.Format,
@@ -188,6 +234,17 @@ func HeaderToSpec(header domain.Header) spec.Header {
 			Description: header.Description,
 		},
 	}
+
+	if header.Items != nil {
+		specHeader.Items = &spec.Items{
+			SimpleSchema: spec.SimpleSchema{
+				Type:   header.Items.Type,
+				Format: header.Items.Format,
+			},
+		}
+	}
+
+	return specHeader
 }
 
 // SchemaToSpec converts a domain.Schema to spec.Schema
@@ -201,6 +258,9 @@ func SchemaToSpec(schema *domain.Schema) *spec.Schema {
 			Type:        []string{},
 			Description: schema.Description,
 		},
+		SwaggerSchemaProps: spec.SwaggerSchemaProps{
+			Example: schema.Example,
+		},
 	}
 
 	// Set type if present
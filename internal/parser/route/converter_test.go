@@ -365,6 +365,30 @@ func TestResponseToSpec(t *testing.T) {
 			t.Errorf("Expected X-Request-Id type 'string', got %s", header.Type)
 		}
 	})
+
+	t.Run("converts response content type override", func(t *testing.T) {
+		resp := domain.Response{
+			Description: "Report",
+			Produces:    []string{"text/csv"},
+		}
+
+		result := ResponseToSpec(resp)
+
+		produces, ok := result.Extensions["x-produces"].([]string)
+		if !ok || len(produces) != 1 || produces[0] != "text/csv" {
+			t.Errorf("Expected x-produces [text/csv], got %v", result.Extensions["x-produces"])
+		}
+	})
+
+	t.Run("converts shared response reference", func(t *testing.T) {
+		resp := domain.Response{Ref: "DefaultError"}
+
+		result := ResponseToSpec(resp)
+
+		if got := result.Ref.String(); got != "#/responses/DefaultError" {
+			t.Errorf("Expected ref '#/responses/DefaultError', got %s", got)
+		}
+	})
 }
 
 func TestSchemaToSpec(t *testing.T) {
@@ -1,6 +1,10 @@
 // Package domain contains domain models for route parsing.
 package domain
 
+// DefaultResponseCode keys the "default" response of an operation in Route.Responses.
+// No HTTP status code is 0.
+const DefaultResponseCode = 0
+
 // Route represents a parsed HTTP route with all its metadata
 type Route struct {
 	// HTTP method (GET, POST, PUT, DELETE, etc.)
@@ -21,7 +25,7 @@ type Route struct {
 	// Parameters for the route
 	Parameters []Parameter
 
-	// Responses keyed by status code
+	// Responses keyed by status code, with the catch-all response under DefaultResponseCode
 	Responses map[int]Response
 
 	// Security requirements
@@ -54,6 +58,9 @@ type Route struct {
 
 // Parameter represents a route parameter
 type Parameter struct {
+	// Ref names a shared parameter in #/parameters ({ref} params); other fields are ignored when set
+	Ref string
+
 	// Name of the parameter
 	Name string
 
@@ -78,9 +85,18 @@ type Parameter struct {
 	// Default value
 	Default interface{}
 
+	// Example value, converted to the parameter type
+	Example interface{}
+
+	// SchemaType is the model named by schema(Type) for a complex non-body parameter
+	SchemaType string
+
 	// Format (e.g., "int32", "date-time")
 	Format string
 
+	// CollectionFormat of array values (csv, ssv, tsv, pipes or multi)
+	CollectionFormat string
+
 	// Enum values
 	Enum []interface{}
 
@@ -90,11 +106,20 @@ type Parameter struct {
 	// Maximum value (for numbers)
 	Maximum *float64
 
+	// MultipleOf value (for numbers)
+	MultipleOf *float64
+
 	// MinLength (for strings)
 	MinLength *float64
 
 	// MaxLength (for strings)
 	MaxLength *float64
+
+	// Style is the serialization style of the value, e.g. deepObject
+	Style string
+
+	// Explode reports whether arrays and objects are serialized as separate parameters, nil when unset
+	Explode *bool
 }
 
 // Items describes the items in an array parameter
@@ -119,6 +144,12 @@ type Response struct {
 
 	// Headers in the response
 	Headers map[string]Header
+
+	// Ref names a shared response in #/responses ({ref} responses); other fields are ignored when set
+	Ref string
+
+	// Produces overrides the operation's content types for this response (produce(...) suffix)
+	Produces []string
 }
 
 // Header represents a response header
@@ -131,6 +162,9 @@ type Header struct {
 
 	// Format of the header value
 	Format string
+
+	// Items for array headers
+	Items *Items
 }
 
 // Schema represents a data schema
@@ -162,4 +196,7 @@ type Schema struct {
 
 	// Description of the schema
 	Description string
+
+	// Example value, e.g. of a body parameter
+	Example interface{}
 }
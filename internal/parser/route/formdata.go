@@ -0,0 +1,252 @@
+package route
+
+import (
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/griffnb/core-swag/internal/parser/route/domain"
+	"github.com/griffnb/core-swag/internal/schemautil"
+)
+
+const mimeMultipartForm = "multipart/form-data"
+
+// expandMultipartBodyParams replaces struct body parameters with one formData
+// parameter per struct field when the operation accepts multipart/form-data.
+// Field names come from `form` tags (falling back to `json`, then the property
+// naming strategy), embedded structs contribute their fields, and
+// *multipart.FileHeader fields become parameters of type "file".
+// Body params whose type cannot be resolved through the registry are left untouched.
+func (s *Service) expandMultipartBodyParams(op *operation) {
+	if !consumesMultipartForm(op.consumes) {
+		return
+	}
+
+	params := make([]domain.Parameter, 0, len(op.parameters))
+	for _, param := range op.parameters {
+		if param.In != "body" || param.Schema == nil || param.Schema.Ref == "" {
+			params = append(params, param)
+			continue
+		}
+
+		structType, file := s.lookupStructType(strings.TrimPrefix(param.Schema.Ref, "#/definitions/"), op.astFile)
+		if structType == nil {
+			params = append(params, param)
+			continue
+		}
+
+		params = append(params, s.formDataParamsFromStruct(structType, file, map[*ast.StructType]bool{})...)
+	}
+
+	op.parameters = params
+}
+
+// consumesMultipartForm reports whether multipart/form-data is among the accepted mime types
+func consumesMultipartForm(consumes []string) bool {
+	for _, mimeType := range consumes {
+		if mimeType == mimeMultipartForm {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupStructType resolves a qualified type name to its struct definition via the
+// registry, along with the file declaring it
+func (s *Service) lookupStructType(qualifiedType string, file *ast.File) (*ast.StructType, *ast.File) {
+	if s.registry == nil {
+		return nil, nil
+	}
+
+	typeDef := s.registry.FindTypeSpec(qualifiedType, file)
+	if typeDef == nil || typeDef.TypeSpec == nil {
+		return nil, nil
+	}
+
+	structType, ok := typeDef.TypeSpec.Type.(*ast.StructType)
+	if !ok {
+		return nil, nil
+	}
+	return structType, typeDef.File
+}
+
+// formDataParamsFromStruct builds formData parameters from the fields of a struct
+// declared in file. Untagged embedded structs are expanded in place, visited guards
+// against embedding cycles. Fields tagged "-" and untagged unexported fields are skipped.
+func (s *Service) formDataParamsFromStruct(structType *ast.StructType, file *ast.File, visited map[*ast.StructType]bool) []domain.Parameter {
+	var params []domain.Parameter
+	if structType.Fields == nil || visited[structType] {
+		return params
+	}
+	visited[structType] = true
+
+	for _, field := range structType.Fields.List {
+		tagName, required := formFieldName(field)
+		if tagName == "" && isIgnoredFormField(field) {
+			continue
+		}
+
+		names := make([]string, 0, len(field.Names))
+		if len(field.Names) == 0 {
+			typeName := embeddedTypeName(field.Type)
+			if typeName == "" {
+				continue
+			}
+			if tagName == "" {
+				if embedded, embeddedFile := s.lookupStructType(typeName, file); embedded != nil {
+					params = append(params, s.formDataParamsFromStruct(embedded, embeddedFile, visited)...)
+					continue
+				}
+			}
+			names = append(names, typeName[strings.LastIndex(typeName, ".")+1:])
+		} else {
+			for _, ident := range field.Names {
+				names = append(names, ident.Name)
+			}
+		}
+
+		for _, fieldName := range names {
+			name := tagName
+			if name == "" {
+				if !ast.IsExported(fieldName) {
+					continue
+				}
+				name = schemautil.PropertyName(fieldName, s.propNamingStrategy)
+			}
+			params = append(params, formDataParam(field, name, required))
+		}
+	}
+
+	return params
+}
+
+// formDataParam builds the formData parameter for a struct field under the given name
+func formDataParam(field *ast.Field, name string, required bool) domain.Parameter {
+	param := domain.Parameter{
+		Name:     name,
+		In:       "formData",
+		Required: required,
+	}
+	if field.Doc != nil {
+		param.Description = strings.TrimSpace(field.Doc.Text())
+	} else if field.Comment != nil {
+		param.Description = strings.TrimSpace(field.Comment.Text())
+	}
+
+	fieldType := field.Type
+	if arrayType, ok := fieldType.(*ast.ArrayType); ok && arrayType.Len == nil {
+		itemType, itemFormat := formFieldType(arrayType.Elt)
+		param.Type = "array"
+		param.Items = &domain.Items{Type: itemType, Format: itemFormat}
+	} else {
+		param.Type, param.Format = formFieldType(fieldType)
+	}
+
+	// A format tag overrides the format of the type, e.g. format:"date" for a time.Time
+	if format := structTag(field).Get("format"); format != "" {
+		if param.Items != nil {
+			param.Items.Format = format
+		} else {
+			param.Format = format
+		}
+	}
+
+	return param
+}
+
+// isIgnoredFormField reports whether the tag formFieldName names a field after is "-"
+func isIgnoredFormField(field *ast.Field) bool {
+	tag := structTag(field)
+	tagName := tag.Get("form")
+	if strings.Split(tagName, ",")[0] == "" {
+		tagName = tag.Get("json")
+	}
+	return tagName == "-"
+}
+
+// embeddedTypeName returns the type name of an embedded field, e.g. "Base" or
+// "common.Base" for *common.Base, or empty for other expressions
+func embeddedTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			return pkg.Name + "." + t.Sel.Name
+		}
+	}
+	return ""
+}
+
+// formFieldName returns the form field name for a struct field and whether
+// the field is marked required via a `binding:"required"` or `validate:"required"` tag.
+func formFieldName(field *ast.Field) (string, bool) {
+	tag := structTag(field)
+
+	tagName := tag.Get("form")
+	name := strings.Split(tagName, ",")[0]
+	if name == "" {
+		tagName = tag.Get("json")
+		name = strings.Split(tagName, ",")[0]
+	}
+	// "-" ignores the field, while "-," names it "-"
+	if tagName == "-" {
+		return "", false
+	}
+
+	required := false
+	for _, key := range []string{"binding", "validate"} {
+		for _, rule := range strings.Split(tag.Get(key), ",") {
+			if strings.TrimSpace(rule) == "required" {
+				required = true
+			}
+		}
+	}
+
+	return name, required
+}
+
+// structTag returns the tag of a struct field, empty when it has none or it cannot be unquoted.
+func structTag(field *ast.Field) reflect.StructTag {
+	if field.Tag == nil {
+		return ""
+	}
+
+	tagValue, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(tagValue)
+}
+
+// formFieldType maps a struct field type expression to a formData type and format
+func formFieldType(expr ast.Expr) (string, string) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	switch t := expr.(type) {
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "multipart" && t.Sel.Name == "FileHeader" {
+			return "file", ""
+		}
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "time" && t.Sel.Name == "Time" {
+			return "string", "date-time"
+		}
+		return "string", ""
+	case *ast.Ident:
+		schemaType, format := convertType(nil, t.Name)
+		if schemaType == "object" {
+			// Named non-struct types are sent as plain strings in form data
+			return "string", ""
+		}
+		return schemaType, format
+	default:
+		return "string", ""
+	}
+}
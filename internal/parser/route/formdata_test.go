@@ -0,0 +1,256 @@
+package route
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/griffnb/core-swag/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fileTypeRegistry resolves "pkg.Type" names against the type declarations of a single file
+type fileTypeRegistry struct {
+	file *ast.File
+}
+
+func (r *fileTypeRegistry) FindTypeSpec(typeName string, _ *ast.File) *domain.TypeSpecDef {
+	name := typeName[strings.LastIndex(typeName, ".")+1:]
+	for _, decl := range r.file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if typeSpec.Name.Name == name {
+				return &domain.TypeSpecDef{File: r.file, TypeSpec: typeSpec, PkgPath: r.file.Name.Name}
+			}
+		}
+	}
+	return nil
+}
+
+func TestExpandMultipartBodyParams(t *testing.T) {
+	src := `
+package test
+
+import "mime/multipart"
+
+type UploadRequest struct {
+	// Title of the upload
+	Title       string                  ` + "`form:\"title\" binding:\"required\"`" + `
+	Count       int                     ` + "`form:\"count\"`" + `
+	Tags        []string                ` + "`form:\"tags\"`" + `
+	Avatar      *multipart.FileHeader   ` + "`form:\"avatar\" binding:\"required\"`" + `
+	Attachments []*multipart.FileHeader ` + "`form:\"attachments\"`" + `
+	Internal    string                  ` + "`form:\"-\"`" + `
+	Untagged    string
+}
+
+// @Summary Upload a file
+// @Accept mpfd
+// @Param request body UploadRequest true "Upload"
+// @Router /uploads [post]
+func Upload() {}
+`
+
+	t.Run("should expand form-tagged struct into formData params", func(t *testing.T) {
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		service.SetRegistry(&fileTypeRegistry{file: astFile})
+
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 6)
+
+		for _, param := range params {
+			assert.Equal(t, "formData", param.In)
+		}
+
+		assert.Equal(t, "title", params[0].Name)
+		assert.Equal(t, "string", params[0].Type)
+		assert.True(t, params[0].Required)
+		assert.Equal(t, "Title of the upload", params[0].Description)
+
+		assert.Equal(t, "count", params[1].Name)
+		assert.Equal(t, "integer", params[1].Type)
+		assert.False(t, params[1].Required)
+
+		assert.Equal(t, "tags", params[2].Name)
+		assert.Equal(t, "array", params[2].Type)
+		require.NotNil(t, params[2].Items)
+		assert.Equal(t, "string", params[2].Items.Type)
+
+		assert.Equal(t, "avatar", params[3].Name)
+		assert.Equal(t, "file", params[3].Type)
+		assert.True(t, params[3].Required)
+
+		assert.Equal(t, "attachments", params[4].Name)
+		assert.Equal(t, "array", params[4].Type)
+		require.NotNil(t, params[4].Items)
+		assert.Equal(t, "file", params[4].Items.Type)
+
+		assert.Equal(t, "Untagged", params[5].Name)
+		assert.Equal(t, "string", params[5].Type)
+	})
+
+	t.Run("should keep body param when not multipart", func(t *testing.T) {
+		jsonSrc := strings.Replace(src, "@Accept mpfd", "@Accept json", 1)
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", jsonSrc, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		service.SetRegistry(&fileTypeRegistry{file: astFile})
+
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Len(t, routes[0].Parameters, 1)
+		assert.Equal(t, "body", routes[0].Parameters[0].In)
+		assert.Equal(t, "#/definitions/test.UploadRequest", routes[0].Parameters[0].Schema.Ref)
+	})
+
+	t.Run("should apply format tags", func(t *testing.T) {
+		formatSrc := `
+package test
+
+import "time"
+
+type EventRequest struct {
+	Day  time.Time   ` + "`form:\"day\" format:\"date\"`" + `
+	At   time.Time   ` + "`form:\"at\"`" + `
+	Days []time.Time ` + "`form:\"days\" format:\"date\"`" + `
+}
+
+// @Accept mpfd
+// @Param request body EventRequest true "Event"
+// @Router /events [post]
+func CreateEvent() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", formatSrc, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		service.SetRegistry(&fileTypeRegistry{file: astFile})
+
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 3)
+		assert.Equal(t, "date", params[0].Format)
+		assert.Equal(t, "date-time", params[1].Format)
+		require.NotNil(t, params[2].Items)
+		assert.Equal(t, "date", params[2].Items.Format)
+	})
+}
+
+func TestExpandMultipartBodyParams_StructShapes(t *testing.T) {
+	src := `
+package test
+
+type Audit struct {
+	CreatedBy string ` + "`form:\"created_by\"`" + `
+	*Meta
+}
+
+type Meta struct {
+	Source string ` + "`form:\"source\"`" + `
+}
+
+type Label string
+
+type ProfileRequest struct {
+	Audit
+	Label
+	Skipped     Meta ` + "`form:\"-\"`" + `
+	First, Last string ` + "`form:\"name\"`" + `
+	DisplayName string
+	Street, City string
+	hidden      string
+}
+
+// @Accept mpfd
+// @Param request body ProfileRequest true "Profile"
+// @Router /profiles [post]
+func UpdateProfile() {}
+`
+
+	parse := func(t *testing.T, strategy string) []string {
+		t.Helper()
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		service.SetRegistry(&fileTypeRegistry{file: astFile})
+		service.SetPropNamingStrategy(strategy)
+
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		var names []string
+		for _, param := range routes[0].Parameters {
+			assert.Equal(t, "formData", param.In)
+			assert.Equal(t, "string", param.Type)
+			names = append(names, param.Name)
+		}
+		return names
+	}
+
+	t.Run("should expand embedded structs and name every field of a multi-name declaration", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"created_by", "source", // Audit, and Meta embedded in it
+			"Label",
+			"name", "name",
+			"DisplayName",
+			"Street", "City",
+		}, parse(t, ""))
+	})
+
+	t.Run("should name untagged fields with the property naming strategy", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"created_by", "source",
+			"label",
+			"name", "name",
+			"display_name",
+			"street", "city",
+		}, parse(t, "snakecase"))
+	})
+}
+
+func TestFormFieldName(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: `form:"title"`, want: "title"},
+		{tag: `json:"title,omitempty"`, want: "title"},
+		{tag: `form:"-"`, want: ""},
+		{tag: `json:"-"`, want: ""},
+		{tag: `form:"-,"`, want: "-"},
+		{tag: `json:"-,omitempty"`, want: "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			field := &ast.Field{Tag: &ast.BasicLit{Kind: token.STRING, Value: "`" + tt.tag + "`"}}
+			name, _ := formFieldName(field)
+			assert.Equal(t, tt.want, name)
+		})
+	}
+}
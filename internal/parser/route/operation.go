@@ -3,8 +3,7 @@ package route
 import (
 	"fmt"
 	"go/ast"
-	"os"
-	"path/filepath"
+	"net/http"
 	"regexp"
 	"strings"
 
@@ -14,6 +13,7 @@ import (
 // operation represents a parsed operation (before being split into routes)
 type operation struct {
 	functionName string
+	receiverName string // Receiver type name when the operation is a method
 	packageName  string // Package name for type resolution
 	summary      string
 	description  string
@@ -22,6 +22,7 @@ type operation struct {
 	routerPaths  []routerPath
 	parameters   []domain.Parameter
 	responses    map[int]domain.Response
+	ranged       map[int]bool // Status codes whose response was expanded from an NXX range
 	security     []map[string][]string
 	consumes     []string
 	produces     []string
@@ -29,6 +30,11 @@ type operation struct {
 	filePath     string    // Source file path for x-path extension
 	lineNumber   int       // Function line number for x-line extension
 	astFile      *ast.File // AST file for import resolution
+
+	// inDescription is set while the lines after @Description continue it,
+	// descriptionBreaks counts the blank lines seen since the last one
+	inDescription     bool
+	descriptionBreaks int
 }
 
 // routerPath represents a single @router annotation
@@ -40,6 +46,8 @@ type routerPath struct {
 
 var (
 	routerPattern = regexp.MustCompile(`^(/[\w./\-{}\(\)+:$~]*)[[:blank:]]+\[(\w+)]`)
+	// mimeTypeAliases maps the @Accept/@Produce shorthands to MIME types, matching
+	// the general info parser; anything else is used verbatim as a MIME type
 	mimeTypeAliases = map[string]string{
 		"json":                  "application/json",
 		"xml":                   "text/xml",
@@ -70,9 +78,29 @@ func (s *Service) parseComment(op *operation, comment string) error {
 	}
 
 	if len(commentLine) == 0 {
+		if op.inDescription {
+			op.descriptionBreaks++
+		}
 		return nil
 	}
 
+	// Lines after @Description up to the next annotation continue the description,
+	// keeping their line breaks and the indentation of list items
+	if op.inDescription && !strings.HasPrefix(commentLine, "@") {
+		line := commentLine
+		if strings.HasPrefix(strings.TrimSpace(comment), "//") {
+			line = descriptionLine(comment)
+		}
+		if op.description != "" {
+			op.description += strings.Repeat("\n", op.descriptionBreaks+1)
+		}
+		op.description += line
+		op.descriptionBreaks = 0
+		return nil
+	}
+	op.inDescription = false
+	op.descriptionBreaks = 0
+
 	// Split into fields
 	allFields := strings.Fields(commentLine)
 	if len(allFields) == 0 {
@@ -96,11 +124,20 @@ func (s *Service) parseComment(op *operation, comment string) error {
 		} else {
 			op.description += "\n" + lineRemainder
 		}
+		op.inDescription = true
 	case "@description.markdown":
-		// Load description from markdown file
-		content, err := s.loadMarkdownFile(lineRemainder)
+		// Load description from markdown file, named after the function when no filename is given
+		filename := lineRemainder
+		if filename == "" {
+			filename = op.functionName
+		}
+		content, err := s.loadMarkdownFile(filename)
 		if err != nil {
-			return err
+			if s.strict {
+				return fmt.Errorf("%w: %s: %v", ErrMarkdownFile, op.functionName, err)
+			}
+			s.warnOperation(op, WarningMarkdownFile, "%s", err)
+			return nil
 		}
 		op.description = string(content)
 	case "@id":
@@ -192,13 +229,15 @@ func (s *Service) parseRouter(op *operation, line string, deprecated bool) error
 	path := matches[1]
 	method := strings.ToUpper(matches[2])
 
-	// Validate HTTP method
-	validMethods := map[string]bool{
-		"GET": true, "POST": true, "PUT": true, "DELETE": true,
-		"PATCH": true, "HEAD": true, "OPTIONS": true,
-	}
-	if !validMethods[method] {
-		return fmt.Errorf("invalid HTTP method: %s", method)
+	// Methods are case-insensitive; registration maps each to its path item field,
+	// and TRACE to the x-trace extension
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete,
+		http.MethodPatch, http.MethodHead, http.MethodOptions, http.MethodTrace:
+	case http.MethodConnect:
+		return fmt.Errorf("%w: %s, Swagger 2.0 has no %s operations", ErrRouterMethod, method, method)
+	default:
+		return fmt.Errorf("%w: %s", ErrRouterMethod, method)
 	}
 
 	op.routerPaths = append(op.routerPaths, routerPath{
@@ -210,24 +249,6 @@ func (s *Service) parseRouter(op *operation, line string, deprecated bool) error
 	return nil
 }
 
-// loadMarkdownFile loads content from a markdown file
-func (s *Service) loadMarkdownFile(tag string) ([]byte, error) {
-	if s.markdownFileDir == "" {
-		return nil, fmt.Errorf("markdown file directory not set")
-	}
-
-	// The tag is the filename (e.g., "api" becomes "api.md")
-	filename := tag + ".md"
-	filepath := filepath.Join(s.markdownFileDir, filename)
-
-	content, err := os.ReadFile(filepath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read markdown file %s: %w", filepath, err)
-	}
-
-	return content, nil
-}
-
 // parseSecurity parses the @security annotation
 func (s *Service) parseSecurity(op *operation, line string) error {
 	if len(line) == 0 {
@@ -262,3 +283,11 @@ func (s *Service) parseSecurity(op *operation, line string) error {
 	op.security = append(op.security, securityMap)
 	return nil
 }
+
+// descriptionLine returns a "//" comment line without the marker and the space
+// after it, keeping any further indentation.
+func descriptionLine(comment string) string {
+	line := strings.TrimPrefix(strings.TrimSpace(comment), "//")
+	line = strings.TrimPrefix(line, " ")
+	return strings.TrimRight(line, " \t")
+}
@@ -1,30 +1,69 @@
 package route
 
 import (
+	"encoding/json"
 	"fmt"
+	"go/ast"
 	"math"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/griffnb/core-swag/internal/parser/field"
 	"github.com/griffnb/core-swag/internal/parser/route/domain"
+	"github.com/griffnb/core-swag/internal/typeregistry"
 )
 
-var paramPattern = regexp.MustCompile(`(\S+)\s+(\w+)\s+([\S.]+)\s+(\w+)\s+"([^"]+)"`)
+// paramPattern matches a @Param line up to its description. The description is
+// double-quoted and may hold backslash-escaped characters, e.g. "the \"primary\" id".
+var paramPattern = regexp.MustCompile(`(\S+)\s+(\w+)\s+([\S.]+)\s+(\w+)\s+"((?:[^"\\]|\\.)+)"`)
+
+// paramLocations maps the lowercased @Param locations to their Swagger 2.0 names.
+var paramLocations = map[string]string{
+	"path":     "path",
+	"query":    "query",
+	"header":   "header",
+	"body":     "body",
+	"formdata": "formData",
+	"form":     "formData",
+}
 
 // parseParam parses the @param annotation
 // Format: @Param name paramType dataType required "description" [Attribute(value)]...
 // Example: @Param id path int true "User ID" Format(int64) Minimum(0)
 func (s *Service) parseParam(op *operation, line string) error {
+	// {ref} points at a shared parameter declared with a general-info @Parameter
+	if fields := strings.Fields(line); len(fields) == 2 && strings.EqualFold(fields[0], "{ref}") {
+		for _, param := range op.parameters {
+			if param.Ref == fields[1] {
+				return nil
+			}
+		}
+		op.parameters = append(op.parameters, domain.Parameter{Ref: fields[1]})
+		return nil
+	}
+
 	matches := paramPattern.FindStringSubmatch(line)
 	if len(matches) != 6 {
 		return fmt.Errorf("invalid param format: %s", line)
 	}
 
 	name := matches[1]
-	paramType := matches[2] // path, query, header, body, formData
 	dataType := matches[3]
 	requiredStr := strings.ToLower(matches[4])
-	description := matches[5]
+	description := unescapeDescription(matches[5])
+
+	// path, query, header, body or formData, case-insensitive. Swagger 2.0 has
+	// no cookie parameters, so those are dropped like unknown locations.
+	paramType, ok := paramLocations[strings.ToLower(matches[2])]
+	if !ok {
+		if s.strict {
+			return fmt.Errorf("%w: %s: @Param %s: %s", ErrParamLocation, op.functionName, name, matches[2])
+		}
+		s.warnOperation(op, WarningParamLocation, "@Param %s: %s parameters are not supported by Swagger 2.0, ignoring it",
+			name, matches[2])
+		return nil
+	}
 
 	required := requiredStr == "true" || requiredStr == "required"
 
@@ -35,7 +74,12 @@ func (s *Service) parseParam(op *operation, line string) error {
 	}
 
 	// Convert Go types to OpenAPI types
-	schemaType, format := convertType(dataType)
+	schemaType, format := convertType(s.types, dataType)
+	if schemaType == "object" && paramType != "body" {
+		if timeFormat, ok := s.namedTimeFormat(dataType, op.packageName, op.astFile); ok {
+			schemaType, format = "string", timeFormat
+		}
+	}
 
 	param := domain.Parameter{
 		Name:        name,
@@ -49,11 +93,12 @@ func (s *Service) parseParam(op *operation, line string) error {
 	// Find the end of the description (closing quote) and parse remainder
 	matchEnd := len(matches[0])
 	if matchEnd < len(line) {
-		remainder := line[matchEnd:]
-		if err := parseParamAttributes(&param, remainder); err != nil {
-			return err
+		if err := parseParamAttributes(&param, line[matchEnd:]); err != nil {
+			return fmt.Errorf("%s: @Param %s: %w", op.functionName, name, err)
 		}
 	}
+	// example(value) is kept as written until the param type is known
+	example, hasExample := param.Example.(string)
 
 	// Override format if Format() attribute was specified
 	if param.Format == "" && format != "" {
@@ -62,53 +107,253 @@ func (s *Service) parseParam(op *operation, line string) error {
 
 	// For body parameters with model types, use Schema instead of Type
 	// Body parameters need proper schema references for complex types
-	if paramType == "body" && isModelType(dataType) {
+	if paramType == "body" && isModelType(s.types, dataType) {
 		// Handle map types inline: map[string]interface{} → object, map[string]Model → additionalProperties
 		if strings.HasPrefix(dataType, "map[") {
 			param.Schema = buildMapParamSchema(dataType, op.packageName)
 		} else {
-			// Qualify unqualified type names with the controller's package name
-			qualifiedType := dataType
-			if op.packageName != "" && !strings.Contains(dataType, ".") {
-				qualifiedType = op.packageName + "." + dataType
+			// Reference the model like responses do: qualified with the controller's
+			// package, resolved to its import path and the Public variant under @Public.
+			// Combined types like Request{data=Account} compose an AllOf schema.
+			var modelSchema *domain.Schema
+			if strings.Contains(dataType, "{") {
+				modelSchema = s.buildAllOfResponseSchema(strings.TrimPrefix(dataType, "*"), op.packageName, op.isPublic, op.astFile)
+			} else {
+				modelSchema = s.buildSchemaForTypeWithPublic(strings.TrimPrefix(dataType, "*"), op.packageName, op.isPublic, op.astFile)
 			}
-			// Resolve full import path for unambiguous registry lookup
-			typePath := s.resolveTypePath(qualifiedType, op.astFile)
-			// Build schema for model type
 			if isArray {
 				// Array of models
 				param.Schema = &domain.Schema{
-					Type: "array",
-					Items: &domain.Schema{
-						Ref:      "#/definitions/" + qualifiedType,
-						TypePath: typePath,
-					},
+					Type:  "array",
+					Items: modelSchema,
 				}
 			} else {
 				// Single model
-				param.Schema = &domain.Schema{
-					Ref:      "#/definitions/" + qualifiedType,
-					TypePath: typePath,
-				}
+				param.Schema = modelSchema
 			}
 		}
 	} else {
 		// For non-body parameters or primitives, use Type field
+		// A file param with collectionFormat(multi) uploads several files, like []file
+		if schemaType == "file" && param.CollectionFormat == "multi" {
+			isArray = true
+		}
 		if isArray {
+			// Enums constrain the items of an array, not the array itself
 			param.Type = "array"
 			param.Items = &domain.Items{
 				Type:   schemaType,
 				Format: param.Format,
+				Enum:   param.Enum,
+			}
+			param.Enum = nil
+			// Each uploaded file is its own form part, so file arrays are always multi
+			if schemaType == "file" {
+				param.CollectionFormat = "multi"
 			}
 		} else {
 			param.Type = schemaType
 		}
+		// schema(Type) documents a complex non-body param, e.g. a deepObject query filter,
+		// referencing the model like body params do
+		if param.SchemaType != "" {
+			param.Schema = s.buildSchemaForTypeWithPublic(strings.TrimPrefix(param.SchemaType, "*"), op.packageName, op.isPublic, op.astFile)
+		}
+	}
+
+	if hasExample {
+		if paramType == "body" && param.Schema != nil {
+			param.Schema.Example = parseParamExample(example, param.Schema.Type)
+			param.Example = nil
+		} else if param.Items != nil {
+			var items []interface{}
+			for _, item := range strings.Split(example, ",") {
+				items = append(items, parseParamExample(strings.TrimSpace(item), param.Items.Type))
+			}
+			param.Example = items
+		} else {
+			param.Example = parseParamExample(example, param.Type)
+		}
+	}
+
+	s.checkParamBounds(op, &param)
+
+	// A later @Param with the same name and location refines the earlier one
+	for i := range op.parameters {
+		if op.parameters[i].Name == param.Name && op.parameters[i].In == param.In {
+			mergeParam(&op.parameters[i], param)
+			return nil
+		}
 	}
 
 	op.parameters = append(op.parameters, param)
 	return nil
 }
 
+// ParseNamedParameters collects the shared parameters declared in the general API
+// info of a file, e.g. "@Parameter PageParam query page int false "Page number"",
+// keyed by name. After the name come the location and the parameter name, then
+// the type, required flag, description and attributes as in @Param. Operations
+// reference them with "@Param {ref} PageParam".
+func (s *Service) ParseNamedParameters(astFile *ast.File) (map[string]domain.Parameter, error) {
+	params := make(map[string]domain.Parameter)
+
+	for _, group := range astFile.Comments {
+		for _, line := range strings.Split(group.Text(), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 || !strings.EqualFold(fields[0], "@parameter") {
+				continue
+			}
+			name := fields[1]
+			if !namedResponsePattern.MatchString(name) || len(fields) < 4 {
+				return nil, fmt.Errorf("invalid @Parameter: %s", strings.TrimSpace(line))
+			}
+
+			op := &operation{
+				functionName: "@Parameter " + name,
+				packageName:  astFile.Name.Name,
+				astFile:      astFile,
+			}
+			// Parse the declaration as the @Param of a placeholder operation, which
+			// starts with the parameter name
+			rest := strings.TrimSpace(line)
+			for _, field := range fields[:4] {
+				rest = strings.TrimSpace(strings.TrimPrefix(rest, field))
+			}
+			declaration := fields[3] + " " + fields[2] + " " + rest
+			if err := s.parseParam(op, declaration); err != nil {
+				return nil, fmt.Errorf("@Parameter %s: %w", name, err)
+			}
+			if len(op.parameters) == 0 {
+				// Unsupported locations are dropped outside strict mode
+				continue
+			}
+			params[name] = op.parameters[0]
+		}
+	}
+
+	return params, nil
+}
+
+// unescapeDescription removes the backslashes escaping quotes and backslashes in a
+// quoted @Param description, so \" becomes " and \\ becomes \. Other backslashes,
+// e.g. in a Windows path, are kept.
+func unescapeDescription(description string) string {
+	if !strings.Contains(description, `\`) {
+		return description
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(description); i++ {
+		if description[i] == '\\' && i+1 < len(description) && (description[i+1] == '"' || description[i+1] == '\\') {
+			i++
+		}
+		b.WriteByte(description[i])
+	}
+
+	return b.String()
+}
+
+// mergeParam merges a repeated @Param into the earlier one: every attribute the
+// later line sets replaces the earlier value, the others are kept, and the
+// parameter is required when either line says so.
+func mergeParam(dst *domain.Parameter, src domain.Parameter) {
+	dst.Required = dst.Required || src.Required
+
+	if src.Type != "" {
+		dst.Type = src.Type
+		dst.Schema = src.Schema
+		dst.SchemaType = src.SchemaType
+		dst.Items = src.Items
+	} else if src.Schema != nil {
+		dst.Type = ""
+		dst.Schema = src.Schema
+		dst.Items = nil
+	}
+	if src.Description != "" {
+		dst.Description = src.Description
+	}
+	if src.Format != "" {
+		dst.Format = src.Format
+	}
+	if src.CollectionFormat != "" {
+		dst.CollectionFormat = src.CollectionFormat
+	}
+	if src.Default != nil {
+		dst.Default = src.Default
+	}
+	if src.Example != nil {
+		dst.Example = src.Example
+	}
+	if src.Enum != nil {
+		dst.Enum = src.Enum
+	}
+	if src.Minimum != nil {
+		dst.Minimum = src.Minimum
+	}
+	if src.Maximum != nil {
+		dst.Maximum = src.Maximum
+	}
+	if src.MultipleOf != nil {
+		dst.MultipleOf = src.MultipleOf
+	}
+	if src.MinLength != nil {
+		dst.MinLength = src.MinLength
+	}
+	if src.MaxLength != nil {
+		dst.MaxLength = src.MaxLength
+	}
+	if src.Style != "" {
+		dst.Style = src.Style
+	}
+	if src.Explode != nil {
+		dst.Explode = src.Explode
+	}
+}
+
+// checkParamBounds drops bound attributes that don't apply to the parameter's type,
+// recording a warning for each: Minimum/Maximum/MultipleOf only apply to integer and number
+// parameters, MinLength/MaxLength only to string parameters.
+func (s *Service) checkParamBounds(op *operation, param *domain.Parameter) {
+	isNumeric := param.Type == "integer" || param.Type == "number"
+	isString := param.Type == "string"
+
+	warn := func(attr string) {
+		paramType := param.Type
+		if param.Schema != nil {
+			paramType = "body schema"
+		}
+		s.warnOperation(op, WarningParamBound, "@Param %s: %s does not apply to %s parameters, ignoring it",
+			param.Name, attr, paramType)
+	}
+
+	if !isNumeric {
+		if param.Minimum != nil {
+			warn("minimum")
+			param.Minimum = nil
+		}
+		if param.Maximum != nil {
+			warn("maximum")
+			param.Maximum = nil
+		}
+		if param.MultipleOf != nil {
+			warn("multipleOf")
+			param.MultipleOf = nil
+		}
+	}
+
+	if !isString {
+		if param.MinLength != nil {
+			warn("minLength")
+			param.MinLength = nil
+		}
+		if param.MaxLength != nil {
+			warn("maxLength")
+			param.MaxLength = nil
+		}
+	}
+}
+
 // buildMapParamSchema builds an inline schema for map types in body parameters.
 // map[string]interface{} / map[string]any → { type: object }
 // map[string]SomeModel → { type: object, additionalProperties: { $ref: ... } }
@@ -131,7 +376,7 @@ func buildMapParamSchema(dataType, packageName string) *domain.Schema {
 	}
 
 	if isPrimitiveType(valueType) {
-		schemaType, _ := convertType(valueType)
+		schemaType, _ := convertType(nil, valueType)
 		return &domain.Schema{
 			Type: "object",
 			AdditionalProperties: &domain.Schema{Type: schemaType},
@@ -146,19 +391,70 @@ func buildMapParamSchema(dataType, packageName string) *domain.Schema {
 	}
 }
 
-// parseParam Attributes parses attribute modifiers like Format(int64), Enums(1,2,3), etc.
-func parseParamAttributes(param *domain.Parameter, attrs string) error {
-	// Regex to match attribute patterns: AttributeName(value)
-	attrPattern := regexp.MustCompile(`(\w+)\(([^)]+)\)`)
-	matches := attrPattern.FindAllStringSubmatch(attrs, -1)
+// paramAttribute is a Name(value) attribute of a @Param line.
+type paramAttribute struct {
+	name  string
+	value string
+}
+
+// splitParamAttributes splits the attributes after a @Param description into
+// Name(value) pairs, in the order given. The value runs to the matching closing
+// parenthesis, skipping parentheses nested or in double quotes, e.g.
+// Default("a (b)"). Attributes with an empty value are dropped.
+func splitParamAttributes(attrs string) []paramAttribute {
+	var result []paramAttribute
 
-	for _, match := range matches {
-		if len(match) != 3 {
+	for i := 0; i < len(attrs); {
+		start := i
+		for i < len(attrs) && isAttributeNameChar(attrs[i]) {
+			i++
+		}
+		if i == start || i >= len(attrs) || attrs[i] != '(' {
+			if i == start {
+				i++
+			}
 			continue
 		}
 
-		attrName := strings.ToLower(match[1])
-		attrValue := match[2]
+		end, depth, quoted := -1, 0, false
+		for j := i; j < len(attrs) && end < 0; j++ {
+			switch c := attrs[j]; {
+			case c == '"':
+				quoted = !quoted
+			case quoted:
+			case c == '(':
+				depth++
+			case c == ')':
+				depth--
+				if depth == 0 {
+					end = j
+				}
+			}
+		}
+		if end < 0 {
+			// Unterminated value
+			break
+		}
+
+		if value := attrs[i+1 : end]; value != "" {
+			result = append(result, paramAttribute{name: attrs[start:i], value: value})
+		}
+		i = end + 1
+	}
+
+	return result
+}
+
+// isAttributeNameChar reports whether c may appear in an attribute name.
+func isAttributeNameChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// parseParam Attributes parses attribute modifiers like Format(int64), Enums(1,2,3), etc.
+func parseParamAttributes(param *domain.Parameter, attrs string) error {
+	for _, attr := range splitParamAttributes(attrs) {
+		attrName := strings.ToLower(attr.name)
+		attrValue := attr.value
 
 		switch attrName {
 		case "format":
@@ -193,6 +489,14 @@ func parseParamAttributes(param *domain.Parameter, attrs string) error {
 			if max, err := parseFiniteFloat(attrValue); err == nil {
 				param.Maximum = &max
 			}
+		case "collectionformat":
+			if format := field.TransToValidCollectionFormat(strings.TrimSpace(attrValue)); format != "" {
+				param.CollectionFormat = format
+			}
+		case "multipleof":
+			if multiple, err := parseFiniteFloat(attrValue); err == nil && multiple > 0 {
+				param.MultipleOf = &multiple
+			}
 		case "minlength":
 			var minLen int
 			if _, err := fmt.Sscanf(attrValue, "%d", &minLen); err == nil {
@@ -224,12 +528,51 @@ func parseParamAttributes(param *domain.Parameter, attrs string) error {
 				// Default to string (remove surrounding quotes if present)
 				param.Default = strings.Trim(attrValue, "\"'")
 			}
+		case "style":
+			param.Style = strings.TrimSpace(attrValue)
+		case "explode":
+			explode, err := strconv.ParseBool(strings.TrimSpace(attrValue))
+			if err != nil {
+				return fmt.Errorf("invalid explode(%s), expected true or false", attrValue)
+			}
+			param.Explode = &explode
+		case "schema":
+			param.SchemaType = strings.TrimSpace(attrValue)
+		case "example":
+			param.Example = strings.TrimSpace(attrValue)
 		}
 	}
 
 	return nil
 }
 
+// parseParamExample converts an example(value) to the param type: integers,
+// finite numbers and booleans that don't parse stay strings. Examples of object,
+// array and untyped schemas, e.g. body models, are parsed as JSON when they can be.
+func parseParamExample(value, schemaType string) interface{} {
+	switch schemaType {
+	case "integer":
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := parseFiniteFloat(value); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case "object", "array", "":
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err == nil {
+			return v
+		}
+	}
+
+	return strings.Trim(value, "\"'")
+}
+
 // parseFloat parses a string to float64
 func parseFloat(s string) (float64, error) {
 	var f float64
@@ -252,8 +595,9 @@ func parseFiniteFloat(s string) (float64, error) {
 	return f, nil
 }
 
-// convertType converts Go types to OpenAPI types
-func convertType(goType string) (schemaType string, format string) {
+// convertType converts Go types to OpenAPI types. Extended primitives are looked
+// up in types, or in the built-in mappings when types is nil.
+func convertType(types *typeregistry.Registry, goType string) (schemaType string, format string) {
 	switch goType {
 	case "int", "int32", "int64", "uint", "uint32", "uint64":
 		return "integer", goType
@@ -274,7 +618,52 @@ func convertType(goType string) (schemaType string, format string) {
 	case "file":
 		return "file", ""
 	default:
-		// For custom types, treat as object
+		// Extended primitives like time.Time, otherwise custom types are objects
+		if entry, ok := types.Lookup(goType); ok {
+			return entry.SchemaType, entry.Format
+		}
 		return "object", ""
 	}
 }
+
+// namedTimeFormat returns the format of a type defined as time.Time, e.g.
+// type DateOnly time.Time, see typeregistry.TimeFormat.
+func (s *Service) namedTimeFormat(dataType, packageName string, file *ast.File) (string, bool) {
+	if s.registry == nil {
+		return "", false
+	}
+
+	qualifiedType := strings.TrimPrefix(dataType, "*")
+	if packageName != "" && !strings.Contains(qualifiedType, ".") {
+		qualifiedType = packageName + "." + qualifiedType
+	}
+
+	typeDef := s.registry.FindTypeSpec(qualifiedType, file)
+	if typeDef == nil || typeDef.TypeSpec == nil || !isTimeExpr(typeDef.TypeSpec.Type, typeDef.File) {
+		return "", false
+	}
+	return typeregistry.TimeFormat(typeDef.TypeSpec.Name.Name), true
+}
+
+// isTimeExpr reports whether expr is time.Time, given the imports of the file declaring it.
+func isTimeExpr(expr ast.Expr, file *ast.File) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Time" {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || file == nil {
+		return false
+	}
+
+	for _, imp := range file.Imports {
+		if imp.Path.Value != `"time"` {
+			continue
+		}
+		if imp.Name == nil {
+			return pkg.Name == "time"
+		}
+		return pkg.Name == imp.Name.Name
+	}
+	return false
+}
@@ -1,15 +1,21 @@
 package route
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/go-openapi/spec"
-	"github.com/griffnb/core-swag/internal/console"
 	"github.com/griffnb/core-swag/internal/parser/route/domain"
+	"github.com/griffnb/core-swag/internal/schema"
 )
 
+// ErrUnknownSecurityScope is returned when an operation requests an oauth2
+// scope that its security definition does not declare.
+var ErrUnknownSecurityScope = errors.New("unknown security scope")
+
 // RegisterRoutes registers routes to swagger.Paths
 // strict controls whether to error on duplicate routes
 func (s *Service) RegisterRoutes(swagger *spec.Swagger, routes []*domain.Route, strict bool) error {
@@ -45,10 +51,22 @@ func (s *Service) registerRoute(swagger *spec.Swagger, route *domain.Route, stri
 	// Check if operation already exists
 	if *op != nil {
 		err := fmt.Errorf("route %s %s is declared multiple times", route.Method, route.Path)
+		if function, ok := (*op).Extensions.GetString("x-function"); ok {
+			err = fmt.Errorf("%w, already declared by %s", err, function)
+		}
+		if strict {
+			return err
+		}
+		s.warn(WarningDuplicateRoute, route.FilePath, route.LineNumber, "%s", err)
+	}
+
+	// Check requested oauth2 scopes against the declared security definitions
+	if err := ValidateSecurityScopes(route.Security, swagger.SecurityDefinitions); err != nil {
+		err = fmt.Errorf("route %s %s: %w", route.Method, route.Path, err)
 		if strict {
 			return err
 		}
-		console.Logger.Debug("warning: %s\n", err)
+		s.warn(WarningUnknownSecurityScope, route.FilePath, route.LineNumber, "%s", err)
 	}
 
 	// Convert domain.Route to spec.Operation
@@ -64,6 +82,9 @@ func (s *Service) registerRoute(swagger *spec.Swagger, route *domain.Route, stri
 
 	// Set the operation
 	*op = specOp
+	if route.Method == http.MethodTrace {
+		schema.SetTraceOperation(&pathItem, specOp)
+	}
 
 	// Save the path item
 	swagger.Paths.Paths[route.Path] = pathItem
@@ -71,7 +92,37 @@ func (s *Service) registerRoute(swagger *spec.Swagger, route *domain.Route, stri
 	return nil
 }
 
-// refRouteMethodOp returns a pointer to the operation field for the given HTTP method
+// ValidateSecurityScopes checks that every scope requested by an operation's
+// security requirements is declared by the matching oauth2 security definition.
+// Schemes that are undefined, not oauth2, or declare no scopes are not checked.
+func ValidateSecurityScopes(security []map[string][]string, definitions spec.SecurityDefinitions) error {
+	for _, requirement := range security {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			scheme, ok := definitions[name]
+			if !ok || scheme == nil || scheme.Type != "oauth2" || len(scheme.Scopes) == 0 {
+				continue
+			}
+
+			for _, scope := range requirement[name] {
+				if _, declared := scheme.Scopes[scope]; !declared {
+					return fmt.Errorf("%w %q for security scheme %q", ErrUnknownSecurityScope, scope, name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// refRouteMethodOp returns a pointer to the operation field for the given HTTP method.
+// Path items have no TRACE field, so for TRACE it points to a copy of the x-trace
+// extension's operation, which the caller stores back with schema.SetTraceOperation.
 func refRouteMethodOp(item *spec.PathItem, method string) **spec.Operation {
 	switch method {
 	case http.MethodGet:
@@ -88,6 +139,9 @@ func refRouteMethodOp(item *spec.PathItem, method string) **spec.Operation {
 		return &item.Head
 	case http.MethodOptions:
 		return &item.Options
+	case http.MethodTrace:
+		trace := schema.TraceOperation(*item)
+		return &trace
 	default:
 		return nil
 	}
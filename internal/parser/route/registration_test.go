@@ -1,9 +1,12 @@
 package route
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/console"
 	"github.com/griffnb/core-swag/internal/parser/route/domain"
 )
 
@@ -145,17 +148,25 @@ func TestRegisterRoutes(t *testing.T) {
 				Summary: "List users v1",
 			},
 			{
-				Method:  "GET",
-				Path:    "/users",
-				Summary: "List users v2",
+				Method:     "GET",
+				Path:       "/users",
+				Summary:    "List users v2",
+				FilePath:   "users.go",
+				LineNumber: 12,
 			},
 		}
+		warnings := console.NewDiagnostics()
+		service.SetWarnings(warnings)
 
 		// Should not error in non-strict mode, just warn
 		err := service.RegisterRoutes(swagger, routes, false)
 		if err != nil {
 			t.Fatalf("Expected no error in non-strict mode, got %v", err)
 		}
+		got := warnings.List()
+		if len(got) != 1 || got[0].Category != WarningDuplicateRoute || got[0].File != "users.go" || got[0].Line != 12 {
+			t.Errorf("Expected a duplicate-route warning at users.go:12, got %+v", got)
+		}
 
 		// Last one wins
 		pathItem := swagger.Paths.Paths["/users"]
@@ -342,6 +353,80 @@ func TestRegisterRoute(t *testing.T) {
 	})
 }
 
+func TestValidateSecurityScopes(t *testing.T) {
+	newSwagger := func() *spec.Swagger {
+		return &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Paths: &spec.Paths{
+					Paths: make(map[string]spec.PathItem),
+				},
+				SecurityDefinitions: spec.SecurityDefinitions{
+					"OAuth2":     spec.OAuth2AccessToken("https://example.com/auth", "https://example.com/token"),
+					"ApiKeyAuth": spec.APIKeyAuth("Authorization", "header"),
+				},
+			},
+		}
+	}
+
+	newRoute := func(scopes ...string) *domain.Route {
+		return &domain.Route{
+			Method:   "POST",
+			Path:     "/users",
+			Security: []map[string][]string{{"OAuth2": scopes}, {"ApiKeyAuth": {}}},
+		}
+	}
+
+	t.Run("accepts declared scopes", func(t *testing.T) {
+		swagger := newSwagger()
+		swagger.SecurityDefinitions["OAuth2"].AddScope("read", "Read access")
+		swagger.SecurityDefinitions["OAuth2"].AddScope("write", "Write access")
+
+		service := NewService(nil, "")
+		if err := service.RegisterRoutes(swagger, []*domain.Route{newRoute("read", "write")}, true); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("errors on unknown scope in strict mode", func(t *testing.T) {
+		swagger := newSwagger()
+		swagger.SecurityDefinitions["OAuth2"].AddScope("read", "Read access")
+
+		service := NewService(nil, "")
+		err := service.RegisterRoutes(swagger, []*domain.Route{newRoute("read", "admin")}, true)
+		if !errors.Is(err, ErrUnknownSecurityScope) {
+			t.Fatalf("Expected ErrUnknownSecurityScope, got %v", err)
+		}
+		if !strings.Contains(err.Error(), `"admin"`) {
+			t.Errorf("Expected error to name the unknown scope, got %v", err)
+		}
+	})
+
+	t.Run("registers unknown scope when not strict", func(t *testing.T) {
+		swagger := newSwagger()
+		swagger.SecurityDefinitions["OAuth2"].AddScope("read", "Read access")
+
+		service := NewService(nil, "")
+		warnings := console.NewDiagnostics()
+		service.SetWarnings(warnings)
+		if err := service.RegisterRoutes(swagger, []*domain.Route{newRoute("admin")}, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if swagger.Paths.Paths["/users"].Post == nil {
+			t.Fatal("Expected POST operation to exist")
+		}
+		if got := warnings.List(); len(got) != 1 || got[0].Category != WarningUnknownSecurityScope {
+			t.Errorf("Expected an unknown-security-scope warning, got %+v", got)
+		}
+	})
+
+	t.Run("skips schemes without declared scopes", func(t *testing.T) {
+		err := ValidateSecurityScopes(newRoute("anything").Security, newSwagger().SecurityDefinitions)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+}
+
 func TestRefRouteMethodOp(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -1,27 +1,154 @@
 package route
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 
 	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
+	"github.com/griffnb/core-swag/internal/schema"
 	"github.com/griffnb/core-swag/internal/typeregistry"
 )
 
 var (
 	// Matches: 200 {object} string "description" OR 200 {object} string
 	responsePattern = regexp.MustCompile(`([\w,]+)\s+\{(\w+)\}\s+(\S+)(?:\s+"([^"]+)")?`)
+	// Matches: 200 {integer} X-Rate-Limit "description" OR 200 {[]string} X-Tags "description"
+	headerPattern = regexp.MustCompile(`([\w,]+)\s+\{((?:\[\])?[\w.]+)\}\s+(\S+)(?:\s+"([^"]+)")?`)
+	// Matches: 200 {file} "description" OR 200 {file} (binary download without a data type)
+	fileResponsePattern = regexp.MustCompile(`^([\w,]+)\s+\{file\}(?:\s+"([^"]+)")?\s*$`)
 	// Matches: 200 "description"
 	emptyResponsePattern = regexp.MustCompile(`([\w,]+)\s+"([^"]+)"`)
+	// Matches: 204 OR 204,205, status codes without a description
+	bareResponsePattern = regexp.MustCompile(`^([\w,]+)$`)
+	// Matches the name of a shared response: an identifier that is not a status code
+	namedResponsePattern = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+	// Matches a trailing content-type override: produce(text/csv) or produce(csv,json)
+	responseProducePattern = regexp.MustCompile(`(?i)\s+produce\(([^)]*)\)\s*$`)
 )
 
-// parseResponse parses @success, @failure, or @response annotations
+// parseResponse parses @success, @failure, or @response annotations.
+// A trailing produce(...) overrides the operation's content types for the response.
 func (s *Service) parseResponse(op *operation, line string) error {
+	var produces []string
+	if matches := responseProducePattern.FindStringSubmatch(line); matches != nil {
+		if err := s.parseMimeTypes(matches[1], &produces); err != nil {
+			return err
+		}
+		if len(produces) == 0 {
+			return fmt.Errorf("empty produce() in response: %s", line)
+		}
+		line = line[:len(line)-len(matches[0])]
+	}
+
+	if err := s.parseResponseLine(op, line); err != nil {
+		return err
+	}
+	if len(produces) == 0 {
+		return nil
+	}
+
+	// Every response format starts with the comma separated status codes
+	codes, _ := parseStatusCodes(strings.Fields(line)[0])
+	for _, status := range codes {
+		if status.ranged && !op.ranged[status.code] {
+			continue
+		}
+		code := status.code
+		response := op.responses[code]
+		if response.Ref != "" {
+			return fmt.Errorf("produce() can't be used with {ref} responses: %s", line)
+		}
+		response.Produces = produces
+		op.responses[code] = response
+	}
+
+	return nil
+}
+
+// statusRanges expands the NXX status code shorthand. Swagger 2.0 has no status
+// code ranges, so a range stands for the common codes of its class.
+var statusRanges = map[string][]int{
+	"1XX": {100},
+	"2XX": {200},
+	"3XX": {301, 302, 304},
+	"4XX": {400, 401, 403, 404},
+	"5XX": {500, 502, 503, 504},
+}
+
+// statusCode is a response status code, expanded from an NXX range when ranged is set.
+type statusCode struct {
+	code   int
+	ranged bool
+}
+
+// parseStatusCodes parses the comma separated status codes of a response
+// annotation, expanding NXX ranges with statusRanges.
+func parseStatusCodes(statusCodes string) ([]statusCode, error) {
+	var codes []statusCode
+	for _, codeStr := range strings.Split(statusCodes, ",") {
+		codeStr = strings.TrimSpace(codeStr)
+		if expanded, ok := statusRanges[strings.ToUpper(codeStr)]; ok {
+			for _, code := range expanded {
+				codes = append(codes, statusCode{code: code, ranged: true})
+			}
+			continue
+		}
+		code, err := parseStatusCode(codeStr)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, statusCode{code: code})
+	}
+	return codes, nil
+}
+
+// claimResponse reports whether a response annotation may set the response for
+// status. A code expanded from a range doesn't replace a response declared for
+// that exact code, in whichever order they come, while an exact code replaces it.
+func (op *operation) claimResponse(status statusCode) bool {
+	if !status.ranged {
+		delete(op.ranged, status.code)
+		return true
+	}
+	if _, exists := op.responses[status.code]; exists && !op.ranged[status.code] {
+		return false
+	}
+	if op.ranged == nil {
+		op.ranged = make(map[int]bool)
+	}
+	op.ranged[status.code] = true
+	return true
+}
+
+// parseStatusCode parses the status code of a response annotation, where
+// "default" is the catch-all response of the operation.
+func parseStatusCode(codeStr string) (int, error) {
+	codeStr = strings.TrimSpace(codeStr)
+	if strings.EqualFold(codeStr, "default") {
+		return routedomain.DefaultResponseCode, nil
+	}
+	code, err := strconv.Atoi(codeStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid status code: %s", codeStr)
+	}
+	return code, nil
+}
+
+// parseResponseLine parses a response annotation without its produce(...) override.
+func (s *Service) parseResponseLine(op *operation, line string) error {
+	// File responses may omit the data type
+	matches := fileResponsePattern.FindStringSubmatch(strings.TrimSpace(line))
+	if len(matches) == 3 {
+		return s.parseResponseWithSchema(op, []string{matches[0], matches[1], "file", "", matches[2]})
+	}
+
 	// Try to match with schema first
-	matches := responsePattern.FindStringSubmatch(line)
+	matches = responsePattern.FindStringSubmatch(line)
 	if len(matches) == 5 {
 		return s.parseResponseWithSchema(op, matches)
 	}
@@ -32,6 +159,12 @@ func (s *Service) parseResponse(op *operation, line string) error {
 		return s.parseEmptyResponse(op, matches)
 	}
 
+	// Bare status codes, e.g. "@Success 204", are described by their status text
+	matches = bareResponsePattern.FindStringSubmatch(strings.TrimSpace(line))
+	if len(matches) == 2 {
+		return s.parseEmptyResponse(op, []string{matches[0], matches[1], ""})
+	}
+
 	return fmt.Errorf("invalid response format: %s", line)
 }
 
@@ -47,22 +180,45 @@ func (s *Service) parseResponseWithSchema(op *operation, matches []string) error
 		description = "OK" // Default description
 	}
 
+	// Reject circular combined types up front instead of silently falling back
+	if strings.Contains(dataType, "{") {
+		if _, _, err := schema.ParseCombinedType(dataType); errors.Is(err, schema.ErrCircularCombinedType) {
+			return fmt.Errorf("%s: %w", op.functionName, err)
+		}
+	}
+
+	// {ref} points at a shared response declared with a general-info @Response
+	if schemaType == "ref" {
+		codes, err := parseStatusCodes(statusCodes)
+		if err != nil {
+			return err
+		}
+		for _, status := range codes {
+			if op.claimResponse(status) {
+				op.responses[status.code] = routedomain.Response{Ref: dataType}
+			}
+		}
+		return nil
+	}
+
 	// Build the schema with package context and @Public support
-	schema := s.buildSchemaWithPackageAndPublic(schemaType, dataType, op.packageName, op.isPublic, op.astFile)
+	responseSchema := s.buildSchemaWithPackageAndPublic(schemaType, dataType, op.packageName, op.isPublic, op.astFile)
 
 	// Parse status codes (can be comma-separated)
-	for _, codeStr := range strings.Split(statusCodes, ",") {
-		codeStr = strings.TrimSpace(codeStr)
-
-		code, err := strconv.Atoi(codeStr)
-		if err != nil {
-			return fmt.Errorf("invalid status code: %s", codeStr)
+	codes, err := parseStatusCodes(statusCodes)
+	if err != nil {
+		return err
+	}
+	for _, status := range codes {
+		if !op.claimResponse(status) {
+			continue
 		}
+		code := status.code
 
 		// Create or update the response
 		response := routedomain.Response{
 			Description: description,
-			Schema:      schema,
+			Schema:      responseSchema,
 			Headers:     make(map[string]routedomain.Header),
 		}
 
@@ -77,23 +233,34 @@ func (s *Service) parseResponseWithSchema(op *operation, matches []string) error
 	return nil
 }
 
-// parseEmptyResponse parses a response without a schema
+// parseEmptyResponse parses a response without a schema, e.g. a 204. Its Schema
+// stays nil, so no empty schema is written. Without a description, the standard
+// status text describes it.
 func (s *Service) parseEmptyResponse(op *operation, matches []string) error {
 	statusCodes := matches[1]
 	description := matches[2]
 
 	// Parse status codes (can be comma-separated)
-	for _, codeStr := range strings.Split(statusCodes, ",") {
-		codeStr = strings.TrimSpace(codeStr)
+	codes, err := parseStatusCodes(statusCodes)
+	if err != nil {
+		return err
+	}
+	for _, status := range codes {
+		if !op.claimResponse(status) {
+			continue
+		}
+		code := status.code
 
-		code, err := strconv.Atoi(codeStr)
-		if err != nil {
-			return fmt.Errorf("invalid status code: %s", codeStr)
+		codeDescription := description
+		if codeDescription == "" {
+			if codeDescription = http.StatusText(code); codeDescription == "" {
+				return fmt.Errorf("response %s needs a description", statusCodes)
+			}
 		}
 
 		// Create or update the response
 		response := routedomain.Response{
-			Description: description,
+			Description: codeDescription,
 			Headers:     make(map[string]routedomain.Header),
 		}
 
@@ -108,6 +275,77 @@ func (s *Service) parseEmptyResponse(op *operation, matches []string) error {
 	return nil
 }
 
+// ParseNamedResponses collects the shared responses declared in the general API
+// info of a file, e.g. "@Response DefaultError {object} web.APIError "Server error"",
+// keyed by name. Headers are declared on them by name, e.g.
+// "@Header DefaultError {integer} X-RateLimit-Limit "Requests per hour"", so every
+// operation referencing the response shares them. Route-level @Response and @Header
+// lines, which start with a status code, all or default, are skipped.
+func (s *Service) ParseNamedResponses(astFile *ast.File) (map[string]routedomain.Response, error) {
+	responses := make(map[string]routedomain.Response)
+	var headerLines []string
+
+	for _, group := range astFile.Comments {
+		for _, line := range strings.Split(group.Text(), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 || !isResponseName(fields[1]) {
+				continue
+			}
+			if strings.EqualFold(fields[0], "@header") {
+				headerLines = append(headerLines, strings.TrimSpace(line))
+				continue
+			}
+			if !strings.EqualFold(fields[0], "@response") {
+				continue
+			}
+			name := fields[1]
+
+			op := &operation{
+				packageName: astFile.Name.Name,
+				astFile:     astFile,
+				responses:   make(map[int]routedomain.Response),
+			}
+			// Parse the declaration as the default response of a placeholder operation
+			rest := strings.TrimSpace(strings.TrimSpace(line)[len(fields[0]):])
+			declaration := "default " + strings.TrimSpace(rest[len(name):])
+			if err := s.parseResponse(op, declaration); err != nil {
+				return nil, fmt.Errorf("@Response %s: %w", name, err)
+			}
+
+			response := op.responses[routedomain.DefaultResponseCode]
+			if response.Ref != "" {
+				return nil, fmt.Errorf("@Response %s: shared responses can't reference other responses", name)
+			}
+			responses[name] = response
+		}
+	}
+
+	// Headers may be declared before their response, so they're added last
+	for _, line := range headerLines {
+		fields := strings.Fields(line)
+		name := fields[1]
+		response, ok := responses[name]
+		if !ok {
+			return nil, fmt.Errorf("@Header %s: no @Response %s declared", name, name)
+		}
+
+		op := &operation{responses: map[int]routedomain.Response{routedomain.DefaultResponseCode: response}}
+		declaration := "default " + strings.TrimSpace(strings.TrimSpace(line[len(fields[0]):])[len(name):])
+		if err := s.parseHeader(op, declaration); err != nil {
+			return nil, fmt.Errorf("@Header %s: %w", name, err)
+		}
+		responses[name] = op.responses[routedomain.DefaultResponseCode]
+	}
+
+	return responses, nil
+}
+
+// isResponseName reports whether the first argument of a @Response or @Header line
+// names a shared response rather than status codes, all or default.
+func isResponseName(name string) bool {
+	return namedResponsePattern.MatchString(name) && !strings.EqualFold(name, "all") && !strings.EqualFold(name, "default")
+}
+
 // buildSchema builds a schema from the schemaType and dataType
 func (s *Service) buildSchema(schemaType, dataType string) *routedomain.Schema {
 	return s.buildSchemaWithPackage(schemaType, dataType, "")
@@ -121,7 +359,7 @@ func (s *Service) buildSchemaWithPackage(schemaType, dataType, packageName strin
 // buildSchemaWithPackageAndPublic builds a schema with package qualification and @Public support.
 // file is used for import resolution to produce fully qualified TypePath values.
 func (s *Service) buildSchemaWithPackageAndPublic(schemaType, dataType, packageName string, isPublic bool, file *ast.File) *routedomain.Schema {
-	schema := &routedomain.Schema{}
+	result := &routedomain.Schema{}
 
 	// Check for AllOf combined type syntax: Response{data=Account}
 	if strings.Contains(dataType, "{") {
@@ -131,19 +369,19 @@ func (s *Service) buildSchemaWithPackageAndPublic(schemaType, dataType, packageN
 
 	if schemaType == "file" {
 		// File response (e.g., @Success 200 {file} []byte "File content")
-		schema.Type = "file"
-		return schema
+		result.Type = "file"
+		return result
 	} else if schemaType == "array" {
-		schema.Type = "array"
+		result.Type = "array"
 		// For array items, apply @Public flag
 		itemSchema := s.buildSchemaForTypeWithPublic(dataType, packageName, isPublic, file)
-		schema.Items = itemSchema
+		result.Items = itemSchema
 	} else {
 		// Build schema for the type with @Public flag
 		return s.buildSchemaForTypeWithPublic(dataType, packageName, isPublic, file)
 	}
 
-	return schema
+	return result
 }
 
 // buildSchemaForType builds a schema for a single type, creating refs for model types
@@ -160,7 +398,7 @@ func (s *Service) buildSchemaForTypeWithPackage(dataType, packageName string) *r
 // file is used for import resolution to produce fully qualified TypePath values.
 func (s *Service) buildSchemaForTypeWithPublic(dataType, packageName string, isPublic bool, file *ast.File) *routedomain.Schema {
 	// Check if it's a primitive type
-	primitiveType := convertTypeToSchemaType(dataType)
+	primitiveType := convertTypeToSchemaType(s.types, dataType)
 	if primitiveType != "object" {
 		// It's a primitive - return with type
 		return &routedomain.Schema{Type: primitiveType}
@@ -179,6 +417,11 @@ func (s *Service) buildSchemaForTypeWithPublic(dataType, packageName string, isP
 		qualifiedType = packageName + "." + dataType
 	}
 
+	qualifiedType, aliasSchema := s.resolveAliasType(qualifiedType, file)
+	if aliasSchema != nil {
+		return aliasSchema
+	}
+
 	// Resolve full import path for unambiguous registry lookup.
 	// Do this before appending Public suffix since the registry stores base types.
 	typePath := s.resolveTypePath(qualifiedType, file)
@@ -197,8 +440,9 @@ func (s *Service) buildSchemaForTypeWithPublic(dataType, packageName string, isP
 }
 
 // convertTypeToSchemaType converts a data type to a schema type.
-// Handles basic Go types and extended primitives (time.Time, UUID, decimal).
-func convertTypeToSchemaType(dataType string) string {
+// Handles basic Go types and extended primitives (time.Time, UUID, decimal), which
+// are looked up in types, or in the built-in mappings when types is nil.
+func convertTypeToSchemaType(types *typeregistry.Registry, dataType string) string {
 	// Strip pointer prefix for processing
 	cleanType := strings.TrimPrefix(dataType, "*")
 
@@ -214,7 +458,7 @@ func convertTypeToSchemaType(dataType string) string {
 	}
 
 	// Check extended primitives via centralized registry
-	if entry, ok := typeregistry.Lookup(dataType); ok {
+	if entry, ok := types.Lookup(dataType); ok {
 		return entry.SchemaType
 	}
 
@@ -225,9 +469,10 @@ func convertTypeToSchemaType(dataType string) string {
 // parseHeader parses @header annotation
 // Format: @Header statusCode {type} headerName "description"
 // Example: @Header 200 {string} X-Request-Id "Request ID"
+// The type is a Go or OpenAPI primitive ({int64}, {integer}, {boolean}, ...), or an
+// array of them: {[]int} or {array}, whose items are strings.
 func (s *Service) parseHeader(op *operation, line string) error {
-	// Reuse response pattern to parse header
-	matches := responsePattern.FindStringSubmatch(line)
+	matches := headerPattern.FindStringSubmatch(line)
 	if len(matches) != 5 {
 		return fmt.Errorf("invalid header format: %s", line)
 	}
@@ -238,10 +483,21 @@ func (s *Service) parseHeader(op *operation, line string) error {
 	description := matches[4]
 
 	header := routedomain.Header{
-		Type:        convertTypeToSchemaType(headerType),
 		Description: description,
 	}
 
+	if itemType, isArray := strings.CutPrefix(headerType, "[]"); isArray || headerType == "array" {
+		if !isArray {
+			itemType = "string"
+		}
+		items := &routedomain.Items{}
+		items.Type, items.Format = headerTypeFormat(s.types, itemType)
+		header.Type = "array"
+		header.Items = items
+	} else {
+		header.Type, header.Format = headerTypeFormat(s.types, headerType)
+	}
+
 	// Handle "all" status code
 	if strings.EqualFold(statusCodes, "all") {
 		// Add header to all existing responses
@@ -256,13 +512,12 @@ func (s *Service) parseHeader(op *operation, line string) error {
 	}
 
 	// Parse specific status codes
-	for _, codeStr := range strings.Split(statusCodes, ",") {
-		codeStr = strings.TrimSpace(codeStr)
-
-		code, err := strconv.Atoi(codeStr)
-		if err != nil {
-			return fmt.Errorf("invalid status code: %s", codeStr)
-		}
+	codes, err := parseStatusCodes(statusCodes)
+	if err != nil {
+		return err
+	}
+	for _, status := range codes {
+		code := status.code
 
 		// Get or create the response
 		response, ok := op.responses[code]
@@ -283,14 +538,39 @@ func (s *Service) parseHeader(op *operation, line string) error {
 	return nil
 }
 
+// headerTypeFormat resolves the type and format of a header value from a Go or
+// OpenAPI primitive type name, e.g. int64 is integer/int64 and integer is integer.
+func headerTypeFormat(types *typeregistry.Registry, dataType string) (string, string) {
+	cleanType := strings.TrimPrefix(dataType, "*")
+
+	switch cleanType {
+	case "integer", "number", "boolean", "string":
+		return cleanType, ""
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32", "rune":
+		return "integer", "int32"
+	case "int64", "uint64":
+		return "integer", "int64"
+	case "float32":
+		return "number", "float"
+	case "float64":
+		return "number", "double"
+	}
+
+	if entry, ok := types.Lookup(dataType); ok {
+		return entry.SchemaType, entry.Format
+	}
+
+	return convertTypeToSchemaType(types, dataType), ""
+}
+
 // resolveTypePathsInSchema recursively walks a domain schema tree and resolves TypePath
 // for any schema that has a $ref but no TypePath yet.
-func (s *Service) resolveTypePathsInSchema(schema *routedomain.Schema, file *ast.File) {
-	if schema == nil {
+func (s *Service) resolveTypePathsInSchema(target *routedomain.Schema, file *ast.File) {
+	if target == nil {
 		return
 	}
-	if schema.Ref != "" && schema.TypePath == "" {
-		typeName := strings.TrimPrefix(schema.Ref, "#/definitions/")
+	if target.Ref != "" && target.TypePath == "" {
+		typeName := strings.TrimPrefix(target.Ref, "#/definitions/")
 		// Strip Public suffix for lookup since registry stores base types
 		lookupName := typeName
 		isPublicRef := strings.HasSuffix(lookupName, "Public")
@@ -299,18 +579,18 @@ func (s *Service) resolveTypePathsInSchema(schema *routedomain.Schema, file *ast
 		}
 		if tp := s.resolveTypePath(lookupName, file); tp != "" {
 			if isPublicRef {
-				schema.TypePath = tp + "Public"
+				target.TypePath = tp + "Public"
 			} else {
-				schema.TypePath = tp
+				target.TypePath = tp
 			}
 		}
 	}
-	s.resolveTypePathsInSchema(schema.Items, file)
-	s.resolveTypePathsInSchema(schema.AdditionalProperties, file)
-	for _, prop := range schema.Properties {
+	s.resolveTypePathsInSchema(target.Items, file)
+	s.resolveTypePathsInSchema(target.AdditionalProperties, file)
+	for _, prop := range target.Properties {
 		s.resolveTypePathsInSchema(prop, file)
 	}
-	for _, allOf := range schema.AllOf {
+	for _, allOf := range target.AllOf {
 		s.resolveTypePathsInSchema(allOf, file)
 	}
 }
@@ -12,8 +12,9 @@ import (
 
 // isModelType checks if a type name represents a model type (not a primitive).
 // Returns true for custom types and qualified types (package.Type), false for Go primitives.
-// Also returns false for extended primitives like time.Time, UUID, and decimal.
-func isModelType(typeName string) bool {
+// Also returns false for extended primitives like time.Time, UUID, and decimal, which
+// are looked up in types, or in the built-in mappings when types is nil.
+func isModelType(types *typeregistry.Registry, typeName string) bool {
 	// Strip pointer prefix for checking
 	cleanType := strings.TrimPrefix(typeName, "*")
 
@@ -32,7 +33,7 @@ func isModelType(typeName string) bool {
 	}
 
 	// Check extended primitives via centralized registry
-	if typeregistry.IsExtendedPrimitive(typeName) {
+	if types.IsExtendedPrimitive(typeName) {
 		return false
 	}
 
@@ -53,7 +54,7 @@ func (s *Service) resolveTypeSchema(typeName string, file *ast.File, useRef bool
 	// TODO: Implement full type resolution when typeResolver interface is defined
 	// For now, always return basic schema
 	return &domain.Schema{
-		Type: convertTypeToSchemaType(typeName),
+		Type: convertTypeToSchemaType(s.types, typeName),
 	}, nil
 }
 
@@ -185,6 +185,63 @@ func CreateUsers() {}
 		assert.NotNil(t, param.Schema.Items)
 		assert.NotEmpty(t, param.Schema.Items.Ref)
 	})
+
+	t.Run("should reference the Public variant of array items with @Public", func(t *testing.T) {
+		src := `
+package test
+
+// @Public
+// @Param items body []Account true "list"
+// @Param owners body []*model.User true "owners"
+// @Router /accounts/bulk [post]
+func CreateAccounts() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 2)
+
+		require.NotNil(t, params[0].Schema)
+		assert.Equal(t, "array", params[0].Schema.Type)
+		require.NotNil(t, params[0].Schema.Items)
+		assert.Equal(t, "#/definitions/test.AccountPublic", params[0].Schema.Items.Ref)
+
+		require.NotNil(t, params[1].Schema)
+		assert.Equal(t, "array", params[1].Schema.Type)
+		require.NotNil(t, params[1].Schema.Items)
+		assert.Equal(t, "#/definitions/model.UserPublic", params[1].Schema.Items.Ref)
+	})
+
+	t.Run("should reference the base model of array items without @Public", func(t *testing.T) {
+		src := `
+package test
+
+// @Param items body []Account true "list"
+// @Router /accounts/bulk [post]
+func CreateAccounts() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		param := routes[0].Parameters[0]
+		require.NotNil(t, param.Schema)
+		assert.Equal(t, "array", param.Schema.Type)
+		require.NotNil(t, param.Schema.Items)
+		assert.Equal(t, "#/definitions/test.Account", param.Schema.Items.Ref)
+	})
 }
 
 // TestParseResponseWithModelType tests parsing responses with model types
@@ -341,7 +398,7 @@ func TestIsModelType(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := isModelType(tc.typeName)
+			result := isModelType(nil, tc.typeName)
 			assert.Equal(t, tc.isModel, result, "isModelType(%s) = %v, want %v", tc.typeName, result, tc.isModel)
 		})
 	}
@@ -374,7 +431,7 @@ func TestIsModelType_ExtendedPrimitives(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := isModelType(tc.typeName)
+			result := isModelType(nil, tc.typeName)
 			assert.Equal(t, tc.isModel, result, "isModelType(%s) = %v, want %v", tc.typeName, result, tc.isModel)
 		})
 	}
@@ -416,7 +473,7 @@ func TestConvertTypeToSchemaType(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := convertTypeToSchemaType(tc.dataType)
+			result := convertTypeToSchemaType(nil, tc.dataType)
 			assert.Equal(t, tc.schemaType, result, "convertTypeToSchemaType(%s) = %s, want %s", tc.dataType, result, tc.schemaType)
 		})
 	}
@@ -4,13 +4,32 @@
 package route
 
 import (
+	"errors"
+	"fmt"
 	"go/ast"
 	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/griffnb/core-swag/internal/console"
 	"github.com/griffnb/core-swag/internal/domain"
 	routedomain "github.com/griffnb/core-swag/internal/parser/route/domain"
+	"github.com/griffnb/core-swag/internal/schema"
+	"github.com/griffnb/core-swag/internal/typeregistry"
 )
 
+// ErrMarkdownFile is returned in strict mode when an operation's markdown description can't be loaded.
+var ErrMarkdownFile = errors.New("failed to load markdown description")
+
+// ErrParamLocation is returned in strict mode for a @Param location that Swagger 2.0
+// does not support, e.g. cookie.
+var ErrParamLocation = errors.New("unsupported param location")
+
+// ErrRouterMethod is returned for a @Router method that declares no Swagger 2.0
+// operation: an unknown verb, or CONNECT, which path items have no field for.
+var ErrRouterMethod = errors.New("unsupported HTTP method")
+
 // TypeRegistry provides type lookup functionality
 type TypeRegistry interface {
 	FindTypeSpec(typeName string, file *ast.File) *domain.TypeSpecDef
@@ -24,6 +43,12 @@ type Service struct {
 	codeExampleFilesDir string
 	markdownFileDir     string
 	collectionFormat    string
+	propNamingStrategy  string
+	strict              bool
+	defaultConsumes     []string
+	defaultProduces     []string
+	warnings            *console.Diagnostics
+	types               *typeregistry.Registry
 }
 
 // NewService creates a new route parser service
@@ -43,6 +68,35 @@ func (s *Service) SetMarkdownFileDir(dir string) {
 	s.markdownFileDir = dir
 }
 
+// SetPropNamingStrategy sets the naming strategy for untagged struct fields
+// expanded into formData parameters
+func (s *Service) SetPropNamingStrategy(strategy string) {
+	s.propNamingStrategy = strategy
+}
+
+// SetTypes sets the type mappings used for extended primitives such as time.Time.
+// Without them the built-in mappings are used.
+func (s *Service) SetTypes(types *typeregistry.Registry) {
+	s.types = types
+}
+
+// SetStrict sets whether likely user errors, such as missing markdown files, fail parsing
+func (s *Service) SetStrict(strict bool) {
+	s.strict = strict
+}
+
+// loadMarkdownFile loads an operation description from the markdown files directory
+func (s *Service) loadMarkdownFile(filename string) ([]byte, error) {
+	return domain.ReadMarkdownFile(s.markdownFileDir, filename)
+}
+
+// SetDefaultMimeTypes sets the general-info @Accept and @Produce mime types that
+// operations without their own inherit
+func (s *Service) SetDefaultMimeTypes(consumes, produces []string) {
+	s.defaultConsumes = consumes
+	s.defaultProduces = produces
+}
+
 // SetRegistry sets the registry service for type lookups
 func (s *Service) SetRegistry(registry TypeRegistry) {
 	s.registry = registry
@@ -62,6 +116,35 @@ func (s *Service) resolveTypePath(qualifiedType string, file *ast.File) string {
 	return typeDef.FullPath()
 }
 
+// resolveAliasType documents a type alias (type A = B) as the type it aliases. An alias
+// of a primitive returns the primitive's schema, an alias of a named type the qualified
+// name of that type. Other types are returned unchanged.
+func (s *Service) resolveAliasType(qualifiedType string, file *ast.File) (string, *routedomain.Schema) {
+	if s.registry == nil || strings.Contains(qualifiedType, "[") {
+		return qualifiedType, nil
+	}
+	typeDef := s.registry.FindTypeSpec(qualifiedType, file)
+	if typeDef == nil {
+		return qualifiedType, nil
+	}
+
+	// FindTypeSpec follows aliases of named types, so only aliases of others are left
+	if typeDef.IsAlias() {
+		if ident, ok := typeDef.TypeSpec.Type.(*ast.Ident); ok && domain.IsGolangPrimitiveType(ident.Name) {
+			return qualifiedType, &routedomain.Schema{Type: convertTypeToSchemaType(s.types, ident.Name)}
+		}
+		return qualifiedType, nil
+	}
+
+	// A type of another name is the aliased one, unless the name is a @name override
+	name := qualifiedType[strings.LastIndex(qualifiedType, ".")+1:]
+	if typeDef.Name() != name && typeDef.SchemaName != qualifiedType {
+		return typeDef.SimpleTypeName(), nil
+	}
+
+	return qualifiedType, nil
+}
+
 // ParseRoutes extracts all routes from an AST file.
 // filePath is the source file path and fset is used to resolve line numbers.
 // Both are optional — if provided, routes will include x-path and x-line metadata.
@@ -76,19 +159,25 @@ func (s *Service) ParseRoutes(astFile *ast.File, filePath string, fset *token.Fi
 
 	// Iterate through all declarations in the file
 	for _, decl := range astFile.Decls {
-		// Only process function declarations
+		// Only process function declarations, including methods (handlers on a receiver type)
 		funcDecl, ok := decl.(*ast.FuncDecl)
 		if !ok || funcDecl.Doc == nil {
 			continue
 		}
 
 		// Parse the function's documentation comments with package context
-		operation := s.parseOperation(funcDecl, packageName, filePath, fset)
+		operation, err := s.parseOperation(funcDecl, packageName, filePath, fset)
+		if err != nil {
+			return nil, err
+		}
 		if operation == nil {
 			continue
 		}
 		operation.astFile = astFile
 
+		// Multipart endpoints send struct bodies as individual form fields
+		s.expandMultipartBodyParams(operation)
+
 		// Convert operation to routes (one operation can have multiple routes)
 		operationRoutes := s.operationToRoutes(operation)
 		routes = append(routes, operationRoutes...)
@@ -97,10 +186,61 @@ func (s *Service) ParseRoutes(astFile *ast.File, filePath string, fset *token.Fi
 	return routes, nil
 }
 
-// parseOperation parses a function declaration into an operation
-func (s *Service) parseOperation(funcDecl *ast.FuncDecl, packageName string, filePath string, fset *token.FileSet) *operation {
+// RouterProblem is a @Router or @DeprecatedRouter annotation that ParseRoutes skips
+// because it cannot be parsed, e.g. one without an HTTP method.
+type RouterProblem struct {
+	Line    int
+	Message string
+}
+
+// InvalidRouters returns the router annotations of the functions in astFile that
+// do not declare a route, in source order.
+func (s *Service) InvalidRouters(astFile *ast.File, fset *token.FileSet) []RouterProblem {
+	var problems []RouterProblem
+
+	for _, decl := range astFile.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Doc == nil {
+			continue
+		}
+
+		for _, comment := range funcDecl.Doc.List {
+			commentLine := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(comment.Text), "//"))
+			fields := strings.Fields(commentLine)
+			if len(fields) == 0 {
+				continue
+			}
+
+			attribute := strings.ToLower(fields[0])
+			if attribute != "@router" && attribute != "@deprecatedrouter" {
+				continue
+			}
+
+			err := s.parseRouter(&operation{}, strings.Join(fields[1:], " "), false)
+			if err == nil {
+				continue
+			}
+
+			line := 0
+			if fset != nil {
+				line = fset.Position(comment.Pos()).Line
+			}
+			problems = append(problems, RouterProblem{
+				Line:    line,
+				Message: fmt.Sprintf("%s on %s: %v", fields[0], funcDecl.Name.Name, err),
+			})
+		}
+	}
+
+	return problems
+}
+
+// parseOperation parses a function declaration into an operation.
+// Comments that fail to parse are skipped; only strict mode markdown and param location errors are returned.
+func (s *Service) parseOperation(funcDecl *ast.FuncDecl, packageName string, filePath string, fset *token.FileSet) (*operation, error) {
 	op := &operation{
 		functionName: funcDecl.Name.Name,
+		receiverName: receiverTypeName(funcDecl),
 		packageName:  packageName,
 		filePath:     filePath,
 		routerPaths:  []routerPath{},
@@ -121,6 +261,13 @@ func (s *Service) parseOperation(funcDecl *ast.FuncDecl, packageName string, fil
 	// Parse each comment line
 	for _, comment := range funcDecl.Doc.List {
 		if err := s.parseComment(op, comment.Text); err != nil {
+			if errors.Is(err, ErrMarkdownFile) || errors.Is(err, ErrParamLocation) ||
+				errors.Is(err, schema.ErrCircularCombinedType) {
+				return nil, err
+			}
+			if errors.Is(err, ErrRouterMethod) {
+				s.warnOperation(op, WarningRouterMethod, "%v, ignoring the route", err)
+			}
 			// Skip comments that fail to parse
 			continue
 		}
@@ -128,10 +275,43 @@ func (s *Service) parseOperation(funcDecl *ast.FuncDecl, packageName string, fil
 
 	// Only return if we have at least one router path
 	if len(op.routerPaths) == 0 {
-		return nil
+		return nil, nil
+	}
+
+	if len(op.consumes) == 0 {
+		op.consumes = append(op.consumes, s.defaultConsumes...)
+	}
+	if len(op.produces) == 0 {
+		op.produces = append(op.produces, s.defaultProduces...)
 	}
 
-	return op
+	return op, nil
+}
+
+// receiverTypeName returns the receiver type name of a method, without pointer
+// or type parameters (e.g. "Handler" for func (h *Handler[T]) Get()), or "" for functions.
+func receiverTypeName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return ""
+	}
+
+	expr := funcDecl.Recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
 }
 
 // operationToRoutes converts an operation into one or more routes
@@ -153,7 +333,7 @@ func (s *Service) operationToRoutes(op *operation) []*routedomain.Route {
 			Produces:     op.produces,
 			IsPublic:     op.isPublic,
 			Deprecated:   routerPath.deprecated,
-			OperationID:  op.operationID,
+			OperationID:  operationIDForRouter(op, routerPath),
 			FunctionName: op.functionName,
 			FilePath:     op.filePath,
 			LineNumber:   op.lineNumber,
@@ -164,3 +344,44 @@ func (s *Service) operationToRoutes(op *operation) []*routedomain.Route {
 
 	return routes
 }
+
+// operationIDForRouter returns the operationId for one router of an operation.
+// It is the explicit @ID or defaults to the function name, prefixed with the
+// receiver type for methods (e.g. "UserHandlerGetUser"). When the function
+// declares multiple routers, the HTTP method is appended (e.g.
+// "GetOrCreateUserPost"), followed by the path when several routers share the
+// method (e.g. "ListItemsGetUsersId" for GET /users/{id}).
+func operationIDForRouter(op *operation, routerPath routerPath) string {
+	operationID := op.operationID
+	if operationID == "" {
+		operationID = op.receiverName + op.functionName
+	}
+	if len(op.routerPaths) == 1 {
+		return operationID
+	}
+
+	operationID += capitalize(strings.ToLower(routerPath.method))
+	sameMethod := 0
+	for _, other := range op.routerPaths {
+		if other.method == routerPath.method {
+			sameMethod++
+		}
+	}
+	if sameMethod > 1 {
+		for _, word := range strings.FieldsFunc(routerPath.path, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			operationID += capitalize(word)
+		}
+	}
+	return operationID
+}
+
+// capitalize returns word with its first letter in upper case.
+func capitalize(word string) string {
+	if word == "" {
+		return word
+	}
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
@@ -1,10 +1,17 @@
 package route
 
 import (
+	"errors"
 	goparser "go/parser"
 	"go/token"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/go-openapi/spec"
+	"github.com/griffnb/core-swag/internal/console"
+	"github.com/griffnb/core-swag/internal/parser/route/domain"
+	"github.com/griffnb/core-swag/internal/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -71,6 +78,117 @@ func GetOrCreateUser() {}
 		assert.Equal(t, "/users", routes[1].Path)
 	})
 
+	t.Run("should default operationId to function name", func(t *testing.T) {
+		src := `
+package test
+
+// @Router /users [get]
+func ListUsers() {}
+
+// @ID fetchUser
+// @Router /users/{id} [get]
+func GetUser() {}
+
+// @Router /users [get]
+// @Router /users [post]
+func GetOrCreateUser() {}
+
+// @Router /users [get]
+// @Router /users/{id} [get]
+// @Router /users [delete]
+func ListItems() {}
+
+// @ID listAll
+// @Router /all [get]
+// @Router /all [post]
+func ListAll() {}
+
+// @ID search
+// @Router /search [get]
+// @Router /search/{kind} [get]
+func Search() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 11)
+
+		assert.Equal(t, "ListUsers", routes[0].OperationID)
+		assert.Equal(t, "fetchUser", routes[1].OperationID)
+		assert.Equal(t, "GetOrCreateUserGet", routes[2].OperationID)
+		assert.Equal(t, "GetOrCreateUserPost", routes[3].OperationID)
+		// Routers sharing a method are told apart by their path
+		assert.Equal(t, "ListItemsGetUsers", routes[4].OperationID)
+		assert.Equal(t, "ListItemsGetUsersId", routes[5].OperationID)
+		assert.Equal(t, "ListItemsDelete", routes[6].OperationID)
+		// An explicit @ID on several routers gets the same suffixes
+		assert.Equal(t, "listAllGet", routes[7].OperationID)
+		assert.Equal(t, "listAllPost", routes[8].OperationID)
+		assert.Equal(t, "searchGetSearch", routes[9].OperationID)
+		assert.Equal(t, "searchGetSearchKind", routes[10].OperationID)
+	})
+
+	t.Run("should parse methods on a receiver type", func(t *testing.T) {
+		src := `
+package test
+
+type UserHandler struct{}
+
+type AdminHandler[T any] struct{}
+
+// GetUser returns a user
+// @Summary Get user
+// @Description Returns a single user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} string "OK"
+// @Failure 404 "Not found"
+// @Security ApiKeyAuth
+// @Router /users/{id} [get]
+func (h *UserHandler) GetUser(c *Context) {}
+
+// @Router /admin/users/{id} [get]
+func (h AdminHandler[T]) GetUser() {}
+
+// @ID deleteUser
+// @Router /users/{id} [delete]
+func (h *UserHandler) DeleteUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 3)
+
+		route := routes[0]
+		assert.Equal(t, "GET", route.Method)
+		assert.Equal(t, "/users/{id}", route.Path)
+		assert.Equal(t, "Get user", route.Summary)
+		assert.Equal(t, "Returns a single user", route.Description)
+		assert.Equal(t, []string{"users"}, route.Tags)
+		assert.Equal(t, []string{"application/json"}, route.Consumes)
+		assert.Equal(t, []string{"application/json"}, route.Produces)
+		require.Len(t, route.Parameters, 1)
+		assert.Equal(t, "id", route.Parameters[0].Name)
+		assert.Contains(t, route.Responses, 200)
+		assert.Contains(t, route.Responses, 404)
+		assert.Equal(t, []map[string][]string{{"ApiKeyAuth": {}}}, route.Security)
+		assert.Equal(t, "GetUser", route.FunctionName)
+		assert.Equal(t, "UserHandlerGetUser", route.OperationID)
+
+		assert.Equal(t, "AdminHandlerGetUser", routes[1].OperationID)
+		assert.Equal(t, "deleteUser", routes[2].OperationID)
+	})
+
 	t.Run("should skip functions without router annotation", func(t *testing.T) {
 		src := `
 package test
@@ -87,6 +205,35 @@ func Helper() {}
 		require.NoError(t, err)
 		assert.Empty(t, routes)
 	})
+
+	t.Run("should keep description continuation lines", func(t *testing.T) {
+		src := `
+package test
+
+// @Summary List users
+// @Description Lists the users of the account.
+// Results are ordered by name.
+//
+// Filters:
+//   - active users only
+//   - up to 100 users
+//
+// @Tags users
+// @Router /users [get]
+func ListUsers() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		assert.Equal(t, "Lists the users of the account.\nResults are ordered by name.\n\nFilters:\n  - active users only\n  - up to 100 users", routes[0].Description)
+		assert.Equal(t, []string{"users"}, routes[0].Tags)
+	})
 }
 
 // TestParseRouter tests @router annotation parsing
@@ -149,6 +296,93 @@ func OldEndpoint() {}
 		assert.True(t, routes[0].Deprecated)
 	})
 
+	t.Run("should accept methods case-insensitively", func(t *testing.T) {
+		src := `
+package test
+
+// @Router /users [Get]
+// @Router /users [PATCH]
+// @Router /users [options]
+// @Router /users [hEaD]
+func Users() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 4)
+
+		swagger := &spec.Swagger{}
+		require.NoError(t, service.RegisterRoutes(swagger, routes, false))
+		item := swagger.Paths.Paths["/users"]
+		assert.NotNil(t, item.Get)
+		assert.NotNil(t, item.Patch)
+		assert.NotNil(t, item.Options)
+		assert.NotNil(t, item.Head)
+	})
+
+	t.Run("should register trace routes as an x-trace extension", func(t *testing.T) {
+		src := `
+package test
+
+// @Router /users [get]
+// @Router /users [trace]
+func Users() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+
+		swagger := &spec.Swagger{}
+		require.NoError(t, service.RegisterRoutes(swagger, routes, false))
+		item := swagger.Paths.Paths["/users"]
+		assert.NotNil(t, item.Get)
+		trace := schema.TraceOperation(item)
+		require.NotNil(t, trace)
+		assert.Equal(t, "Users", trace.Extensions["x-function"])
+
+		// A second TRACE route on the same path is a duplicate
+		err = service.RegisterRoutes(swagger, routes[1:], true)
+		assert.ErrorContains(t, err, "route TRACE /users is declared multiple times, already declared by Users")
+	})
+
+	t.Run("should skip methods without a Swagger 2.0 operation", func(t *testing.T) {
+		src := `
+package test
+
+// @Router /users [get]
+// @Router /users [CONNECT]
+// @Router /users [fetch]
+func Users() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		warnings := console.NewDiagnostics()
+		service.SetWarnings(warnings)
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		assert.Equal(t, "GET", routes[0].Method)
+
+		list := warnings.List()
+		require.Len(t, list, 2)
+		assert.Equal(t, WarningRouterMethod, list[0].Category)
+		assert.Equal(t, "Users: unsupported HTTP method: CONNECT, Swagger 2.0 has no CONNECT operations, ignoring the route", list[0].Message)
+		assert.Equal(t, 7, list[0].Line)
+		assert.Equal(t, "Users: unsupported HTTP method: FETCH, ignoring the route", list[1].Message)
+	})
+
 	t.Run("should handle complex paths", func(t *testing.T) {
 		testCases := []struct {
 			name     string
@@ -185,6 +419,34 @@ func Handler() {}
 	})
 }
 
+// TestInvalidRouters tests reporting router annotations that declare no route
+func TestInvalidRouters(t *testing.T) {
+	src := `
+package test
+
+// @Router /users [get]
+func GetUsers() {}
+
+// @Router /users
+func CreateUser() {}
+
+// @DeprecatedRouter /old [fetch]
+func OldEndpoint() {}
+`
+	fset := token.NewFileSet()
+	astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+	require.NoError(t, err)
+
+	service := NewService(nil, "")
+	problems := service.InvalidRouters(astFile, fset)
+	require.Len(t, problems, 2)
+
+	assert.Equal(t, 7, problems[0].Line)
+	assert.Contains(t, problems[0].Message, "@Router on CreateUser")
+	assert.Equal(t, 10, problems[1].Line)
+	assert.Contains(t, problems[1].Message, "unsupported HTTP method: FETCH")
+}
+
 // TestParseParam tests @param annotation parsing
 func TestParseParam(t *testing.T) {
 	t.Run("should parse path parameter", func(t *testing.T) {
@@ -283,20 +545,690 @@ func CreateUser() {}
 		params := routes[0].Parameters
 		require.Len(t, params, 1)
 
-		assert.Equal(t, "user", params[0].Name)
-		assert.Equal(t, "body", params[0].In)
+		assert.Equal(t, "user", params[0].Name)
+		assert.Equal(t, "body", params[0].In)
+	})
+
+	t.Run("should parse bound attributes", func(t *testing.T) {
+		src := `
+package test
+
+// @Param age query int false "age" minimum(0) maximum(150)
+// @Param code query string false "code" minLength(6) maxLength(6)
+// @Param name query string false "name" minimum(1)
+// @Param count query int false "count" maxLength(3)
+// @Param price query int false "price" multipleOf(5)
+// @Param step query float64 false "step" multipleOf(0.5)
+// @Param tag query string false "tag" multipleOf(2)
+// @Param page query int false "page" multipleOf(0)
+// @Router /users [get]
+func GetUsers() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		warnings := console.NewDiagnostics()
+		service.SetWarnings(warnings)
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 8)
+
+		age := ParameterToSpec(params[0])
+		require.NotNil(t, age.Minimum)
+		require.NotNil(t, age.Maximum)
+		assert.Equal(t, 0.0, *age.Minimum)
+		assert.Equal(t, 150.0, *age.Maximum)
+
+		code := ParameterToSpec(params[1])
+		require.NotNil(t, code.MinLength)
+		require.NotNil(t, code.MaxLength)
+		assert.Equal(t, int64(6), *code.MinLength)
+		assert.Equal(t, int64(6), *code.MaxLength)
+
+		// Bounds that don't match the parameter type are dropped with a warning
+		assert.Nil(t, params[2].Minimum)
+		assert.Nil(t, params[3].MaxLength)
+		var messages []string
+		for _, warning := range warnings.List() {
+			assert.Equal(t, WarningParamBound, warning.Category)
+			messages = append(messages, warning.Message)
+		}
+		assert.Equal(t, []string{
+			"GetUsers: @Param name: minimum does not apply to string parameters, ignoring it",
+			"GetUsers: @Param count: maxLength does not apply to integer parameters, ignoring it",
+			"GetUsers: @Param tag: multipleOf does not apply to string parameters, ignoring it",
+		}, messages)
+
+		price := ParameterToSpec(params[4])
+		require.NotNil(t, price.MultipleOf)
+		assert.Equal(t, 5.0, *price.MultipleOf)
+
+		step := ParameterToSpec(params[5])
+		require.NotNil(t, step.MultipleOf)
+		assert.Equal(t, 0.5, *step.MultipleOf)
+
+		assert.Nil(t, params[6].MultipleOf)
+		assert.Nil(t, params[7].MultipleOf, "multipleOf must be positive")
+	})
+
+	t.Run("should parse header and formData params", func(t *testing.T) {
+		src := `
+package test
+
+// @Param Authorization header string true "bearer"
+// @Param avatar formData file true "avatar image"
+// @Param name formdata string false "display name"
+// @Param X-Trace Header string false "trace id"
+// @Router /users [post]
+func CreateUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 4)
+
+		assert.Equal(t, "Authorization", params[0].Name)
+		assert.Equal(t, "header", params[0].In)
+		assert.Equal(t, "string", params[0].Type)
+		assert.True(t, params[0].Required)
+
+		assert.Equal(t, "formData", params[1].In)
+		assert.Equal(t, "file", params[1].Type)
+
+		// Locations are case-insensitive
+		assert.Equal(t, "formData", params[2].In)
+		assert.Equal(t, "header", params[3].In)
+	})
+
+	t.Run("should skip cookie params", func(t *testing.T) {
+		src := `
+package test
+
+// @Param session cookie string true "session id"
+// @Param id path int true "User ID"
+// @Router /users/{id} [get]
+func GetUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		warnings := console.NewDiagnostics()
+		service.SetWarnings(warnings)
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Len(t, routes[0].Parameters, 1)
+		assert.Equal(t, "id", routes[0].Parameters[0].Name)
+
+		got := warnings.List()
+		require.Len(t, got, 1)
+		assert.Equal(t, WarningParamLocation, got[0].Category)
+		assert.Equal(t, "GetUser: @Param session: cookie parameters are not supported by Swagger 2.0, ignoring it", got[0].Message)
+
+		service.SetStrict(true)
+		_, err = service.ParseRoutes(astFile, "test.go", fset)
+		assert.ErrorIs(t, err, ErrParamLocation)
+	})
+
+	t.Run("should skip unknown param locations", func(t *testing.T) {
+		src := `
+package test
+
+// @Param id header2 int true "User ID"
+// @Router /users/{id} [get]
+func GetUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		assert.Empty(t, routes[0].Parameters)
+
+		service.SetStrict(true)
+		_, err = service.ParseRoutes(astFile, "test.go", fset)
+		assert.ErrorIs(t, err, ErrParamLocation)
+	})
+
+	t.Run("should parse array enums and collection format in any order", func(t *testing.T) {
+		src := `
+package test
+
+// @Param roles query []string false "roles" Enums(admin,user) collectionFormat(multi)
+// @Param ids query []int false "ids" collectionFormat(pipes) Enums(1, 2) Format(int64)
+// @Param q query string false "search (text)" Default("a (b)") CollectionFormat(bogus)
+// @Router /users [get]
+func GetUsers() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 3)
+
+		roles := ParameterToSpec(params[0])
+		assert.Equal(t, "array", roles.Type)
+		assert.Equal(t, "multi", roles.CollectionFormat)
+		require.NotNil(t, roles.Items)
+		assert.Equal(t, "string", roles.Items.Type)
+		assert.Equal(t, []interface{}{"admin", "user"}, roles.Items.Enum)
+		assert.Empty(t, roles.Enum)
+
+		ids := ParameterToSpec(params[1])
+		assert.Equal(t, "pipes", ids.CollectionFormat)
+		require.NotNil(t, ids.Items)
+		assert.Equal(t, "int64", ids.Items.Format)
+		assert.Equal(t, []interface{}{1.0, 2.0}, ids.Items.Enum)
+
+		// Parentheses inside quoted values don't end the attribute; unknown formats are dropped
+		assert.Equal(t, "a (b)", params[2].Default)
+		assert.Empty(t, params[2].CollectionFormat)
+	})
+
+	t.Run("should parse single and multi-file upload params", func(t *testing.T) {
+		src := `
+package test
+
+// @Accept mpfd
+// @Param avatar formData file true "avatar"
+// @Param files formData []file true "files"
+// @Param docs formData file false "docs" collectionFormat(multi)
+// @Router /uploads [post]
+func Upload() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 3)
+
+		avatar := ParameterToSpec(params[0])
+		assert.Equal(t, "formData", avatar.In)
+		assert.Equal(t, "file", avatar.Type)
+		assert.Empty(t, avatar.CollectionFormat)
+		assert.Nil(t, avatar.Items)
+
+		for _, param := range params[1:] {
+			files := ParameterToSpec(param)
+			assert.Equal(t, "formData", files.In)
+			assert.Equal(t, "array", files.Type)
+			assert.Equal(t, "multi", files.CollectionFormat)
+			require.NotNil(t, files.Items)
+			assert.Equal(t, "file", files.Items.Type)
+		}
+	})
+
+	t.Run("should map time types to date and date-time strings", func(t *testing.T) {
+		src := `
+package test
+
+import "time"
+
+type DateOnly time.Time
+
+// @Param since query time.Time false "since"
+// @Param on query DateOnly false "on"
+// @Param day query string false "day" Format(date)
+// @Param until query DateOnly false "until" Format(date-time)
+// @Router /events [get]
+func GetEvents() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		service.SetRegistry(&fileTypeRegistry{file: astFile})
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 4)
+
+		for i, format := range []string{"date-time", "date", "date", "date-time"} {
+			assert.Equal(t, "string", params[i].Type, params[i].Name)
+			assert.Equal(t, format, params[i].Format, params[i].Name)
+		}
+	})
+
+	t.Run("should keep escaped quotes in the description", func(t *testing.T) {
+		src := `
+package test
+
+// @Param id path int true "User's \"primary\" id, e.g. C:\path" Minimum(1)
+// @Router /users/{id} [get]
+func GetUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 1)
+
+		assert.Equal(t, "id", params[0].Name)
+		assert.Equal(t, "path", params[0].In)
+		assert.Equal(t, "integer", params[0].Type)
+		assert.True(t, params[0].Required)
+		assert.Equal(t, `User's "primary" id, e.g. C:\path`, params[0].Description)
+		require.NotNil(t, params[0].Minimum)
+		assert.Equal(t, 1.0, *params[0].Minimum)
+	})
+
+	t.Run("should reference a schema for deepObject query params", func(t *testing.T) {
+		src := `
+package test
+
+// @Param filter query object false "filter" schema(FilterStruct) style(deepObject) explode(true)
+// @Param sort query string false "sort" style(form)
+// @Router /users [get]
+func GetUsers() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 2)
+
+		assert.Equal(t, "query", params[0].In)
+		assert.Equal(t, "object", params[0].Type)
+		require.NotNil(t, params[0].Schema)
+		assert.Equal(t, "#/definitions/test.FilterStruct", params[0].Schema.Ref)
+		assert.Equal(t, "deepObject", params[0].Style)
+		require.NotNil(t, params[0].Explode)
+		assert.True(t, *params[0].Explode)
+
+		assert.Equal(t, "string", params[1].Type)
+		assert.Nil(t, params[1].Schema)
+		assert.Equal(t, "form", params[1].Style)
+		assert.Nil(t, params[1].Explode)
+
+		// Swagger 2.0 has no schema on non-body params, so it is kept as x-schema
+		filter := ParameterToSpec(params[0])
+		assert.Nil(t, filter.Schema)
+		assert.Equal(t, "object", filter.Type)
+		xSchema, ok := filter.Extensions["x-schema"].(*spec.Schema)
+		require.True(t, ok)
+		assert.Equal(t, "#/definitions/test.FilterStruct", xSchema.Ref.String())
+		assert.Equal(t, "deepObject", filter.Extensions["x-style"])
+		assert.Equal(t, true, filter.Extensions["x-explode"])
+
+		err = service.parseParam(&operation{functionName: "GetUsers"}, `filter query object false "filter" explode(yes)`)
+		assert.EqualError(t, err, "GetUsers: @Param filter: invalid explode(yes), expected true or false")
+	})
+
+	t.Run("should convert examples to the param type", func(t *testing.T) {
+		src := `
+package test
+
+// @Param id query int false "id" example(42)
+// @Param ratio query number false "ratio" example(0.5)
+// @Param tags query []string false "tags" example(a,b)
+// @Param name query string false "name" example("max")
+// @Param body body Pet true "pet" example({"name":"max"})
+// @Router /users [post]
+func CreateUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 5)
+
+		assert.Equal(t, 42, params[0].Example)
+		assert.Equal(t, 0.5, params[1].Example)
+		assert.Equal(t, []interface{}{"a", "b"}, params[2].Example)
+		assert.Equal(t, "max", params[3].Example)
+		assert.Nil(t, params[4].Example)
+		require.NotNil(t, params[4].Schema)
+		assert.Equal(t, map[string]interface{}{"name": "max"}, params[4].Schema.Example)
+
+		assert.Equal(t, 42, ParameterToSpec(params[0]).Example)
+		assert.Equal(t, map[string]interface{}{"name": "max"}, ParameterToSpec(params[4]).Schema.Example)
+	})
+
+	t.Run("should merge repeated parameters", func(t *testing.T) {
+		src := `
+package test
+
+// @Param id path string true "User ID"
+// @Param limit query int false "Limit results"
+// @Param id path string true "User ID or slug" Enums(me, admin)
+// @Param id query string false "Other id"
+// @Router /users/{id} [get]
+func GetUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		params := routes[0].Parameters
+		require.Len(t, params, 3)
+
+		assert.Equal(t, "id", params[0].Name)
+		assert.Equal(t, "path", params[0].In)
+		assert.Equal(t, "string", params[0].Type)
+		assert.True(t, params[0].Required)
+		assert.Equal(t, "User ID or slug", params[0].Description)
+		assert.Equal(t, []interface{}{"me", "admin"}, params[0].Enum)
+
+		assert.Equal(t, "limit", params[1].Name)
+
+		// Same name in another location is a different parameter
+		assert.Equal(t, "id", params[2].Name)
+		assert.Equal(t, "query", params[2].In)
+		assert.Nil(t, params[2].Enum)
+	})
+}
+
+// TestParseSuccess tests @success annotation parsing
+func TestParseSuccess(t *testing.T) {
+	t.Run("should parse success response with description only", func(t *testing.T) {
+		src := `
+package test
+
+// @Success 200 "OK"
+// @Router /health [get]
+func Health() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		responses := routes[0].Responses
+		require.Contains(t, responses, 200)
+
+		assert.Equal(t, "OK", responses[200].Description)
+		assert.Nil(t, responses[200].Schema)
+	})
+
+	t.Run("should leave no content responses without a schema", func(t *testing.T) {
+		src := `
+package test
+
+// @Success 204 "No Content"
+// @Failure 404,410
+// @Router /users/{id} [delete]
+func DeleteUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		responses := routes[0].Responses
+		require.Contains(t, responses, 204)
+		assert.Equal(t, "No Content", responses[204].Description)
+		assert.Nil(t, responses[204].Schema)
+		assert.Nil(t, ResponseToSpec(responses[204]).Schema)
+
+		assert.Equal(t, "Not Found", responses[404].Description)
+		assert.Equal(t, "Gone", responses[410].Description)
+		assert.Nil(t, responses[410].Schema)
+	})
+
+	t.Run("should parse success response with schema", func(t *testing.T) {
+		src := `
+package test
+
+// @Success 200 {object} string "Success"
+// @Router /users [get]
+func GetUsers() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		responses := routes[0].Responses
+		require.Contains(t, responses, 200)
+
+		assert.Equal(t, "Success", responses[200].Description)
+		assert.NotNil(t, responses[200].Schema)
+	})
+
+	t.Run("should parse success response with array schema", func(t *testing.T) {
+		src := `
+package test
+
+// @Success 200 {array} string "List of users"
+// @Router /users [get]
+func GetUsers() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		responses := routes[0].Responses
+		require.Contains(t, responses, 200)
+
+		assert.NotNil(t, responses[200].Schema)
+	})
+
+	t.Run("should parse multiple status codes", func(t *testing.T) {
+		src := `
+package test
+
+// @Success 200,201,204 "Success"
+// @Router /users [post]
+func CreateUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		responses := routes[0].Responses
+		assert.Contains(t, responses, 200)
+		assert.Contains(t, responses, 201)
+		assert.Contains(t, responses, 204)
+	})
+
+	t.Run("should parse response content type overrides", func(t *testing.T) {
+		src := `
+package test
+
+// @Produce json
+// @Success 200,203 {object} string "Report" produce(text/csv)
+// @Success 202 {file} produce(octet-stream, application/pdf)
+// @Failure 400 "Bad request" PRODUCE(plain)
+// @Failure 500 {object} string "Error"
+// @Router /reports [get]
+func GetReport() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		assert.Equal(t, []string{"application/json"}, routes[0].Produces)
+
+		responses := routes[0].Responses
+		assert.Equal(t, "Report", responses[200].Description)
+		assert.Equal(t, []string{"text/csv"}, responses[200].Produces)
+		assert.Equal(t, []string{"text/csv"}, responses[203].Produces)
+		assert.Equal(t, "file", responses[202].Schema.Type)
+		assert.Equal(t, []string{"application/octet-stream", "application/pdf"}, responses[202].Produces)
+		assert.Equal(t, "Bad request", responses[400].Description)
+		assert.Equal(t, []string{"text/plain"}, responses[400].Produces)
+		assert.Empty(t, responses[500].Produces)
+	})
+}
+
+func TestParseSuccessFileByteResponse(t *testing.T) {
+	t.Run("should parse file response with []byte as file type not a ref", func(t *testing.T) {
+		src := `
+package test
+
+// @Success 200 {file} []byte "File content"
+// @Router /download [get]
+func Download() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		responses := routes[0].Responses
+		require.Contains(t, responses, 200)
+
+		resp := responses[200]
+		assert.Equal(t, "File content", resp.Description)
+		require.NotNil(t, resp.Schema)
+		assert.Equal(t, "file", resp.Schema.Type)
+		assert.Empty(t, resp.Schema.Ref, "[]byte file response should not create a $ref")
+	})
+
+	t.Run("should parse file response without a data type", func(t *testing.T) {
+		src := `
+package test
+
+// @Produce octet-stream
+// @Success 200 {file} "the report"
+// @Failure 404 {file}
+// @Router /report [get]
+func DownloadReport() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		assert.Equal(t, []string{"application/octet-stream"}, routes[0].Produces)
+
+		resp := routes[0].Responses[200]
+		assert.Equal(t, "the report", resp.Description)
+		require.NotNil(t, resp.Schema)
+		assert.Equal(t, "file", resp.Schema.Type)
+		specSchema := ResponseToSpec(resp).Schema
+		require.NotNil(t, specSchema)
+		assert.Equal(t, []string{"file"}, []string(specSchema.Type))
+
+		notFound := routes[0].Responses[404]
+		assert.Equal(t, "OK", notFound.Description)
+		require.NotNil(t, notFound.Schema)
+		assert.Equal(t, "file", notFound.Schema.Type)
+	})
+}
+
+// TestParseFailure tests @failure annotation parsing
+func TestParseFailure(t *testing.T) {
+	t.Run("should parse failure response", func(t *testing.T) {
+		src := `
+package test
+
+// @Failure 400 "Bad Request"
+// @Router /users [post]
+func CreateUser() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		responses := routes[0].Responses
+		require.Contains(t, responses, 400)
+
+		assert.Equal(t, "Bad Request", responses[400].Description)
 	})
-}
 
-// TestParseSuccess tests @success annotation parsing
-func TestParseSuccess(t *testing.T) {
-	t.Run("should parse success response with description only", func(t *testing.T) {
+	t.Run("should parse failure with schema", func(t *testing.T) {
 		src := `
 package test
 
-// @Success 200 "OK"
-// @Router /health [get]
-func Health() {}
+// @Failure 500 {object} string "Internal Server Error"
+// @Router /users [get]
+func GetUsers() {}
 `
 		fset := token.NewFileSet()
 		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
@@ -308,16 +1240,16 @@ func Health() {}
 		require.Len(t, routes, 1)
 
 		responses := routes[0].Responses
-		require.Contains(t, responses, 200)
+		require.Contains(t, responses, 500)
 
-		assert.Equal(t, "OK", responses[200].Description)
+		assert.NotNil(t, responses[500].Schema)
 	})
 
-	t.Run("should parse success response with schema", func(t *testing.T) {
+	t.Run("should parse failure referencing a shared response", func(t *testing.T) {
 		src := `
 package test
 
-// @Success 200 {object} string "Success"
+// @Failure 400,500 {ref} DefaultError
 // @Router /users [get]
 func GetUsers() {}
 `
@@ -331,17 +1263,22 @@ func GetUsers() {}
 		require.Len(t, routes, 1)
 
 		responses := routes[0].Responses
-		require.Contains(t, responses, 200)
+		require.Contains(t, responses, 400)
+		require.Contains(t, responses, 500)
 
-		assert.Equal(t, "Success", responses[200].Description)
-		assert.NotNil(t, responses[200].Schema)
+		assert.Equal(t, "DefaultError", responses[500].Ref)
+		assert.Nil(t, responses[500].Schema)
 	})
 
-	t.Run("should parse success response with array schema", func(t *testing.T) {
+	t.Run("should expand NXX ranges to the common codes of the class", func(t *testing.T) {
 		src := `
 package test
 
-// @Success 200 {array} string "List of users"
+// @Failure 404 {object} NotFound "not found"
+// @Failure 4XX {object} ErrorResponse "client error"
+// @Failure 5xx "server error"
+// @Failure 500 {object} ServerError "internal"
+// @Header 4XX {string} X-Request-ID "request id"
 // @Router /users [get]
 func GetUsers() {}
 `
@@ -355,18 +1292,33 @@ func GetUsers() {}
 		require.Len(t, routes, 1)
 
 		responses := routes[0].Responses
-		require.Contains(t, responses, 200)
+		for _, code := range []int{400, 401, 403} {
+			require.Contains(t, responses, code)
+			assert.Equal(t, "client error", responses[code].Description)
+			assert.Equal(t, "#/definitions/test.ErrorResponse", responses[code].Schema.Ref)
+			assert.Contains(t, responses[code].Headers, "X-Request-ID")
+		}
+		for _, code := range []int{502, 503, 504} {
+			require.Contains(t, responses, code)
+			assert.Equal(t, "server error", responses[code].Description)
+		}
 
-		assert.NotNil(t, responses[200].Schema)
+		// Exact codes win over ranges, declared before or after them
+		assert.Equal(t, "not found", responses[404].Description)
+		assert.Contains(t, responses[404].Headers, "X-Request-ID")
+		assert.Equal(t, "internal", responses[500].Description)
+		assert.Len(t, responses, 8)
 	})
 
-	t.Run("should parse multiple status codes", func(t *testing.T) {
+	t.Run("should parse default response", func(t *testing.T) {
 		src := `
 package test
 
-// @Success 200,201,204 "Success"
-// @Router /users [post]
-func CreateUser() {}
+// @Success 200 {object} string "OK"
+// @Failure default {object} ErrorResponse "unexpected"
+// @Header default {string} X-Request-ID "request id"
+// @Router /users [get]
+func GetUsers() {}
 `
 		fset := token.NewFileSet()
 		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
@@ -378,87 +1330,195 @@ func CreateUser() {}
 		require.Len(t, routes, 1)
 
 		responses := routes[0].Responses
-		assert.Contains(t, responses, 200)
-		assert.Contains(t, responses, 201)
-		assert.Contains(t, responses, 204)
+		require.Contains(t, responses, domain.DefaultResponseCode)
+		assert.Equal(t, "unexpected", responses[domain.DefaultResponseCode].Description)
+		assert.Contains(t, responses[domain.DefaultResponseCode].Headers, "X-Request-ID")
+
+		operation := RouteToSpecOperation(routes[0])
+		require.NotNil(t, operation.Responses.Default)
+		assert.Equal(t, "unexpected", operation.Responses.Default.Description)
+		assert.Equal(t, "#/definitions/test.ErrorResponse", operation.Responses.Default.Schema.Ref.String())
+		assert.NotContains(t, operation.Responses.StatusCodeResponses, domain.DefaultResponseCode)
+		assert.Contains(t, operation.Responses.StatusCodeResponses, 200)
 	})
 }
 
-func TestParseSuccessFileByteResponse(t *testing.T) {
-	t.Run("should parse file response with []byte as file type not a ref", func(t *testing.T) {
+func TestParseStatusCodes(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []statusCode
+		wantErr string
+	}{
+		{input: "200", want: []statusCode{{code: 200}}},
+		{input: "200, default", want: []statusCode{{code: 200}, {code: domain.DefaultResponseCode}}},
+		{input: "5xx", want: []statusCode{{500, true}, {502, true}, {503, true}, {504, true}}},
+		{input: "404,4XX", want: []statusCode{{code: 404}, {400, true}, {401, true}, {403, true}, {404, true}}},
+		{input: "6XX", wantErr: "invalid status code: 6XX"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			codes, err := parseStatusCodes(tt.input)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, codes)
+		})
+	}
+}
+
+// TestParseNamedResponses tests shared @Response declarations in general API info
+func TestParseNamedParameters(t *testing.T) {
+	t.Run("should collect named parameters referenced by routes", func(t *testing.T) {
 		src := `
-package test
+package main
 
-// @Success 200 {file} []byte "File content"
-// @Router /download [get]
-func Download() {}
+// @title Test API
+// @Parameter PageParam query page int false "Page number" default(1)
+func main() {}
+
+// @Param {ref} PageParam
+// @Param {ref} PageParam
+// @Router /users [get]
+func GetUsers() {}
 `
 		fset := token.NewFileSet()
-		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		astFile, err := goparser.ParseFile(fset, "main.go", src, goparser.ParseComments)
 		require.NoError(t, err)
 
 		service := NewService(nil, "")
-		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		params, err := service.ParseNamedParameters(astFile)
+		require.NoError(t, err)
+		require.Len(t, params, 1)
+
+		page := params["PageParam"]
+		assert.Equal(t, "page", page.Name)
+		assert.Equal(t, "query", page.In)
+		assert.Equal(t, "integer", page.Type)
+		assert.Equal(t, "Page number", page.Description)
+		assert.Equal(t, float64(1), page.Default)
+
+		routes, err := service.ParseRoutes(astFile, "main.go", fset)
 		require.NoError(t, err)
 		require.Len(t, routes, 1)
+		require.Len(t, routes[0].Parameters, 1, "repeated refs are merged")
+		ref := ParameterToSpec(routes[0].Parameters[0]).Ref
+		assert.Equal(t, "#/parameters/PageParam", ref.String())
+	})
 
-		responses := routes[0].Responses
-		require.Contains(t, responses, 200)
+	t.Run("should reject incomplete declarations", func(t *testing.T) {
+		src := `
+package main
 
-		resp := responses[200]
-		assert.Equal(t, "File content", resp.Description)
-		require.NotNil(t, resp.Schema)
-		assert.Equal(t, "file", resp.Schema.Type)
-		assert.Empty(t, resp.Schema.Ref, "[]byte file response should not create a $ref")
+// @Parameter PageParam query
+func main() {}
+`
+		astFile, err := goparser.ParseFile(token.NewFileSet(), "main.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		_, err = NewService(nil, "").ParseNamedParameters(astFile)
+		assert.Error(t, err)
 	})
 }
 
-// TestParseFailure tests @failure annotation parsing
-func TestParseFailure(t *testing.T) {
-	t.Run("should parse failure response", func(t *testing.T) {
+func TestParseNamedResponses(t *testing.T) {
+	t.Run("should collect named responses and skip route responses", func(t *testing.T) {
 		src := `
-package test
+package main
 
-// @Failure 400 "Bad Request"
-// @Router /users [post]
-func CreateUser() {}
+// @title Test API
+// @Response DefaultError {object} string "Server error"
+// @Response NotFound "Not found"
+func main() {}
+
+// @Response 200 {object} string "OK"
+// @Router /users [get]
+func GetUsers() {}
 `
 		fset := token.NewFileSet()
-		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		astFile, err := goparser.ParseFile(fset, "main.go", src, goparser.ParseComments)
 		require.NoError(t, err)
 
 		service := NewService(nil, "")
-		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		responses, err := service.ParseNamedResponses(astFile)
 		require.NoError(t, err)
-		require.Len(t, routes, 1)
+		require.Len(t, responses, 2)
 
-		responses := routes[0].Responses
-		require.Contains(t, responses, 400)
-
-		assert.Equal(t, "Bad Request", responses[400].Description)
+		assert.Equal(t, "Server error", responses["DefaultError"].Description)
+		assert.NotNil(t, responses["DefaultError"].Schema)
+		assert.Equal(t, "Not found", responses["NotFound"].Description)
+		assert.Nil(t, responses["NotFound"].Schema)
 	})
 
-	t.Run("should parse failure with schema", func(t *testing.T) {
+	t.Run("should attach named headers to the shared response", func(t *testing.T) {
 		src := `
-package test
+package main
 
-// @Failure 500 {object} string "Internal Server Error"
+// @title Test API
+// @Header DefaultError {integer} X-RateLimit-Limit "Requests per hour"
+// @Response DefaultError {object} string "Server error"
+// @Header DefaultError {integer} X-RateLimit-Remaining "Requests left"
+func main() {}
+
+// @Failure 500 {ref} DefaultError
 // @Router /users [get]
 func GetUsers() {}
 `
 		fset := token.NewFileSet()
-		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		astFile, err := goparser.ParseFile(fset, "main.go", src, goparser.ParseComments)
 		require.NoError(t, err)
 
 		service := NewService(nil, "")
-		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		responses, err := service.ParseNamedResponses(astFile)
+		require.NoError(t, err)
+		require.Len(t, responses, 1)
+
+		shared := ResponseToSpec(responses["DefaultError"])
+		assert.Equal(t, "Server error", shared.Description)
+		require.Len(t, shared.Headers, 2)
+		assert.Equal(t, "integer", shared.Headers["X-RateLimit-Limit"].Type)
+		assert.Equal(t, "Requests left", shared.Headers["X-RateLimit-Remaining"].Description)
+
+		routes, err := service.ParseRoutes(astFile, "main.go", fset)
 		require.NoError(t, err)
 		require.Len(t, routes, 1)
+		failure := ResponseToSpec(routes[0].Responses[500])
+		assert.Equal(t, "#/responses/DefaultError", failure.Ref.String())
+		assert.Empty(t, failure.Headers, "headers live on the shared response only")
+	})
 
-		responses := routes[0].Responses
-		require.Contains(t, responses, 500)
+	t.Run("should reject headers of undeclared shared responses", func(t *testing.T) {
+		src := `
+package main
 
-		assert.NotNil(t, responses[500].Schema)
+// @Header RateLimited {integer} Retry-After "Seconds to wait"
+func main() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "main.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		_, err = service.ParseNamedResponses(astFile)
+		assert.EqualError(t, err, "@Header RateLimited: no @Response RateLimited declared")
+	})
+
+	t.Run("should reject a shared response referencing another", func(t *testing.T) {
+		src := `
+package main
+
+// @Response DefaultError {ref} Other
+func main() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "main.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		_, err = service.ParseNamedResponses(astFile)
+		assert.Error(t, err)
 	})
 }
 
@@ -561,6 +1621,156 @@ func CreateUser() {}
 		assert.Contains(t, responses[200].Headers, "X-Request-Id")
 		assert.Contains(t, responses[400].Headers, "X-Request-Id")
 	})
+
+	t.Run("should parse primitive header types and formats", func(t *testing.T) {
+		src := `
+package test
+
+// @Success 200 "OK"
+// @Header 200 {integer} X-RateLimit-Remaining "remaining"
+// @Header 200 {int64} X-Total-Count "total"
+// @Header 200 {number} X-Score "score"
+// @Header 200 {float32} X-Ratio "ratio"
+// @Header 200 {boolean} X-Cached "cached"
+// @Header 200 {time.Time} X-Expires "expiry"
+// @Router /users [get]
+func GetUsers() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		headers := routes[0].Responses[200].Headers
+		tests := []struct {
+			name       string
+			wantType   string
+			wantFormat string
+		}{
+			{"X-RateLimit-Remaining", "integer", ""},
+			{"X-Total-Count", "integer", "int64"},
+			{"X-Score", "number", ""},
+			{"X-Ratio", "number", "float"},
+			{"X-Cached", "boolean", ""},
+			{"X-Expires", "string", "date-time"},
+		}
+		for _, tt := range tests {
+			require.Contains(t, headers, tt.name)
+			assert.Equal(t, tt.wantType, headers[tt.name].Type, tt.name)
+			assert.Equal(t, tt.wantFormat, headers[tt.name].Format, tt.name)
+			assert.Nil(t, headers[tt.name].Items, tt.name)
+		}
+		assert.Equal(t, "remaining", headers["X-RateLimit-Remaining"].Description)
+	})
+
+	t.Run("should parse array headers", func(t *testing.T) {
+		src := `
+package test
+
+// @Success 200 "OK"
+// @Header 200 {array} X-Foo "csv"
+// @Header 200 {[]int64} X-Ids "ids"
+// @Router /users [get]
+func GetUsers() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		headers := routes[0].Responses[200].Headers
+		require.Contains(t, headers, "X-Foo")
+		foo := HeaderToSpec(headers["X-Foo"])
+		assert.Equal(t, "array", foo.Type)
+		assert.Equal(t, "csv", foo.Description)
+		require.NotNil(t, foo.Items)
+		assert.Equal(t, "string", foo.Items.Type)
+
+		require.Contains(t, headers, "X-Ids")
+		ids := HeaderToSpec(headers["X-Ids"])
+		assert.Equal(t, "array", ids.Type)
+		require.NotNil(t, ids.Items)
+		assert.Equal(t, "integer", ids.Items.Type)
+		assert.Equal(t, "int64", ids.Items.Format)
+	})
+}
+
+// TestParseDescriptionMarkdown tests loading operation descriptions from markdown files
+func TestParseDescriptionMarkdown(t *testing.T) {
+	src := `
+package test
+
+// @Summary List users
+// @Description.markdown users
+// @Router /users [get]
+func ListUsers() {}
+
+// @Summary Get a user
+// @Description.markdown
+// @Router /users/{id} [get]
+func GetUser() {}
+`
+
+	warnings := console.NewDiagnostics()
+	parse := func(t *testing.T, dir string, strict bool) ([]string, error) {
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		warnings.Reset()
+		service := NewService(nil, "")
+		service.SetMarkdownFileDir(dir)
+		service.SetStrict(strict)
+		service.SetWarnings(warnings)
+
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		if err != nil {
+			return nil, err
+		}
+
+		var descriptions []string
+		for _, route := range routes {
+			descriptions = append(descriptions, route.Description)
+		}
+		return descriptions, nil
+	}
+
+	t.Run("should load named file and fall back to function name", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "users.md"), []byte("All users"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "GetUser.md"), []byte("One user"), 0o600))
+
+		descriptions, err := parse(t, dir, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"All users", "One user"}, descriptions)
+	})
+
+	t.Run("should skip missing file with a warning when not strict", func(t *testing.T) {
+		descriptions, err := parse(t, t.TempDir(), false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", ""}, descriptions)
+
+		got := warnings.List()
+		require.Len(t, got, 2)
+		assert.Equal(t, WarningMarkdownFile, got[0].Category)
+		assert.Equal(t, "test.go", got[0].File)
+		assert.Equal(t, 7, got[0].Line)
+		assert.Contains(t, got[0].Message, "ListUsers: ")
+	})
+
+	t.Run("should error on missing file in strict mode", func(t *testing.T) {
+		_, err := parse(t, t.TempDir(), true)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrMarkdownFile))
+	})
 }
 
 // TestParseSecurityComment tests security annotation parsing
@@ -666,6 +1876,98 @@ func GetUser() {}
 	assert.Contains(t, route.Produces, "application/json")
 }
 
+// TestMimeTypeAliases tests that @Accept and @Produce expand shorthand aliases
+// and pass unknown tokens through verbatim
+func TestMimeTypeAliases(t *testing.T) {
+	src := `
+package test
+
+// Upload stores a file
+// @Accept mpfd, x-www-form-urlencoded, application/vnd.custom+json
+// @Produce octet-stream, json-api, xml, plain, html, png, jpeg, gif, event-stream, json-stream
+// @Success 200 {string} string "OK"
+// @Router /files [post]
+func Upload() {}
+`
+	fset := token.NewFileSet()
+	astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+	require.NoError(t, err)
+
+	service := NewService(nil, "")
+	routes, err := service.ParseRoutes(astFile, "test.go", fset)
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+
+	assert.Equal(t, []string{
+		"multipart/form-data",
+		"application/x-www-form-urlencoded",
+		"application/vnd.custom+json",
+	}, routes[0].Consumes)
+	assert.Equal(t, []string{
+		"application/octet-stream",
+		"application/vnd.api+json",
+		"text/xml",
+		"text/plain",
+		"text/html",
+		"image/png",
+		"image/jpeg",
+		"image/gif",
+		"text/event-stream",
+		"application/x-json-stream",
+	}, routes[0].Produces)
+}
+
+func TestDefaultMimeTypes(t *testing.T) {
+	src := `
+package test
+
+type UploadRequest struct {
+	Title string ` + "`form:\"title\"`" + `
+}
+
+// @Success 200 {string} string "OK"
+// @Router /inherits [get]
+func Inherits() {}
+
+// @Accept xml
+// @Produce plain
+// @Success 200 {string} string "OK"
+// @Router /overrides [get]
+func Overrides() {}
+
+// @Param request body UploadRequest true "Upload"
+// @Router /uploads [post]
+func Upload() {}
+`
+	fset := token.NewFileSet()
+	astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+	require.NoError(t, err)
+
+	service := NewService(nil, "")
+	service.SetRegistry(&fileTypeRegistry{file: astFile})
+	service.SetDefaultMimeTypes([]string{"multipart/form-data"}, []string{"application/json"})
+
+	routes, err := service.ParseRoutes(astFile, "test.go", fset)
+	require.NoError(t, err)
+	require.Len(t, routes, 3)
+
+	t.Run("should inherit the general-info mime types", func(t *testing.T) {
+		assert.Equal(t, []string{"multipart/form-data"}, routes[0].Consumes)
+		assert.Equal(t, []string{"application/json"}, routes[0].Produces)
+	})
+
+	t.Run("should prefer the operation's own mime types", func(t *testing.T) {
+		assert.Equal(t, []string{"text/xml"}, routes[1].Consumes)
+		assert.Equal(t, []string{"text/plain"}, routes[1].Produces)
+	})
+
+	t.Run("should expand multipart bodies accepted by default", func(t *testing.T) {
+		require.Len(t, routes[2].Parameters, 1)
+		assert.Equal(t, "formData", routes[2].Parameters[0].In)
+		assert.Equal(t, "title", routes[2].Parameters[0].Name)
+	})
+}
+
 // TestPublicAnnotationWithResponses tests @Public annotation affects response schema refs
 func TestPublicAnnotationWithResponses(t *testing.T) {
 	t.Run("should use Public variant for model references when @Public is set", func(t *testing.T) {
@@ -856,6 +2158,25 @@ func GetAccount() {}
 		assert.Contains(t, schema.AllOf[1].Properties, "data")
 	})
 
+	t.Run("should reject a self-referential combined type Node{child=Node}", func(t *testing.T) {
+		src := `
+package test
+
+// @Success 200 {object} Node{child=Node} "Tree"
+// @Router /tree [get]
+func GetTree() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		_, err = service.ParseRoutes(astFile, "test.go", fset)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, schema.ErrCircularCombinedType)
+		assert.Contains(t, err.Error(), "GetTree")
+	})
+
 	t.Run("should build AllOf with array field Response{data=[]Account}", func(t *testing.T) {
 		src := `
 package test
@@ -958,6 +2279,46 @@ func GetPublicAccount() {}
 		assert.Contains(t, dataSchema.Ref, "AccountPublic")
 	})
 
+	t.Run("should build AllOf for body param Request{data=Account}", func(t *testing.T) {
+		src := `
+package test
+
+// @Param request body Request{data=Account} true "Account envelope"
+// @Router /account [put]
+func UpdateAccount() {}
+
+// @Public
+// @Param request body Request{data=Account} true "Account envelope"
+// @Router /public/account [put]
+func UpdatePublicAccount() {}
+`
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, "test.go", src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		service := NewService(nil, "")
+		routes, err := service.ParseRoutes(astFile, "test.go", fset)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+
+		for _, route := range routes {
+			require.Len(t, route.Parameters, 1)
+			param := route.Parameters[0]
+			assert.Equal(t, "body", param.In)
+
+			schema := param.Schema
+			require.NotNil(t, schema)
+			assert.Empty(t, schema.Type)
+			assert.Nil(t, schema.Properties)
+			require.Len(t, schema.AllOf, 2)
+			assert.Equal(t, "#/definitions/test.Request", schema.AllOf[0].Ref)
+			require.Contains(t, schema.AllOf[1].Properties, "data")
+		}
+
+		assert.Equal(t, "#/definitions/test.Account", routes[0].Parameters[0].Schema.AllOf[1].Properties["data"].Ref)
+		assert.Equal(t, "#/definitions/test.AccountPublic", routes[1].Parameters[0].Schema.AllOf[1].Properties["data"].Ref)
+	})
+
 	t.Run("should build AllOf with qualified types Response{data=model.Account}", func(t *testing.T) {
 		src := `
 package test
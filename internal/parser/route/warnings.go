@@ -0,0 +1,37 @@
+package route
+
+import (
+	"fmt"
+
+	"github.com/griffnb/core-swag/internal/console"
+)
+
+// Warning categories reported while parsing and registering routes.
+const (
+	WarningMarkdownFile         = "missing-markdown-file"
+	WarningParamLocation        = "unsupported-param-location"
+	WarningParamBound           = "inapplicable-param-bound"
+	WarningDuplicateRoute       = "duplicate-route"
+	WarningUnknownSecurityScope = "unknown-security-scope"
+	WarningRouterMethod         = "unsupported-method"
+)
+
+// SetWarnings sets the collector that route parsing reports warnings to; nil only logs.
+func (s *Service) SetWarnings(warnings *console.Diagnostics) {
+	s.warnings = warnings
+}
+
+// warnOperation records a warning at the operation's function.
+func (s *Service) warnOperation(op *operation, category, format string, args ...any) {
+	s.warn(category, op.filePath, op.lineNumber, "%s: %s", op.functionName, fmt.Sprintf(format, args...))
+}
+
+// warn records a warning at a source location.
+func (s *Service) warn(category, file string, line int, format string, args ...any) {
+	s.warnings.Add(console.Diagnostic{
+		Category: category,
+		Message:  fmt.Sprintf(format, args...),
+		File:     file,
+		Line:     line,
+	})
+}
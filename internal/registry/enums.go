@@ -152,10 +152,8 @@ func (s *Service) collectConstEnums(parsedSchemas map[*domain.TypeSpecDef]*domai
 				continue
 			}
 
-			// EnumValue has unexported key field, so we cannot set it directly
-			// We need to use a workaround or export the field in swag package
-			// For now, skip setting the key field
 			enumValue := domain.EnumValue{
+				Key:     constVar.VariableName(),
 				Value:   constVar.Value,
 				Comment: commentWithoutNameOverride(constVar.Comment),
 			}
@@ -21,6 +21,7 @@ type Service struct {
 	files             map[*ast.File]*domain.AstFileInfo
 	packages          map[string]*domain.PackageDefinitions
 	uniqueDefinitions map[string]*domain.TypeSpecDef
+	ignoredTypes      []*domain.TypeSpecDef
 	parseDependency   domain.ParseFlag
 	packagePrefixes   []string
 	debug             Debugger
@@ -3,6 +3,7 @@ package registry
 import (
 	"go/parser"
 	"go/token"
+	"reflect"
 	"testing"
 
 	"github.com/griffnb/core-swag/internal/domain"
@@ -252,6 +253,54 @@ type User struct {
 			t.Error("expected at least one unique definition")
 		}
 	})
+
+	t.Run("skips @SwaggerIgnore types", func(t *testing.T) {
+		// Arrange
+		svc := NewService()
+		src := `package test
+
+type User struct {
+	Name string
+}
+
+// internalState is never exposed.
+// @SwaggerIgnore
+type internalState struct {
+	Counter int
+}
+
+type (
+	// swaggerignore
+	Secret string
+	Public string
+)
+`
+		_ = svc.ParseFile("github.com/test/pkg", "test.go", src, domain.ParseAll)
+
+		// Act
+		_, err := svc.ParseTypes()
+
+		// Assert
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		defs := svc.UniqueDefinitions()
+		for _, name := range []string{"test.internalState", "test.Secret"} {
+			if _, ok := defs[name]; ok {
+				t.Errorf("expected %s not to be registered", name)
+			}
+		}
+		for _, name := range []string{"test.User", "test.Public"} {
+			if _, ok := defs[name]; !ok {
+				t.Errorf("expected %s to be registered", name)
+			}
+		}
+
+		ignored := svc.IgnoredTypes()
+		if len(ignored) != 2 || ignored[0].Name() != "internalState" || ignored[1].Name() != "Secret" {
+			t.Errorf("expected internalState and Secret to be ignored, got %v", ignored)
+		}
+	})
 }
 
 func TestService_FindTypeSpec(t *testing.T) {
@@ -279,6 +328,36 @@ type User struct {
 		}
 	})
 
+	t.Run("resolves type aliases to the aliased type", func(t *testing.T) {
+		// Arrange
+		svc := NewService()
+		src := `package test
+type Profile struct {
+	Bio string
+}
+type Account = Profile
+type Owner = Account
+type Name = string`
+		_ = svc.ParseFile("github.com/test/pkg", "test.go", src, domain.ParseAll)
+		_, _ = svc.ParseTypes()
+
+		// Act
+		fset := token.NewFileSet()
+		astFile, _ := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+
+		// Assert
+		for _, alias := range []string{"Account", "Owner"} {
+			typeDef := svc.FindTypeSpec(alias, astFile)
+			if typeDef == nil || typeDef.Name() != "Profile" {
+				t.Errorf("expected %s to resolve to Profile, got %v", alias, typeDef)
+			}
+		}
+		typeDef := svc.FindTypeSpec("Name", astFile)
+		if typeDef == nil || !typeDef.IsAlias() || typeDef.Name() != "Name" {
+			t.Errorf("expected the primitive alias Name to resolve to itself, got %v", typeDef)
+		}
+	})
+
 	t.Run("returns nil for primitive types", func(t *testing.T) {
 		// Arrange
 		svc := NewService()
@@ -418,3 +497,74 @@ type User struct {
 		}
 	})
 }
+
+func TestService_ParseTypes_IotaOffsetEnum(t *testing.T) {
+	// Arrange
+	svc := NewService()
+	src := `package test
+
+type Role int
+
+const (
+	RoleAdmin Role = iota + 100
+	RoleUser
+	RoleGuest
+)
+
+const (
+	Shifted Role = (iota + 1) * 10
+	ShiftedNext
+)
+`
+	if err := svc.ParseFile("github.com/test/pkg", "test.go", src, domain.ParseAll); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	if _, err := svc.ParseTypes(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	pkg := svc.Packages()["github.com/test/pkg"]
+	if pkg == nil {
+		t.Fatal("expected package to be registered")
+	}
+
+	want := map[string]int{
+		"RoleAdmin":   100,
+		"RoleUser":    101,
+		"RoleGuest":   102,
+		"Shifted":     10,
+		"ShiftedNext": 20,
+	}
+	for name, expected := range want {
+		cv, ok := pkg.ConstTable[name]
+		if !ok {
+			t.Fatalf("expected const %s to be collected", name)
+		}
+		value, _ := svc.EvaluateConstValue(pkg, cv, nil)
+		if value != expected {
+			t.Errorf("expected %s = %d, got %v", name, expected, value)
+		}
+	}
+
+	typeDef := pkg.TypeDefinitions["Role"]
+	if typeDef == nil {
+		t.Fatal("expected Role type definition")
+	}
+	var values []interface{}
+	var keys []string
+	for _, enum := range typeDef.Enums {
+		values = append(values, enum.Value)
+		keys = append(keys, enum.Key)
+	}
+	wantValues := []interface{}{100, 101, 102, 10, 20}
+	if !reflect.DeepEqual(values, wantValues) {
+		t.Errorf("expected enum values %v, got %v", wantValues, values)
+	}
+	wantKeys := []string{"RoleAdmin", "RoleUser", "RoleGuest", "Shifted", "ShiftedNext"}
+	if !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("expected enum keys %v, got %v", wantKeys, keys)
+	}
+}
@@ -41,6 +41,12 @@ func (s *Service) parseTypesFromFile(astFile *ast.File, packagePath string, pars
 						ParentSpec: astDeclaration,
 					}
 
+					// @SwaggerIgnore types are never registered, only remembered so refs to them can be dropped
+					if hasSwaggerIgnore(typeSpec.Doc) || (len(generalDeclaration.Specs) == 1 && hasSwaggerIgnore(generalDeclaration.Doc)) {
+						s.ignoredTypes = append(s.ignoredTypes, typeSpecDef)
+						continue
+					}
+
 					if idt, ok := typeSpec.Type.(*ast.Ident); ok && domain.IsGolangPrimitiveType(idt.Name) && parsedSchemas != nil {
 						parsedSchemas[typeSpecDef] = &domain.Schema{
 							PkgPath: typeSpecDef.PkgPath,
@@ -94,6 +100,30 @@ func (s *Service) parseTypesFromFile(astFile *ast.File, packagePath string, pars
 	}
 }
 
+// hasSwaggerIgnore reports whether a type's doc comment contains the
+// @SwaggerIgnore annotation (or swaggerignore, as used in field tags).
+func hasSwaggerIgnore(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToLower(fields[0]) {
+		case "@swaggerignore", "swaggerignore", "swaggerignore:\"true\"":
+			return true
+		}
+	}
+	return false
+}
+
+// IgnoredTypes returns the types excluded from the spec with @SwaggerIgnore.
+func (s *Service) IgnoredTypes() []*domain.TypeSpecDef {
+	return s.ignoredTypes
+}
+
 func (s *Service) parseFunctionScopedTypesFromFile(astFile *ast.File, packagePath string, parsedSchemas map[*domain.TypeSpecDef]*domain.Schema) {
 	for _, astDeclaration := range astFile.Decls {
 		funcDeclaration, ok := astDeclaration.(*ast.FuncDecl)
@@ -192,8 +222,44 @@ func (s *Service) removeAllNotUniqueTypes() {
 	}
 }
 
-// FindTypeSpec finds TypeSpecDef by type name.
+// FindTypeSpec finds TypeSpecDef by type name. Type aliases (type A = B) resolve to
+// the TypeSpecDef of the type they alias, so they share its definition; aliases of
+// primitives and unnamed types, which have none, resolve to themselves.
 func (s *Service) FindTypeSpec(typeName string, file *ast.File) *domain.TypeSpecDef {
+	return s.resolveAlias(s.findTypeSpecByName(typeName, file))
+}
+
+// resolveAlias follows a chain of type aliases to the first TypeSpecDef that isn't an
+// alias of a named type.
+func (s *Service) resolveAlias(typeDef *domain.TypeSpecDef) *domain.TypeSpecDef {
+	seen := make(map[*domain.TypeSpecDef]bool)
+	for typeDef != nil && typeDef.IsAlias() && !seen[typeDef] {
+		seen[typeDef] = true
+
+		var target string
+		switch expr := typeDef.TypeSpec.Type.(type) {
+		case *ast.Ident:
+			target = expr.Name
+		case *ast.SelectorExpr:
+			if pkg, ok := expr.X.(*ast.Ident); ok {
+				target = pkg.Name + "." + expr.Sel.Name
+			}
+		}
+		if target == "" || domain.IsGolangPrimitiveType(target) {
+			return typeDef
+		}
+
+		targetDef := s.findTypeSpecByName(target, typeDef.File)
+		if targetDef == nil {
+			return typeDef
+		}
+		typeDef = targetDef
+	}
+
+	return typeDef
+}
+
+func (s *Service) findTypeSpecByName(typeName string, file *ast.File) *domain.TypeSpecDef {
 	if domain.IsGolangPrimitiveType(typeName) {
 		return nil
 	}
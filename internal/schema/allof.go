@@ -1,12 +1,21 @@
 package schema
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-openapi/spec"
 )
 
+// ErrCircularCombinedType is returned when expanding a combined type re-enters
+// one of its enclosing combined types, e.g. "Node{child=Node}" or "A{b=B{a=A}}",
+// where the inner plain type stands for the combined type being expanded.
+// Nesting a new composition of the same base, e.g. "Node{child=Node{child=Leaf}}",
+// is finite and allowed.
+var ErrCircularCombinedType = errors.New("circular combined type")
+
 // ParseCombinedType is the exported version of parseCombinedType
 func ParseCombinedType(refType string) (string, map[string]string, error) {
 	return parseCombinedType(refType)
@@ -147,9 +156,83 @@ func parseCombinedType(refType string) (string, map[string]string, error) {
 		return "", nil, err
 	}
 
+	// Reject compositions that would loop during materialization
+	if err := checkCombinedTypeCycle(baseType, overrides, nil); err != nil {
+		return "", nil, err
+	}
+
 	return baseType, overrides, nil
 }
 
+// checkCombinedTypeCycle walks nested combined types and returns
+// ErrCircularCombinedType if a plain override type re-enters an enclosing
+// combined type. ancestors holds the chain of base types from the outermost
+// type inward.
+func checkCombinedTypeCycle(baseType string, overrides map[string]string, ancestors []string) error {
+	chain := append(append([]string{}, ancestors...), baseType)
+
+	// Sort field names so the reported path is deterministic
+	fieldNames := make([]string, 0, len(overrides))
+	for fieldName := range overrides {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		fieldType := stripTypeModifiers(overrides[fieldName])
+
+		nestedBase := fieldType
+		openIdx := strings.Index(fieldType, "{")
+		if openIdx != -1 {
+			nestedBase = fieldType[:openIdx]
+		}
+
+		// A nested composition is a new combined type, finite by construction
+		if openIdx != -1 && strings.HasSuffix(fieldType, "}") {
+			nestedOverrides, err := parseFieldOverrides(fieldType[openIdx+1 : len(fieldType)-1])
+			if err != nil {
+				return err
+			}
+			if err := checkCombinedTypeCycle(nestedBase, nestedOverrides, chain); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// A plain type naming an enclosing base re-enters that combined type
+		for _, ancestor := range chain {
+			if ancestor == nestedBase {
+				return fmt.Errorf("%w: %s references %s through field %q",
+					ErrCircularCombinedType, strings.Join(chain, " -> "), nestedBase, fieldName)
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// stripTypeModifiers removes leading slice, pointer and map prefixes so the
+// element type can be compared, e.g. "[]*map[string]Account" → "Account".
+func stripTypeModifiers(typeName string) string {
+	for {
+		switch {
+		case strings.HasPrefix(typeName, "[]"):
+			typeName = typeName[2:]
+		case strings.HasPrefix(typeName, "*"):
+			typeName = typeName[1:]
+		case strings.HasPrefix(typeName, "map["):
+			closeIdx := strings.Index(typeName, "]")
+			if closeIdx == -1 {
+				return typeName
+			}
+			typeName = typeName[closeIdx+1:]
+		default:
+			return strings.TrimSpace(typeName)
+		}
+	}
+}
+
 // shouldUseAllOf determines if AllOf composition is needed.
 // Returns false if no overrides or if base can be merged directly.
 //
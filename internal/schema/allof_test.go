@@ -219,6 +219,44 @@ func TestParseCombinedType_EmptyString(t *testing.T) {
 	assert.Contains(t, err.Error(), "empty type")
 }
 
+func TestParseCombinedType_SelfReferentialRejected(t *testing.T) {
+	// Override that points back at its own base type should be rejected
+	_, _, err := parseCombinedType("Node{child=Node}")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCircularCombinedType)
+	assert.Contains(t, err.Error(), `Node references Node through field "child"`)
+}
+
+func TestParseCombinedType_MutuallyRecursiveRejected(t *testing.T) {
+	// A{b=B{a=A}} loops through the nested combined type
+	_, _, err := parseCombinedType("A{b=B{a=[]A}}")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCircularCombinedType)
+	assert.Contains(t, err.Error(), `A -> B references A through field "a"`)
+}
+
+func TestParseCombinedType_NestedCompositionAllowed(t *testing.T) {
+	// A nested composition of the same base is a new, finite combined type
+	base, overrides, err := parseCombinedType("Node{child=Node{child=Leaf}}")
+	require.NoError(t, err)
+	assert.Equal(t, "Node", base)
+	assert.Equal(t, map[string]string{"child": "Node{child=Leaf}"}, overrides)
+
+	_, _, err = parseCombinedType("A{b=B{a=[]A{c=C}}}")
+	require.NoError(t, err)
+
+	// The innermost plain type still re-enters its own composition
+	_, _, err = parseCombinedType("Node{child=Node{child=Node}}")
+	assert.ErrorIs(t, err, ErrCircularCombinedType)
+}
+
+func TestParseCombinedType_RepeatedSiblingTypesAllowed(t *testing.T) {
+	// The same type used in sibling overrides is not a cycle
+	_, overrides, err := parseCombinedType("Response{data=Inner{field=Account},meta=Inner{field=Account}}")
+	require.NoError(t, err)
+	assert.Len(t, overrides, 2)
+}
+
 // ============================================================================
 // Test shouldUseAllOf - Determining if AllOf composition is needed
 // ============================================================================
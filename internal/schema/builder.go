@@ -3,12 +3,12 @@ package schema
 
 import (
 	"go/ast"
-	"strings"
-	"unicode"
+	"sync"
 
 	"github.com/go-openapi/spec"
 	"github.com/griffnb/core-swag/internal/domain"
 	"github.com/griffnb/core-swag/internal/model"
+	"github.com/griffnb/core-swag/internal/schemautil"
 )
 
 // TypeResolver provides type lookup functionality.
@@ -17,7 +17,9 @@ type TypeResolver interface {
 }
 
 // BuilderService handles schema construction and definition management.
+// It is safe for concurrent use.
 type BuilderService struct {
+	mu                 sync.RWMutex // guards definitions and parsedSchemas
 	definitions        map[string]spec.Schema
 	parsedSchemas      map[*domain.TypeSpecDef]string
 	propNamingStrategy string // camelcase, snakecase, pascalcase, or empty (default camelcase)
@@ -71,14 +73,20 @@ func (b *BuilderService) BuildSchema(typeSpec *domain.TypeSpecDef) (string, erro
 	}
 
 	// Check if already parsed
-	if existingName, ok := b.parsedSchemas[typeSpec]; ok {
+	b.mu.RLock()
+	existingName, parsed := b.parsedSchemas[typeSpec]
+	_, exists := b.definitions[schemaName]
+	b.mu.RUnlock()
+	if parsed {
 		return existingName, nil
 	}
 
 	// Check if schema already exists in definitions (may have been built by StructParser)
-	if _, exists := b.definitions[schemaName]; exists {
+	if exists {
 		// Schema already exists - mark as parsed and return
+		b.mu.Lock()
 		b.parsedSchemas[typeSpec] = schemaName
+		b.mu.Unlock()
 		return schemaName, nil
 	}
 
@@ -113,7 +121,9 @@ func (b *BuilderService) BuildSchema(typeSpec *domain.TypeSpecDef) (string, erro
 		}
 
 		// Use StructBuilder.BuildSpecSchema() to generate schema with proper type references
-		builtSchema, _, err := builder.BuildSpecSchema(typeName, false, b.requiredByDefault, b.enumLookup)
+		opts := b.structParser.Options()
+		opts.RequiredByDefault = b.requiredByDefault
+		builtSchema, _, err := builder.BuildSpecSchema(typeName, false, opts, b.enumLookup)
 		if err != nil || builtSchema == nil {
 			// Schema building failed - return empty object
 			schema.Properties = make(map[string]spec.Schema)
@@ -144,9 +154,15 @@ func (b *BuilderService) BuildSchema(typeSpec *domain.TypeSpecDef) (string, erro
 				// Build enum value list
 				enumList := make([]any, len(enumValues))
 				varNames := make([]string, len(enumValues))
+				descriptions := make([]string, len(enumValues))
+				comments := make(map[string]string)
 				for i, ev := range enumValues {
 					enumList[i] = ev.Value
 					varNames[i] = ev.Key
+					descriptions[i] = ev.Comment
+					if ev.Comment != "" {
+						comments[ev.Key] = ev.Comment
+					}
 				}
 
 				schema = spec.Schema{
@@ -154,12 +170,19 @@ func (b *BuilderService) BuildSchema(typeSpec *domain.TypeSpecDef) (string, erro
 						Type: []string{underlyingType},
 						Enum: enumList,
 					},
+					SwaggerSchemaProps: spec.SwaggerSchemaProps{
+						Example: enumList[0],
+					},
 					VendorExtensible: spec.VendorExtensible{
 						Extensions: spec.Extensions{
 							"x-enum-varnames": varNames,
 						},
 					},
 				}
+				if len(comments) > 0 {
+					schema.Extensions["x-enum-comments"] = comments
+					schema.Extensions["x-enum-descriptions"] = descriptions
+				}
 				// Enum schema created, skip alias resolution
 			}
 		}
@@ -175,7 +198,7 @@ func (b *BuilderService) BuildSchema(typeSpec *domain.TypeSpecDef) (string, erro
 						return "", err
 					}
 					// Copy the resolved schema
-					if resolvedSchema, ok := b.definitions[resolvedType.TypeName()]; ok {
+					if resolvedSchema, ok := b.GetDefinition(resolvedType.TypeName()); ok {
 						schema = resolvedSchema
 					}
 				}
@@ -205,7 +228,7 @@ func (b *BuilderService) BuildSchema(typeSpec *domain.TypeSpecDef) (string, erro
 					return "", err
 				}
 				// Copy the resolved schema
-				if resolvedSchema, ok := b.definitions[resolvedType.TypeName()]; ok {
+				if resolvedSchema, ok := b.GetDefinition(resolvedType.TypeName()); ok {
 					schema = resolvedSchema
 				}
 			}
@@ -216,15 +239,21 @@ func (b *BuilderService) BuildSchema(typeSpec *domain.TypeSpecDef) (string, erro
 		}
 	}
 
-	// Store in definitions
+	// Store in definitions. The lock is not held while building, as building recurses
+	// into aliased types; concurrent builds of the same type store the same schema.
+	b.mu.Lock()
 	b.definitions[schemaName] = schema
 	b.parsedSchemas[typeSpec] = schemaName
+	b.mu.Unlock()
 
 	return schemaName, nil
 }
 
 // AddDefinition adds a schema definition with the given name.
 func (b *BuilderService) AddDefinition(name string, schema spec.Schema) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	b.definitions[name] = schema
 	// Note: We don't have the TypeSpecDef here to add to parsedSchemas
 	// This is OK - BuildSchema will check definitions first
@@ -234,48 +263,26 @@ func (b *BuilderService) AddDefinition(name string, schema spec.Schema) error {
 // GetDefinition retrieves a schema definition by name.
 // Returns the schema and true if found, zero schema and false otherwise.
 func (b *BuilderService) GetDefinition(name string) (spec.Schema, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
 	schema, ok := b.definitions[name]
 	return schema, ok
 }
 
-// Definitions returns all schema definitions.
+// Definitions returns a copy of all schema definitions.
 func (b *BuilderService) Definitions() map[string]spec.Schema {
-	return b.definitions
-}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 
-// applyNamingStrategy applies the configured naming strategy to a field name
-func (b *BuilderService) applyNamingStrategy(fieldName string) string {
-	switch strings.ToLower(b.propNamingStrategy) {
-	case "snakecase", "snake_case":
-		return toSnakeCase(fieldName)
-	case "pascalcase", "pascal_case":
-		return fieldName // Keep as-is (PascalCase)
-	case "camelcase", "camel_case", "":
-		return toCamelCase(fieldName)
-	default:
-		return toCamelCase(fieldName) // Default to camelCase
+	definitions := make(map[string]spec.Schema, len(b.definitions))
+	for name, schema := range b.definitions {
+		definitions[name] = schema
 	}
+	return definitions
 }
 
-// toCamelCase converts PascalCase to camelCase (lowercase first letter)
-func toCamelCase(s string) string {
-	if s == "" {
-		return ""
-	}
-	// Convert first character to lowercase
-	runes := []rune(s)
-	runes[0] = unicode.ToLower(runes[0])
-	return string(runes)
-}
-
-// toSnakeCase converts PascalCase to snake_case
-func toSnakeCase(s string) string {
-	var result []rune
-	for i, r := range s {
-		if i > 0 && unicode.IsUpper(r) {
-			result = append(result, '_')
-		}
-		result = append(result, unicode.ToLower(r))
-	}
-	return string(result)
+// applyNamingStrategy applies the configured naming strategy to a field name
+func (b *BuilderService) applyNamingStrategy(fieldName string) string {
+	return schemautil.PropertyName(fieldName, b.propNamingStrategy)
 }
@@ -1,10 +1,12 @@
 package schema
 
 import (
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/go-openapi/spec"
@@ -254,6 +256,59 @@ func TestBuilderService_Definitions(t *testing.T) {
 			t.Error("expected Product definition")
 		}
 	})
+
+	t.Run("returns a copy", func(t *testing.T) {
+		// Arrange
+		builder := NewBuilder()
+		_ = builder.AddDefinition("User", spec.Schema{})
+
+		// Act
+		defs := builder.Definitions()
+		delete(defs, "User")
+		defs["Product"] = spec.Schema{}
+
+		// Assert
+		if _, ok := builder.GetDefinition("User"); !ok {
+			t.Error("expected User definition to remain")
+		}
+		if _, ok := builder.GetDefinition("Product"); ok {
+			t.Error("expected Product definition not to be added")
+		}
+	})
+}
+
+// TestBuilderService_Concurrent adds, builds and reads definitions from several
+// goroutines; run with -race to catch unsynchronized map access.
+func TestBuilderService_Concurrent(t *testing.T) {
+	builder := NewBuilder()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				name := fmt.Sprintf("Model%d_%d", i, j)
+				_ = builder.AddDefinition(name, spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}})
+				if _, ok := builder.GetDefinition(name); !ok {
+					t.Errorf("expected %s definition", name)
+				}
+				_ = builder.Definitions()
+
+				typeSpec := &domain.TypeSpecDef{
+					TypeSpec: &ast.TypeSpec{Name: ast.NewIdent(name + "ID"), Type: ast.NewIdent("string")},
+				}
+				if _, err := builder.BuildSchema(typeSpec); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(builder.Definitions()); got != 8*50*2 {
+		t.Errorf("expected %d definitions, got %d", 8*50*2, got)
+	}
 }
 
 // TestSchemaBuilder_CoreStructParserIntegration verifies that SchemaBuilder properly
@@ -558,6 +613,21 @@ const (
 		if schema.Extensions == nil || schema.Extensions["x-enum-varnames"] == nil {
 			t.Error("expected x-enum-varnames extension")
 		}
+
+		// Should describe the values from the const comments
+		descriptions, ok := schema.Extensions["x-enum-descriptions"].([]string)
+		if !ok || len(descriptions) != 3 || descriptions[0] != "Administrator" {
+			t.Errorf("expected x-enum-descriptions from the const comments, got %v", schema.Extensions["x-enum-descriptions"])
+		}
+		comments, ok := schema.Extensions["x-enum-comments"].(map[string]string)
+		if !ok || comments["RoleGuest"] != "Guest user" {
+			t.Errorf("expected x-enum-comments from the const comments, got %v", schema.Extensions["x-enum-comments"])
+		}
+
+		// Should use the first value as example
+		if schema.Example != 1 {
+			t.Errorf("expected example 1, got %v", schema.Example)
+		}
 	})
 
 	t.Run("falls back to object for non-enum type alias", func(t *testing.T) {
@@ -6,6 +6,8 @@ import (
 
 // RemoveUnusedDefinitions removes schema definitions that are not referenced anywhere in the Swagger spec.
 // This helps keep the generated documentation clean by eliminating schemas that were generated but never used.
+// Public variants get no special treatment: a <Type>Public definition is kept only when it is reachable
+// from the paths, parameters or responses, even if its base type is used.
 func RemoveUnusedDefinitions(swagger *spec.Swagger) {
 	if swagger == nil || swagger.Definitions == nil {
 		return
@@ -41,98 +43,46 @@ func RemoveUnusedDefinitions(swagger *spec.Swagger) {
 	}
 }
 
+// ReferencedDefinitions returns the names of the definitions v refers to through local $refs.
+// v may be a schema, parameter, response, operation, path item or the whole spec (definitions excluded).
+// Refs inside the referenced definitions are not followed.
+func ReferencedDefinitions(v interface{}) map[string]bool {
+	used := make(map[string]bool)
+	collectRefs(v, used)
+	return used
+}
+
 // collectRefs recursively collects all $ref references in the swagger spec
 func collectRefs(v interface{}, used map[string]bool) {
+	walker := refCollector(used)
+
 	switch val := v.(type) {
 	case spec.Schema:
-		collectSchemaRefs(&val, used)
+		walker.WalkSchema(&val)
 	case *spec.Schema:
-		collectSchemaRefs(val, used)
+		walker.WalkSchema(val)
 	case spec.Response:
-		if val.Schema != nil {
-			collectSchemaRefs(val.Schema, used)
-		}
-		for _, header := range val.Headers {
-			if header.Items != nil {
-				collectItemsRefs(header.Items, used)
-			}
-		}
+		collectResponseRefs(walker, &val, used)
 	case *spec.Response:
-		if val.Schema != nil {
-			collectSchemaRefs(val.Schema, used)
-		}
-		for _, header := range val.Headers {
-			if header.Items != nil {
-				collectItemsRefs(header.Items, used)
-			}
-		}
+		collectResponseRefs(walker, val, used)
 	case spec.Parameter:
-		if val.Schema != nil {
-			collectSchemaRefs(val.Schema, used)
-		}
-		if val.Items != nil {
-			collectItemsRefs(val.Items, used)
-		}
+		walker.WalkParameter(&val)
+		collectExtensionRefs(val.Extensions, used)
 	case *spec.Parameter:
-		if val.Schema != nil {
-			collectSchemaRefs(val.Schema, used)
-		}
-		if val.Items != nil {
-			collectItemsRefs(val.Items, used)
-		}
+		walker.WalkParameter(val)
+		collectExtensionRefs(val.Extensions, used)
 	case spec.Operation:
-		for _, param := range val.Parameters {
-			collectRefs(param, used)
-		}
-		if val.Responses != nil {
-			for _, resp := range val.Responses.StatusCodeResponses {
-				collectRefs(resp, used)
-			}
-			if val.Responses.Default != nil {
-				collectRefs(*val.Responses.Default, used)
-			}
-		}
+		collectOperationRefs(walker, &val, used)
 	case *spec.Operation:
-		for _, param := range val.Parameters {
-			collectRefs(param, used)
-		}
-		if val.Responses != nil {
-			for _, resp := range val.Responses.StatusCodeResponses {
-				collectRefs(resp, used)
-			}
-			if val.Responses.Default != nil {
-				collectRefs(*val.Responses.Default, used)
-			}
-		}
+		collectOperationRefs(walker, val, used)
 	case spec.PathItem:
-		if val.Get != nil {
-			collectRefs(*val.Get, used)
-		}
-		if val.Put != nil {
-			collectRefs(*val.Put, used)
-		}
-		if val.Post != nil {
-			collectRefs(*val.Post, used)
-		}
-		if val.Delete != nil {
-			collectRefs(*val.Delete, used)
-		}
-		if val.Options != nil {
-			collectRefs(*val.Options, used)
-		}
-		if val.Head != nil {
-			collectRefs(*val.Head, used)
-		}
-		if val.Patch != nil {
-			collectRefs(*val.Patch, used)
-		}
-		for _, param := range val.Parameters {
-			collectRefs(param, used)
-		}
+		collectPathItemRefs(walker, &val, used)
 	case *spec.Swagger:
 		// Collect from paths
-		for _, pathItem := range val.Paths.Paths {
-			collectRefs(pathItem, used)
+		if val.Paths != nil {
+			for _, pathItem := range val.Paths.Paths {
+				collectPathItemRefs(walker, &pathItem, used)
+			}
 		}
 		// Collect from parameters
 		for _, param := range val.Parameters {
@@ -142,83 +92,129 @@ func collectRefs(v interface{}, used map[string]bool) {
 		for _, resp := range val.Responses {
 			collectRefs(resp, used)
 		}
+		// Collect from vendor extensions
+		collectExtensionRefs(val.Extensions, used)
 	case map[string]spec.Schema:
 		for _, schema := range val {
-			collectSchemaRefs(&schema, used)
+			walker.WalkSchema(&schema)
 		}
 	}
 }
 
-// collectSchemaRefs collects $ref references from a schema
-func collectSchemaRefs(schema *spec.Schema, used map[string]bool) {
-	if schema == nil {
-		return
+// refCollector returns a walker adding the definitions referenced by schemas and
+// non-body items, and by their vendor extensions, to used.
+func refCollector(used map[string]bool) Walker {
+	return Walker{
+		Schema: func(schema *spec.Schema) {
+			if refName := getRefName(schema.Ref.String()); refName != "" {
+				used[refName] = true
+			}
+			collectExtensionRefs(schema.Extensions, used)
+		},
+		Items: func(items *spec.Items) {
+			if refName := getRefName(items.Ref.String()); refName != "" {
+				used[refName] = true
+			}
+			collectExtensionRefs(items.Extensions, used)
+		},
 	}
+}
 
-	// Check direct $ref
-	if schema.Ref.String() != "" {
-		refName := getRefName(schema.Ref.String())
-		if refName != "" {
-			used[refName] = true
-		}
+// collectResponseRefs collects $ref references from a response, its headers and extensions.
+func collectResponseRefs(walker Walker, resp *spec.Response, used map[string]bool) {
+	walker.WalkResponse(resp)
+	for _, header := range resp.Headers {
+		collectExtensionRefs(header.Extensions, used)
 	}
+	collectExtensionRefs(resp.Extensions, used)
+}
 
-	// Check items
-	if schema.Items != nil {
-		if schema.Items.Schema != nil {
-			collectSchemaRefs(schema.Items.Schema, used)
+// collectOperationRefs collects $ref references from an operation's parameters,
+// responses and extensions, and those of its parameters and responses.
+func collectOperationRefs(walker Walker, op *spec.Operation, used map[string]bool) {
+	walker.WalkOperation(op)
+	for _, param := range op.Parameters {
+		collectExtensionRefs(param.Extensions, used)
+	}
+	if op.Responses != nil {
+		for _, resp := range op.Responses.StatusCodeResponses {
+			collectExtensionRefs(resp.Extensions, used)
+			for _, header := range resp.Headers {
+				collectExtensionRefs(header.Extensions, used)
+			}
 		}
-		for _, itemSchema := range schema.Items.Schemas {
-			collectSchemaRefs(&itemSchema, used)
+		if op.Responses.Default != nil {
+			collectExtensionRefs(op.Responses.Default.Extensions, used)
+			for _, header := range op.Responses.Default.Headers {
+				collectExtensionRefs(header.Extensions, used)
+			}
 		}
 	}
+	collectExtensionRefs(op.Extensions, used)
+}
 
-	// Check properties
-	for _, prop := range schema.Properties {
-		collectSchemaRefs(&prop, used)
-	}
-
-	// Check additional properties
-	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
-		collectSchemaRefs(schema.AdditionalProperties.Schema, used)
-	}
-
-	// Check allOf, oneOf, anyOf
-	for _, s := range schema.AllOf {
-		collectSchemaRefs(&s, used)
+// collectPathItemRefs collects $ref references from a path item's operations,
+// parameters and extensions.
+func collectPathItemRefs(walker Walker, pathItem *spec.PathItem, used map[string]bool) {
+	for _, op := range PathItemOperations(*pathItem) {
+		collectOperationRefs(walker, op, used)
 	}
-	for _, s := range schema.OneOf {
-		collectSchemaRefs(&s, used)
-	}
-	for _, s := range schema.AnyOf {
-		collectSchemaRefs(&s, used)
-	}
-
-	// Check not
-	if schema.Not != nil {
-		collectSchemaRefs(schema.Not, used)
+	for i := range pathItem.Parameters {
+		walker.WalkParameter(&pathItem.Parameters[i])
+		collectExtensionRefs(pathItem.Parameters[i].Extensions, used)
 	}
+	collectExtensionRefs(pathItem.Extensions, used)
+}
 
-	// Check definitions within schema
-	for _, def := range schema.Definitions {
-		collectSchemaRefs(&def, used)
-	}
+// collectSchemaRefs collects $ref references from a schema
+func collectSchemaRefs(schema *spec.Schema, used map[string]bool) {
+	refCollector(used).WalkSchema(schema)
 }
 
-// collectItemsRefs collects $ref references from items
-func collectItemsRefs(items *spec.Items, used map[string]bool) {
-	if items == nil {
-		return
+// collectExtensionRefs collects $ref references from vendor extension values.
+// Extensions are free-form, so any "#/definitions/..." string and any object with
+// a "$ref" key counts, at any depth.
+func collectExtensionRefs(extensions spec.Extensions, used map[string]bool) {
+	for _, value := range extensions {
+		collectValueRefs(value, used)
 	}
+}
 
-	if items.Ref.String() != "" {
-		refName := getRefName(items.Ref.String())
-		if refName != "" {
+// collectValueRefs collects $ref references from an arbitrary extension value,
+// either decoded from JSON or set as a spec type.
+func collectValueRefs(v interface{}, used map[string]bool) {
+	switch val := v.(type) {
+	case string:
+		if refName := getRefName(val); refName != "" {
 			used[refName] = true
 		}
-	}
-
-	if items.Items != nil {
-		collectItemsRefs(items.Items, used)
+	case spec.Ref:
+		if refName := getRefName(val.String()); refName != "" {
+			used[refName] = true
+		}
+	case *spec.Ref:
+		if val != nil {
+			collectValueRefs(*val, used)
+		}
+	case spec.Schema:
+		collectSchemaRefs(&val, used)
+	case *spec.Schema:
+		collectSchemaRefs(val, used)
+	case *spec.Operation:
+		if val != nil {
+			collectRefs(val, used)
+		}
+	case map[string]interface{}:
+		for _, item := range val {
+			collectValueRefs(item, used)
+		}
+	case []interface{}:
+		for _, item := range val {
+			collectValueRefs(item, used)
+		}
+	case []string:
+		for _, item := range val {
+			collectValueRefs(item, used)
+		}
 	}
 }
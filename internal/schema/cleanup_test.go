@@ -352,3 +352,123 @@ func TestCollectSchemaRefs(t *testing.T) {
 		}
 	})
 }
+
+func TestRemoveUnusedDefinitions_PublicVariants(t *testing.T) {
+	objectWith := func(props map[string]spec.Schema) spec.Schema {
+		return spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}, Properties: props}}
+	}
+
+	// Arrange: every model has a generated Public variant, but only some are referenced
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Definitions: map[string]spec.Schema{
+				"account.Account":       objectWith(map[string]spec.Schema{"address": *RefSchema("account.Address")}),
+				"account.AccountPublic": objectWith(map[string]spec.Schema{"address": *RefSchema("account.AddressPublic")}),
+				"account.Address":       objectWith(nil),
+				"account.AddressPublic": objectWith(nil),
+				"user.User":             objectWith(map[string]spec.Schema{"settings": *RefSchema("user.Settings")}),
+				"user.UserPublic":       objectWith(map[string]spec.Schema{"settings": *RefSchema("user.SettingsPublic")}),
+				"user.Settings":         objectWith(nil),
+				"user.SettingsPublic":   objectWith(nil),
+				"user.EmptyPublic":      objectWith(nil),
+			},
+			Paths: &spec.Paths{
+				Paths: map[string]spec.PathItem{
+					"/accounts": {
+						PathItemProps: spec.PathItemProps{
+							Get: &spec.Operation{
+								OperationProps: spec.OperationProps{
+									Responses: &spec.Responses{
+										ResponsesProps: spec.ResponsesProps{
+											StatusCodeResponses: map[int]spec.Response{
+												200: {ResponseProps: spec.ResponseProps{Schema: RefSchema("account.AccountPublic")}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					"/users": {
+						PathItemProps: spec.PathItemProps{
+							Get: &spec.Operation{
+								OperationProps: spec.OperationProps{
+									Responses: &spec.Responses{
+										ResponsesProps: spec.ResponsesProps{
+											StatusCodeResponses: map[int]spec.Response{
+												200: {ResponseProps: spec.ResponseProps{Schema: RefSchema("user.User")}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Act
+	RemoveUnusedDefinitions(swagger)
+
+	// Assert
+	for _, name := range []string{"account.AccountPublic", "account.AddressPublic", "user.User", "user.Settings"} {
+		if _, ok := swagger.Definitions[name]; !ok {
+			t.Errorf("expected %s definition to remain", name)
+		}
+	}
+	for _, name := range []string{"account.Account", "account.Address", "user.UserPublic", "user.SettingsPublic", "user.EmptyPublic"} {
+		if _, ok := swagger.Definitions[name]; ok {
+			t.Errorf("expected unreferenced %s definition to be removed", name)
+		}
+	}
+}
+
+func TestRemoveUnusedDefinitions_ExtensionRefs(t *testing.T) {
+	// Arrange: Related and Nested are only referenced from vendor extensions
+	user := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	user.AddExtension("x-relatedSchema", map[string]interface{}{"$ref": "#/definitions/Related"})
+	related := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	related.AddExtension("x-see-also", []interface{}{"#/definitions/Nested"})
+
+	op := &spec.Operation{}
+	op.AddExtension("x-event", *RefSchema("Event"))
+	op.Responses = &spec.Responses{
+		ResponsesProps: spec.ResponsesProps{
+			StatusCodeResponses: map[int]spec.Response{
+				200: {ResponseProps: spec.ResponseProps{Schema: RefSchema("User")}},
+			},
+		},
+	}
+
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Definitions: map[string]spec.Schema{
+				"User":    user,
+				"Related": related,
+				"Nested":  {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+				"Event":   {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+				"Unused":  {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+			},
+			Paths: &spec.Paths{
+				Paths: map[string]spec.PathItem{
+					"/users": {PathItemProps: spec.PathItemProps{Get: op}},
+				},
+			},
+		},
+	}
+
+	// Act
+	RemoveUnusedDefinitions(swagger)
+
+	// Assert
+	for _, name := range []string{"User", "Related", "Nested", "Event"} {
+		if _, ok := swagger.Definitions[name]; !ok {
+			t.Errorf("expected %s definition to remain", name)
+		}
+	}
+	if _, ok := swagger.Definitions["Unused"]; ok {
+		t.Error("expected Unused to be removed")
+	}
+}
@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/go-openapi/spec"
+)
+
+// TraceExtension is the path item extension holding the TRACE operation,
+// which Swagger 2.0 path items have no field for.
+const TraceExtension = "x-trace"
+
+// ParamSchemaExtension is the parameter extension holding the schema(Type) of a
+// non-body parameter, which Swagger 2.0 allows on body parameters only.
+const ParamSchemaExtension = "x-schema"
+
+// TraceOperation returns the TRACE operation of a path item, or nil when there is none.
+// An operation decoded from JSON is converted and stored back, so changes to the
+// returned operation are kept in the path item.
+func TraceOperation(pathItem spec.PathItem) *spec.Operation {
+	value, ok := pathItem.Extensions[TraceExtension]
+	if !ok || value == nil {
+		return nil
+	}
+	if op, ok := value.(*spec.Operation); ok {
+		return op
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	op := new(spec.Operation)
+	if err := json.Unmarshal(data, op); err != nil {
+		return nil
+	}
+	pathItem.Extensions[TraceExtension] = op
+
+	return op
+}
+
+// SetTraceOperation sets the TRACE operation of a path item, or removes it when op is nil.
+// The extensions are copied first, so copies of the path item are left untouched.
+func SetTraceOperation(pathItem *spec.PathItem, op *spec.Operation) {
+	extensions := make(spec.Extensions, len(pathItem.Extensions)+1)
+	for key, value := range pathItem.Extensions {
+		extensions[key] = value
+	}
+	if op == nil {
+		delete(extensions, TraceExtension)
+	} else {
+		extensions[TraceExtension] = op
+	}
+	if len(extensions) == 0 {
+		extensions = nil
+	}
+	pathItem.Extensions = extensions
+}
+
+// PathItemOperations returns the operations of a path item that are set, including
+// the TRACE operation, in a fixed order.
+func PathItemOperations(pathItem spec.PathItem) []*spec.Operation {
+	var ops []*spec.Operation
+	for _, op := range []*spec.Operation{
+		pathItem.Get, pathItem.Put, pathItem.Post, pathItem.Delete,
+		pathItem.Options, pathItem.Head, pathItem.Patch, TraceOperation(pathItem),
+	} {
+		if op != nil {
+			ops = append(ops, op)
+		}
+	}
+
+	return ops
+}
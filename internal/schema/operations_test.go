@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceOperation(t *testing.T) {
+	t.Run("reads an operation decoded from JSON", func(t *testing.T) {
+		var item spec.PathItem
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"get": {"operationId": "getUser"},
+			"x-trace": {
+				"operationId": "traceUser",
+				"responses": {"200": {"schema": {"$ref": "#/definitions/admin.User"}}}
+			}
+		}`), &item))
+
+		trace := TraceOperation(item)
+		require.NotNil(t, trace)
+		assert.Equal(t, "traceUser", trace.ID)
+
+		ops := PathItemOperations(item)
+		require.Len(t, ops, 2)
+		assert.Same(t, trace, ops[1])
+
+		swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+			Definitions: spec.Definitions{"admin.User": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}}},
+			Paths:       &spec.Paths{Paths: map[string]spec.PathItem{"/users": item}},
+		}}
+		assert.Equal(t, map[string]int{"admin.User": 1}, CountRefs(swagger))
+
+		RenameDefinitions(swagger, map[string]string{"admin.User": "User"})
+		ref := TraceOperation(swagger.Paths.Paths["/users"]).Responses.StatusCodeResponses[200].Schema.Ref
+		assert.Equal(t, "#/definitions/User", ref.String())
+	})
+
+	t.Run("sets and removes the operation on a copy", func(t *testing.T) {
+		item := spec.PathItem{}
+		SetTraceOperation(&item, &spec.Operation{OperationProps: spec.OperationProps{ID: "traceUser"}})
+
+		cleared := item
+		SetTraceOperation(&cleared, nil)
+		assert.Nil(t, TraceOperation(cleared))
+		assert.Nil(t, cleared.Extensions)
+		require.NotNil(t, TraceOperation(item))
+
+		data, err := json.Marshal(item)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"x-trace": {"operationId": "traceUser"}}`, string(data))
+	})
+}
@@ -0,0 +1,156 @@
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/go-openapi/spec"
+)
+
+// refRewriter rewrites a $ref in place. schema is the schema holding the ref,
+// or nil for the items of non-body parameters and headers.
+type refRewriter func(ref *spec.Ref, schema *spec.Schema)
+
+// RenameDefinitions moves definitions to new names and rewrites every $ref to them,
+// in definitions, paths, shared parameters and shared responses.
+// renames maps old definition names to new ones; names not in the map are left untouched.
+func RenameDefinitions(swagger *spec.Swagger, renames map[string]string) {
+	if swagger == nil || len(renames) == 0 {
+		return
+	}
+
+	if swagger.Definitions != nil {
+		definitions := make(spec.Definitions, len(swagger.Definitions))
+		for name, def := range swagger.Definitions {
+			if newName, ok := renames[name]; ok {
+				name = newName
+			}
+			definitions[name] = def
+		}
+		swagger.Definitions = definitions
+	}
+
+	rewriteRefs(swagger, func(ref *spec.Ref, _ *spec.Schema) {
+		if newName, ok := renames[getRefName(ref.String())]; ok {
+			*ref = spec.MustCreateRef("#/definitions/" + newName)
+		}
+	})
+}
+
+// InlineDefinitions removes definitions from the spec and replaces every $ref
+// to them with a generic {"type":"object"} schema. Refs in the items of non-body
+// parameters and headers are dropped. Returns the number of replaced refs.
+func InlineDefinitions(swagger *spec.Swagger, names map[string]bool) int {
+	if swagger == nil || len(names) == 0 {
+		return 0
+	}
+
+	for name := range names {
+		delete(swagger.Definitions, name)
+	}
+
+	replaced := 0
+	rewriteRefs(swagger, func(ref *spec.Ref, schema *spec.Schema) {
+		if !names[getRefName(ref.String())] {
+			return
+		}
+		replaced++
+		*ref = spec.Ref{}
+		if schema != nil {
+			schema.Type = spec.StringOrArray{"object"}
+		}
+	})
+
+	return replaced
+}
+
+// CountRefs returns how many $refs point to each definition, in definitions, paths,
+// shared parameters and shared responses.
+func CountRefs(swagger *spec.Swagger) map[string]int {
+	counts := make(map[string]int)
+	if swagger == nil {
+		return counts
+	}
+
+	rewriteRefs(swagger, func(ref *spec.Ref, _ *spec.Schema) {
+		if name := getRefName(ref.String()); name != "" {
+			counts[name]++
+		}
+	})
+
+	return counts
+}
+
+// ExpandDefinitions replaces every $ref to the named definitions with a copy of the
+// definition's schema, keeping the description and extensions of the referencing
+// schema, and removes the definitions. A definition referenced from the items of a
+// non-body parameter or header can't be expanded there and is kept.
+// Refs of the named definitions to each other are expanded too, so none may refer
+// to itself, directly or not. Returns the number of removed definitions.
+func ExpandDefinitions(swagger *spec.Swagger, names map[string]bool) int {
+	if swagger == nil || len(names) == 0 {
+		return 0
+	}
+
+	kept := make(map[string]bool)
+	rewriteRefs(swagger, func(ref *spec.Ref, schema *spec.Schema) {
+		name := getRefName(ref.String())
+		if !names[name] {
+			return
+		}
+		if schema == nil {
+			kept[name] = true
+			return
+		}
+
+		def, ok := copySchema(swagger.Definitions[name])
+		if !ok {
+			kept[name] = true
+			return
+		}
+		if schema.Description != "" {
+			def.Description = schema.Description
+		}
+		for key, value := range schema.Extensions {
+			def.AddExtension(key, value)
+		}
+		if schema.Nullable {
+			def.Nullable = true
+		}
+		// The copy's own refs are visited next, so refs to other expanded definitions are replaced too
+		*schema = def
+	})
+
+	removed := 0
+	for name := range names {
+		if !kept[name] {
+			delete(swagger.Definitions, name)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// copySchema returns a deep copy of a schema through its JSON encoding.
+func copySchema(schema spec.Schema) (spec.Schema, bool) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return spec.Schema{}, false
+	}
+
+	var copied spec.Schema
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return spec.Schema{}, false
+	}
+
+	return copied, true
+}
+
+// rewriteRefs calls rewrite for every $ref in definitions, paths, shared
+// parameters and shared responses.
+func rewriteRefs(swagger *spec.Swagger, rewrite refRewriter) {
+	Walker{
+		Schema: func(schema *spec.Schema) { rewrite(&schema.Ref, schema) },
+		Items:  func(items *spec.Items) { rewrite(&items.Ref, nil) },
+	}.Walk(swagger)
+}
@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameDefinitions(t *testing.T) {
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Definitions: spec.Definitions{
+				"account.User": {
+					SchemaProps: spec.SchemaProps{
+						Type: []string{"object"},
+						Properties: spec.SchemaProperties{
+							"friends": *spec.ArrayProperty(spec.RefSchema("#/definitions/account.User")),
+							"owner":   *spec.RefSchema("#/definitions/admin.User"),
+						},
+					},
+				},
+				"admin.User": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+				"Other":      {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+			},
+			Paths: &spec.Paths{
+				Paths: map[string]spec.PathItem{
+					"/users": {
+						PathItemProps: spec.PathItemProps{
+							Post: &spec.Operation{
+								OperationProps: spec.OperationProps{
+									Parameters: []spec.Parameter{
+										*spec.BodyParam("user", spec.RefSchema("#/definitions/account.User")),
+									},
+									Responses: &spec.Responses{
+										ResponsesProps: spec.ResponsesProps{
+											StatusCodeResponses: map[int]spec.Response{
+												200: {ResponseProps: spec.ResponseProps{
+													Schema: spec.MapProperty(spec.RefSchema("#/definitions/admin.User")),
+												}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	RenameDefinitions(swagger, map[string]string{
+		"account.User": "User",
+		"admin.User":   "UserAdmin",
+	})
+
+	require.Len(t, swagger.Definitions, 3)
+	require.Contains(t, swagger.Definitions, "User")
+	require.Contains(t, swagger.Definitions, "UserAdmin")
+	require.Contains(t, swagger.Definitions, "Other")
+
+	user := swagger.Definitions["User"]
+	friends := user.Properties["friends"]
+	owner := user.Properties["owner"]
+	assert.Equal(t, "#/definitions/User", friends.Items.Schema.Ref.String())
+	assert.Equal(t, "#/definitions/UserAdmin", owner.Ref.String())
+
+	op := swagger.Paths.Paths["/users"].Post
+	assert.Equal(t, "#/definitions/User", op.Parameters[0].Schema.Ref.String())
+	response := op.Responses.StatusCodeResponses[200]
+	assert.Equal(t, "#/definitions/UserAdmin", response.Schema.AdditionalProperties.Schema.Ref.String())
+}
+
+func TestInlineDefinitions(t *testing.T) {
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Definitions: spec.Definitions{
+				"api.User": {
+					SchemaProps: spec.SchemaProps{
+						Type: []string{"object"},
+						Properties: spec.SchemaProperties{
+							"state":   *spec.RefSchema("#/definitions/api.state"),
+							"history": *spec.ArrayProperty(spec.RefSchema("#/definitions/api.state")),
+							"owner":   *spec.RefSchema("#/definitions/api.User"),
+						},
+					},
+				},
+				"api.state": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+			},
+			Responses: map[string]spec.Response{
+				"State": {ResponseProps: spec.ResponseProps{Schema: spec.RefSchema("#/definitions/api.state")}},
+			},
+		},
+	}
+
+	replaced := InlineDefinitions(swagger, map[string]bool{"api.state": true, "api.statePublic": true})
+
+	assert.Equal(t, 3, replaced)
+	require.Len(t, swagger.Definitions, 1)
+
+	user := swagger.Definitions["api.User"]
+	state := user.Properties["state"]
+	assert.Empty(t, state.Ref.String())
+	assert.Equal(t, spec.StringOrArray{"object"}, state.Type)
+	assert.Empty(t, user.Properties["history"].Items.Schema.Ref.String())
+	owner := user.Properties["owner"]
+	assert.Equal(t, "#/definitions/api.User", owner.Ref.String())
+
+	resp := swagger.Responses["State"]
+	assert.Empty(t, resp.Schema.Ref.String())
+	assert.Equal(t, spec.StringOrArray{"object"}, resp.Schema.Type)
+}
+
+func TestExpandDefinitions(t *testing.T) {
+	address := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: spec.SchemaProperties{"street": *spec.StringProperty()},
+	}}
+	pet := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: spec.SchemaProperties{"address": *spec.RefSchema("#/definitions/Address").WithDescription("Home")},
+	}}
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Definitions: spec.Definitions{"Address": address, "Pet": pet},
+			Responses: map[string]spec.Response{
+				"Pet": {ResponseProps: spec.ResponseProps{Schema: spec.RefSchema("#/definitions/Pet")}},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]int{"Address": 1, "Pet": 1}, CountRefs(swagger))
+
+	removed := ExpandDefinitions(swagger, map[string]bool{"Address": true, "Pet": true})
+
+	assert.Equal(t, 2, removed)
+	assert.Empty(t, swagger.Definitions)
+
+	resp := swagger.Responses["Pet"]
+	assert.Empty(t, resp.Schema.Ref.String())
+	expanded := resp.Schema.Properties["address"]
+	assert.Empty(t, expanded.Ref.String())
+	assert.Equal(t, "Home", expanded.Description)
+	assert.Equal(t, spec.StringOrArray{"string"}, expanded.Properties["street"].Type)
+}
@@ -0,0 +1,159 @@
+package schema
+
+import (
+	"github.com/go-openapi/spec"
+)
+
+// Walker visits the schemas of a spec and their sub-schemas, parents first.
+// Changes made by the visit functions are kept, also for schemas stored by
+// value in maps, and the sub-schemas of a changed schema are the new ones.
+type Walker struct {
+	// Schema is called for every schema, nil to skip schemas
+	Schema func(schema *spec.Schema)
+	// Items is called for the (nested) items of non-body parameters and
+	// headers, which aren't schemas, nil to skip them
+	Items func(items *spec.Items)
+}
+
+// Walk visits the schemas of definitions, shared parameters and responses and
+// of the path items and their operations.
+func (w Walker) Walk(swagger *spec.Swagger) {
+	if swagger == nil {
+		return
+	}
+
+	for name, def := range swagger.Definitions {
+		w.WalkSchema(&def)
+		swagger.Definitions[name] = def
+	}
+
+	for name, param := range swagger.Parameters {
+		w.WalkParameter(&param)
+		swagger.Parameters[name] = param
+	}
+
+	for name, resp := range swagger.Responses {
+		w.WalkResponse(&resp)
+		swagger.Responses[name] = resp
+	}
+
+	if swagger.Paths == nil {
+		return
+	}
+
+	for path, pathItem := range swagger.Paths.Paths {
+		w.WalkPathItem(&pathItem)
+		swagger.Paths.Paths[path] = pathItem
+	}
+}
+
+// WalkPathItem visits the schemas of a path item's parameters and operations.
+func (w Walker) WalkPathItem(pathItem *spec.PathItem) {
+	for i := range pathItem.Parameters {
+		w.WalkParameter(&pathItem.Parameters[i])
+	}
+	for _, op := range PathItemOperations(*pathItem) {
+		w.WalkOperation(op)
+	}
+}
+
+// WalkOperation visits the schemas of an operation's parameters and responses.
+func (w Walker) WalkOperation(op *spec.Operation) {
+	if op == nil {
+		return
+	}
+
+	for i := range op.Parameters {
+		w.WalkParameter(&op.Parameters[i])
+	}
+
+	if op.Responses == nil {
+		return
+	}
+
+	if op.Responses.Default != nil {
+		w.WalkResponse(op.Responses.Default)
+	}
+
+	for code, resp := range op.Responses.StatusCodeResponses {
+		w.WalkResponse(&resp)
+		op.Responses.StatusCodeResponses[code] = resp
+	}
+}
+
+// WalkParameter visits a parameter's schema, items and x-schema extension.
+func (w Walker) WalkParameter(param *spec.Parameter) {
+	w.WalkSchema(param.Schema)
+	w.walkItems(param.Items)
+	if paramSchema, ok := param.Extensions[ParamSchemaExtension].(*spec.Schema); ok {
+		w.WalkSchema(paramSchema)
+	}
+}
+
+// WalkResponse visits a response's schema and header items.
+func (w Walker) WalkResponse(resp *spec.Response) {
+	w.WalkSchema(resp.Schema)
+	for name, header := range resp.Headers {
+		w.walkItems(header.Items)
+		resp.Headers[name] = header
+	}
+}
+
+// WalkSchema visits a schema and all of its sub-schemas.
+func (w Walker) WalkSchema(schema *spec.Schema) {
+	if schema == nil {
+		return
+	}
+
+	if w.Schema != nil {
+		w.Schema(schema)
+	}
+
+	if schema.Items != nil {
+		w.WalkSchema(schema.Items.Schema)
+		for i := range schema.Items.Schemas {
+			w.WalkSchema(&schema.Items.Schemas[i])
+		}
+	}
+
+	for key, prop := range schema.Properties {
+		w.WalkSchema(&prop)
+		schema.Properties[key] = prop
+	}
+
+	for key, prop := range schema.PatternProperties {
+		w.WalkSchema(&prop)
+		schema.PatternProperties[key] = prop
+	}
+
+	if schema.AdditionalProperties != nil {
+		w.WalkSchema(schema.AdditionalProperties.Schema)
+	}
+
+	if schema.AdditionalItems != nil {
+		w.WalkSchema(schema.AdditionalItems.Schema)
+	}
+
+	for _, schemas := range [][]spec.Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for i := range schemas {
+			w.WalkSchema(&schemas[i])
+		}
+	}
+
+	w.WalkSchema(schema.Not)
+
+	for key, def := range schema.Definitions {
+		w.WalkSchema(&def)
+		schema.Definitions[key] = def
+	}
+}
+
+// walkItems visits (nested) non-body items.
+func (w Walker) walkItems(items *spec.Items) {
+	if w.Items == nil {
+		return
+	}
+	for ; items != nil; items = items.Items {
+		w.Items(items)
+	}
+}
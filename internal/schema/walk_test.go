@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalker(t *testing.T) {
+	t.Run("visits every schema and keeps changes to map values", func(t *testing.T) {
+		param := spec.QueryParam("ids").Typed("array", "")
+		param.Items = spec.NewItems().Typed("string", "")
+		param.AddExtension(ParamSchemaExtension, spec.StringProperty().WithTitle("ids"))
+
+		swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+			Definitions: spec.Definitions{
+				"Pet": *spec.MapProperty(spec.RefProperty("#/definitions/Tag")).
+					SetProperty("owner", *spec.RefProperty("#/definitions/Owner")).WithTitle("Pet"),
+			},
+			Paths: &spec.Paths{Paths: map[string]spec.PathItem{"/pets": {}}},
+		}}
+		item := swagger.Paths.Paths["/pets"]
+		SetTraceOperation(&item, &spec.Operation{OperationProps: spec.OperationProps{
+			Parameters: []spec.Parameter{*param},
+			Responses: &spec.Responses{ResponsesProps: spec.ResponsesProps{
+				StatusCodeResponses: map[int]spec.Response{200: *spec.NewResponse().WithSchema(spec.RefProperty("#/definitions/Pet"))},
+			}},
+		}})
+		swagger.Paths.Paths["/pets"] = item
+
+		var visited []string
+		items := 0
+		Walker{
+			Schema: func(schema *spec.Schema) {
+				visited = append(visited, schema.Ref.String()+schema.Title)
+				schema.Description = "visited"
+			},
+			Items: func(*spec.Items) { items++ },
+		}.Walk(swagger)
+
+		sort.Strings(visited)
+		assert.Equal(t, []string{"#/definitions/Owner", "#/definitions/Pet", "#/definitions/Tag", "Pet", "ids"}, visited)
+		assert.Equal(t, 1, items)
+		assert.Equal(t, "visited", swagger.Definitions["Pet"].Properties["owner"].Description)
+		response := TraceOperation(swagger.Paths.Paths["/pets"]).Responses.StatusCodeResponses[200]
+		assert.Equal(t, "visited", response.Schema.Description)
+	})
+
+	t.Run("walks the sub-schemas of a replaced schema", func(t *testing.T) {
+		root := spec.RefProperty("#/definitions/Pet")
+		var refs []string
+		Walker{Schema: func(schema *spec.Schema) {
+			if ref := schema.Ref.String(); ref != "" {
+				refs = append(refs, ref)
+			}
+			if schema.Ref.String() == "#/definitions/Pet" {
+				*schema = *spec.ArrayProperty(spec.RefProperty("#/definitions/Tag"))
+			}
+		}}.WalkSchema(root)
+
+		assert.Equal(t, []string{"#/definitions/Pet", "#/definitions/Tag"}, refs)
+	})
+}
@@ -0,0 +1,58 @@
+package schemautil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PropertyName names a property after a Go field name following a naming
+// strategy: "camelcase", "snakecase" or "pascalcase" (also "camel_case",
+// "snake_case" and "pascal_case"). Other strategies keep the field name.
+func PropertyName(fieldName, strategy string) string {
+	switch strings.ToLower(strategy) {
+	case "camelcase", "camel_case":
+		return toCamelCase(fieldName)
+	case "snakecase", "snake_case":
+		return toSnakeCase(fieldName)
+	case "pascalcase", "pascal_case":
+		runes := []rune(fieldName)
+		if len(runes) > 0 {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		return string(runes)
+	default:
+		return fieldName
+	}
+}
+
+// toCamelCase lowercases the leading word of a Go field name, keeping
+// initialisms together: FirstName -> firstName, ID -> id, HTTPServer -> httpServer.
+func toCamelCase(s string) string {
+	runes := []rune(s)
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		// The last capital of an initialism starts the next word (HTTPServer)
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// toSnakeCase splits a Go field name into lowercase words joined by
+// underscores, keeping initialisms together: FirstName -> first_name, UserID -> user_id.
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -11,16 +11,24 @@ import (
 
 // TypeEntry maps a custom Go type to its OpenAPI schema type and format.
 type TypeEntry struct {
-	SchemaType string // "string", "number", "integer", "boolean", "object"
-	Format     string // "uuid", "date-time", "uri", "byte", ""
+	SchemaType string      // "string", "number", "integer", "boolean", "object"
+	Format     string      // "uuid", "date-time", "uri", "byte", ""
+	Example    interface{} // optional example value
 }
 
-// registry is the central map of custom types to their OpenAPI representations.
-// Go primitives (int, string, bool, etc.) are NOT included here — only extended
-// types that need special OpenAPI mapping.
-var registry = map[string]TypeEntry{
+// time.Duration representations, see Registry.SetDurationAsInt.
+var (
+	durationAsString = TypeEntry{SchemaType: "string", Example: "5s"}
+	durationAsInt    = TypeEntry{SchemaType: "integer", Format: "int64"}
+)
+
+// builtins are the custom types mapped by default. Go primitives (int, string,
+// bool, etc.) are NOT included here — only extended types that need special
+// OpenAPI mapping.
+var builtins = map[string]TypeEntry{
 	// Time
-	"time.Time": {SchemaType: "string", Format: "date-time"},
+	"time.Time":     {SchemaType: "string", Format: "date-time"},
+	"time.Duration": durationAsString,
 
 	// UUID variants
 	"types.UUID":                             {SchemaType: "string", Format: "uuid"},
@@ -45,26 +53,74 @@ var registry = map[string]TypeEntry{
 	"[]uint8": {SchemaType: "string", Format: "byte"},
 }
 
+// Registry maps custom types to their OpenAPI representations for one build: the
+// builtins plus the mappings added through Register. A nil *Registry maps the builtins.
+type Registry struct {
+	entries map[string]TypeEntry
+
+	// registered tracks the types mapped through Register, which take precedence
+	// over the built-in options like SetDurationAsInt.
+	registered map[string]bool
+}
+
+// builtinRegistry serves the package-level lookups and nil registries. It is never modified.
+var builtinRegistry = New()
+
+// New returns a registry holding the builtins.
+func New() *Registry {
+	entries := make(map[string]TypeEntry, len(builtins))
+	for typeName, entry := range builtins {
+		entries[typeName] = entry
+	}
+	return &Registry{entries: entries, registered: map[string]bool{}}
+}
+
+// Register adds or replaces the mapping for a custom type, e.g. from user overrides.
+// Must be called before schema building starts.
+func (r *Registry) Register(typeName string, entry TypeEntry) {
+	clean := strings.TrimPrefix(typeName, "*")
+	r.entries[clean] = entry
+	r.registered[clean] = true
+}
+
+// SetDurationAsInt selects how time.Duration is represented: as its integer
+// nanosecond count, or by default as a string like "5s". A mapping registered
+// for time.Duration is left untouched.
+// Must be called before schema building starts.
+func (r *Registry) SetDurationAsInt(asInt bool) {
+	if r.registered["time.Duration"] {
+		return
+	}
+	if asInt {
+		r.entries["time.Duration"] = durationAsInt
+	} else {
+		r.entries["time.Duration"] = durationAsString
+	}
+}
+
 // Lookup returns the TypeEntry for a custom type. Strips leading `*` before matching.
 // Returns false if the type is not a registered custom type.
-func Lookup(typeName string) (TypeEntry, bool) {
+func (r *Registry) Lookup(typeName string) (TypeEntry, bool) {
+	if r == nil {
+		r = builtinRegistry
+	}
 	clean := strings.TrimPrefix(typeName, "*")
-	entry, ok := registry[clean]
+	entry, ok := r.entries[clean]
 	return entry, ok
 }
 
 // IsExtendedPrimitive returns true if the type is a registered custom type
 // that should be treated as a primitive in OpenAPI (not a model/$ref).
 // Does NOT include basic Go primitives — only extended types like UUID, Time, Decimal.
-func IsExtendedPrimitive(typeName string) bool {
-	_, ok := Lookup(typeName)
+func (r *Registry) IsExtendedPrimitive(typeName string) bool {
+	_, ok := r.Lookup(typeName)
 	return ok
 }
 
 // ToSchema builds an OpenAPI spec.Schema for a registered custom type.
 // Returns nil if the type is not registered.
-func ToSchema(typeName string) *spec.Schema {
-	entry, ok := Lookup(typeName)
+func (r *Registry) ToSchema(typeName string) *spec.Schema {
+	entry, ok := r.Lookup(typeName)
 	if !ok {
 		return nil
 	}
@@ -76,5 +132,60 @@ func ToSchema(typeName string) *spec.Schema {
 	if entry.Format != "" {
 		schema.Format = entry.Format
 	}
+	if entry.Example != nil {
+		schema.Example = entry.Example
+	}
 	return schema
 }
+
+// Lookup returns the built-in TypeEntry for a custom type, see Registry.Lookup.
+func Lookup(typeName string) (TypeEntry, bool) {
+	return builtinRegistry.Lookup(typeName)
+}
+
+// TimeFormat returns the format of a named type defined as time.Time, e.g.
+// type DateOnly time.Time: "date" when a word of its name is Date and none is
+// Time or Timestamp, like Date, DateOnly or BirthDate, and "date-time" like
+// time.Time otherwise.
+func TimeFormat(typeName string) string {
+	date := false
+	for _, word := range nameWords(typeName[strings.LastIndex(typeName, ".")+1:]) {
+		switch strings.ToLower(word) {
+		case "date":
+			date = true
+		case "time", "timestamp":
+			return "date-time"
+		}
+	}
+	if date {
+		return "date"
+	}
+	return "date-time"
+}
+
+// nameWords splits a camel case or snake case identifier into its words.
+func nameWords(name string) []string {
+	var words []string
+	start := 0
+	for i := 1; i <= len(name); i++ {
+		if i < len(name) && name[i] != '_' && !(name[i] >= 'A' && name[i] <= 'Z') {
+			continue
+		}
+		if word := strings.Trim(name[start:i], "_"); word != "" {
+			words = append(words, word)
+		}
+		start = i
+	}
+	return words
+}
+
+// IsExtendedPrimitive reports whether the type is a built-in custom type, see
+// Registry.IsExtendedPrimitive.
+func IsExtendedPrimitive(typeName string) bool {
+	return builtinRegistry.IsExtendedPrimitive(typeName)
+}
+
+// ToSchema builds an OpenAPI spec.Schema for a built-in custom type, see Registry.ToSchema.
+func ToSchema(typeName string) *spec.Schema {
+	return builtinRegistry.ToSchema(typeName)
+}
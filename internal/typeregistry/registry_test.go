@@ -56,6 +56,27 @@ func TestIsExtendedPrimitive(t *testing.T) {
 	assert.False(t, IsExtendedPrimitive("string"))
 }
 
+func TestTimeFormat(t *testing.T) {
+	tests := []struct {
+		typeName string
+		want     string
+	}{
+		{"DateOnly", "date"},
+		{"types.Date", "date"},
+		{"api.BirthDate", "date"},
+		{"DateTime", "date-time"},
+		{"types.Timestamp", "date-time"},
+		{"UpdatedAt", "date-time"},
+		{"date", "date"},
+		{"start_date", "date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			assert.Equal(t, tt.want, TimeFormat(tt.typeName))
+		})
+	}
+}
+
 func TestToSchema(t *testing.T) {
 	schema := ToSchema("types.UUID")
 	assert.Equal(t, spec.StringOrArray{"string"}, schema.Type)
@@ -72,3 +93,52 @@ func TestToSchema(t *testing.T) {
 	// Unknown type returns nil
 	assert.Nil(t, ToSchema("unknown.Type"))
 }
+
+func TestRegistry_SetDurationAsInt(t *testing.T) {
+	registry := New()
+
+	schema := registry.ToSchema("*time.Duration")
+	assert.Equal(t, spec.StringOrArray{"string"}, schema.Type)
+	assert.Equal(t, "", schema.Format)
+	assert.Equal(t, "5s", schema.Example)
+
+	registry.SetDurationAsInt(true)
+	schema = registry.ToSchema("time.Duration")
+	assert.Equal(t, spec.StringOrArray{"integer"}, schema.Type)
+	assert.Equal(t, "int64", schema.Format)
+	assert.Nil(t, schema.Example)
+
+	// The builtins are left unchanged
+	assert.Equal(t, spec.StringOrArray{"string"}, ToSchema("time.Duration").Type)
+
+	registry.SetDurationAsInt(false)
+	schema = registry.ToSchema("time.Duration")
+	assert.Equal(t, spec.StringOrArray{"string"}, schema.Type)
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := New()
+	registry.Register("*github.com/acme/money.Amount", TypeEntry{SchemaType: "number", Format: "double"})
+	registry.Register("time.Duration", TypeEntry{SchemaType: "integer"})
+	registry.Register("uuid.UUID", TypeEntry{SchemaType: "string"})
+
+	entry, ok := registry.Lookup("github.com/acme/money.Amount")
+	assert.True(t, ok)
+	assert.Equal(t, "double", entry.Format)
+
+	// A registered mapping takes precedence over the built-in duration option
+	registry.SetDurationAsInt(true)
+	entry, _ = registry.Lookup("time.Duration")
+	assert.Equal(t, TypeEntry{SchemaType: "integer"}, entry)
+
+	// Other registries and the builtins don't see the mappings
+	for _, other := range []*Registry{New(), nil} {
+		_, ok = other.Lookup("github.com/acme/money.Amount")
+		assert.False(t, ok)
+		entry, ok = other.Lookup("uuid.UUID")
+		assert.True(t, ok)
+		assert.Equal(t, "uuid", entry.Format)
+	}
+	_, ok = Lookup("github.com/acme/money.Amount")
+	assert.False(t, ok)
+}
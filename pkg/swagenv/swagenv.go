@@ -0,0 +1,31 @@
+// Package swagenv substitutes environment variables into generated specs at
+// runtime, for specs generated with --hostEnv or --basePathEnv.
+package swagenv
+
+import (
+	"bytes"
+	"os"
+)
+
+// Placeholder returns the placeholder written for an environment variable, e.g. "${HOST}".
+func Placeholder(name string) string {
+	return "${" + name + "}"
+}
+
+// Expand replaces the ${NAME} placeholders of the given environment variables
+// in a generated swagger.json or swagger.yaml with their current values, e.g.
+//
+//	doc = swagenv.Expand(doc, "HOST", "BASE_PATH")
+//
+// before serving the document. Only the named variables are expanded, so "$ref"
+// and other text is left untouched. Placeholders of unset variables are kept.
+func Expand(doc []byte, names ...string) []byte {
+	for _, name := range names {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		doc = bytes.ReplaceAll(doc, []byte(Placeholder(name)), []byte(value))
+	}
+	return doc
+}
@@ -0,0 +1,19 @@
+package swagenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpand(t *testing.T) {
+	t.Run("should expand only the named variables", func(t *testing.T) {
+		t.Setenv("API_HOST", "api.example.com")
+		t.Setenv("API_BASE_PATH", "/v2")
+
+		doc := []byte(`{"host":"${API_HOST}","basePath":"${API_BASE_PATH}","other":"${OTHER}","$ref":"#/definitions/x"}`)
+		expanded := Expand(doc, "API_HOST", "API_BASE_PATH", "UNSET_VAR")
+
+		assert.Equal(t, `{"host":"api.example.com","basePath":"/v2","other":"${OTHER}","$ref":"#/definitions/x"}`, string(expanded))
+	})
+}
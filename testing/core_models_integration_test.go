@@ -457,6 +457,14 @@ func TestCoreModelsIntegration(t *testing.T) {
 		varNames, hasVarNames := roleDef.Extensions["x-enum-varnames"]
 		require.True(t, hasVarNames, "constants.Role should have x-enum-varnames")
 		require.NotNil(t, varNames, "x-enum-varnames should not be nil")
+
+		// Verify x-enum-descriptions from the const comments
+		descriptions, hasDescriptions := roleDef.Extensions["x-enum-descriptions"]
+		require.True(t, hasDescriptions, "constants.Role should have x-enum-descriptions")
+		assert.Contains(t, descriptions, "System Admin", "x-enum-descriptions should hold the const comments")
+
+		// Verify the first enum value is used as example
+		assert.Equal(t, roleDef.Enum[0], roleDef.Example, "constants.Role example should be its first enum value")
 	})
 
 	t.Run("Account config_key field should $ref constants.GlobalConfigKey definition", func(t *testing.T) {
@@ -489,6 +497,8 @@ func TestCoreModelsIntegration(t *testing.T) {
 		varNames, hasVarNames := configDef.Extensions["x-enum-varnames"]
 		require.True(t, hasVarNames, "constants.GlobalConfigKey should have x-enum-varnames")
 		require.NotNil(t, varNames, "x-enum-varnames should not be nil")
+
+		assert.Equal(t, configDef.Enum[0], configDef.Example, "constants.GlobalConfigKey example should be its first enum value")
 	})
 
 	t.Run("Properties nj_dl_classification should $ref constants.NJDLClassification definition", func(t *testing.T) {
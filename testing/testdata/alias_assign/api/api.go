@@ -0,0 +1,16 @@
+package api
+
+import (
+	"net/http"
+
+	_ "github.com/griffnb/core-swag/testing/testdata/alias_assign/model"
+)
+
+// @Description get an account
+// @ID get-account
+// @Param id path string true "Account ID"
+// @Success 200 {object} model.Account
+// @Success 201 {object} model.Holder
+// @Success 202 {object} model.Name
+// @Router /accounts/{id} [get]
+func GetAccount(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,4 @@
+package ids
+
+// UUID is a binary identifier, documented as a string in its canonical textual form
+type UUID [16]byte
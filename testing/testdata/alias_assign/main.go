@@ -0,0 +1,19 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/griffnb/core-swag/testing/testdata/alias_assign/api"
+)
+
+// @title Swagger Example API
+// @version 1.0
+// @description This is a sample server
+
+// @host petstore.swagger.io
+// @BasePath /v2
+
+func main() {
+	http.HandleFunc("/accounts", api.GetAccount)
+	http.ListenAndServe(":8080", nil)
+}
@@ -0,0 +1,25 @@
+package model
+
+import "github.com/griffnb/core-swag/testing/testdata/alias_assign/ids"
+
+// AccountID is an alias of the identifier type, not a new type
+type AccountID = ids.UUID
+
+// Name is an alias of a primitive
+type Name = string
+
+// Profile is aliased by Account
+type Profile struct {
+	Bio string `json:"bio"`
+}
+
+// Account is an alias of a struct
+type Account = Profile
+
+// Holder references the aliases from its fields
+type Holder struct {
+	ID       AccountID `json:"id"`
+	Name     Name      `json:"name"`
+	Account  *Account  `json:"account"`
+	Accounts []Account `json:"accounts"`
+}
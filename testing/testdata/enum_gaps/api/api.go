@@ -0,0 +1,13 @@
+package api
+
+import (
+	"net/http"
+
+	_ "github.com/griffnb/core-swag/testing/testdata/enum_gaps/model"
+)
+
+// @Description get a grade
+// @ID get-grade
+// @Success 200 {object} model.Report
+// @Router /grades [get]
+func GetGrade(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/griffnb/core-swag/testing/testdata/enum_gaps/api"
+)
+
+// @title Swagger Example API
+// @version 1.0
+// @description This is a sample server
+
+// @host petstore.swagger.io
+// @BasePath /v2
+
+func main() {
+	http.HandleFunc("/grades", api.GetGrade)
+	http.ListenAndServe(":8080", nil)
+}
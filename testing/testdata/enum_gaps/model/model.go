@@ -0,0 +1,25 @@
+package model
+
+// Grade skips its second value
+type Grade int
+
+const (
+	A Grade = iota
+	_
+	C
+)
+
+// Code has non-contiguous values
+type Code int
+
+const (
+	CodeOK       Code = 200
+	CodeNotFound Code = 404
+	_            Code = 500
+	CodeTeapot   Code = 418
+)
+
+type Report struct {
+	Grade Grade  `json:"grade"`
+	Codes []Code `json:"codes"`
+}
@@ -0,0 +1,13 @@
+package api
+
+import (
+	"net/http"
+
+	_ "github.com/griffnb/core-swag/testing/testdata/enum_split/model"
+)
+
+// @Description get an order
+// @ID get-order
+// @Success 200 {object} model.Order
+// @Router /orders [get]
+func GetOrder(w http.ResponseWriter, r *http.Request) {}
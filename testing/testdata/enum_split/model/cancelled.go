@@ -0,0 +1,7 @@
+package model
+
+// Declared in a file sorted before the one declaring Status
+const (
+	StatusCancelled Status = "cancelled"
+	StatusRefunded  Status = "refunded"
+)
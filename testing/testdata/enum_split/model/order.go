@@ -0,0 +1,13 @@
+package model
+
+// Status of an order, its members are declared in several files
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusPaid    Status = "paid"
+)
+
+type Order struct {
+	Status Status `json:"status"`
+}
@@ -0,0 +1,3 @@
+package model
+
+const StatusShipped Status = "shipped"
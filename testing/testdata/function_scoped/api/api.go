@@ -0,0 +1,30 @@
+package api
+
+import "net/http"
+
+// Tag is a package-level type referenced from a handler-local struct
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// @Description get a pet
+// @ID get-pet
+// @Success 200 {object} api.GetPet.response
+// @Router /pets [get]
+func GetPet(w http.ResponseWriter, r *http.Request) {
+	// owner is a local type referenced from another local type
+	type owner struct {
+		Name string `json:"name"`
+	}
+
+	// response is the body returned by GetPet
+	type response struct {
+		ID     int     `json:"id"`
+		Name   string  `json:"name"`
+		Tags   []Tag   `json:"tags"`
+		Owner  *owner  `json:"owner"`
+		Owners []owner `json:"owners"`
+	}
+
+	_ = response{}
+}
@@ -0,0 +1,40 @@
+package api
+
+import "net/http"
+
+// Address is shared by the pet and store responses
+type Address struct {
+	City string `json:"city"`
+}
+
+// Owner is only reachable from the pets operations
+type Owner struct {
+	Name      string    `json:"name"`
+	Locations []Address `json:"locations"`
+}
+
+// Pet is only reachable from the pets operations
+type Pet struct {
+	ID     int     `json:"id"`
+	Owners []Owner `json:"owners"`
+}
+
+// Store is only reachable from the stores operations
+type Store struct {
+	ID        int       `json:"id"`
+	Locations []Address `json:"locations"`
+}
+
+// @Description get a pet
+// @ID get-pet
+// @Tags pets
+// @Success 200 {object} api.Pet
+// @Router /pets [get]
+func GetPet(w http.ResponseWriter, r *http.Request) {}
+
+// @Description get a store
+// @ID get-store
+// @Tags stores
+// @Success 200 {object} api.Store
+// @Router /stores [get]
+func GetStore(w http.ResponseWriter, r *http.Request) {}
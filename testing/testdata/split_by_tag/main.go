@@ -0,0 +1,20 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/griffnb/core-swag/testing/testdata/split_by_tag/api"
+)
+
+// @title Swagger Example API
+// @version 1.0
+// @description This is a sample server
+
+// @host petstore.swagger.io
+// @BasePath /v2
+
+func main() {
+	http.HandleFunc("/pets", api.GetPet)
+	http.HandleFunc("/stores", api.GetStore)
+	http.ListenAndServe(":8080", nil)
+}